@@ -15,7 +15,11 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/KyleYe/open-im-protocol/auth"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/session"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/a2r"
 	"github.com/gin-gonic/gin"
@@ -28,11 +32,15 @@ func NewAuthApi(client rpcclient.Auth) AuthApi {
 }
 
 func (o *AuthApi) UserToken(c *gin.Context) {
-	a2r.Call(auth.AuthClient.UserToken, o.Client, c)
+	a2r.Call(session.SessionClient.UserToken, o.SessionClient, c)
 }
 
 func (o *AuthApi) GetUserToken(c *gin.Context) {
-	a2r.Call(auth.AuthClient.GetUserToken, o.Client, c)
+	a2r.Call(session.SessionClient.GetUserToken, o.SessionClient, c)
+}
+
+func (o *AuthApi) RefreshToken(c *gin.Context) {
+	a2r.Call(session.SessionClient.RefreshToken, o.SessionClient, c)
 }
 
 func (o *AuthApi) ParseToken(c *gin.Context) {
@@ -42,3 +50,10 @@ func (o *AuthApi) ParseToken(c *gin.Context) {
 func (o *AuthApi) ForceLogout(c *gin.Context) {
 	a2r.Call(auth.AuthClient.ForceLogout, o.Client, c)
 }
+
+// JWKS publishes the public keys tokens are verified with.
+func JWKS(keySet *authverify.KeySet) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, keySet.JWKS())
+	}
+}
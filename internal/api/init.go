@@ -41,6 +41,8 @@ type Config struct {
 	API       config.API
 	Share     config.Share
 	Discovery config.Discovery
+	// ThirdConfig locates the objects when the third service stores them locally
+	ThirdConfig config.Third
 }
 
 func Start(ctx context.Context, index int, config *Config) error {
@@ -63,7 +65,10 @@ func Start(ctx context.Context, index int, config *Config) error {
 		prometheusPort int
 	)
 
-	router := newGinRouter(client, config)
+	router, err := newGinRouter(client, config)
+	if err != nil {
+		return err
+	}
 	if config.API.Prometheus.Enable {
 		go func() {
 			prometheusPort, err = datautil.GetElemByIndex(config.API.Prometheus.Ports, index)
@@ -21,6 +21,10 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/apistruct"
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/reaction"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/a2r"
 	"github.com/KyleYe/open-im-tools/apiresp"
@@ -85,11 +89,15 @@ func (m MessageApi) newUserSendMsgReq(_ *gin.Context, params *apistruct.SendMsg)
 	if params.NotOfflinePush {
 		datautil.SetSwitchFromOptions(options, constant.IsOfflinePush, false)
 	}
+	clientMsgID := params.ClientMsgID
+	if clientMsgID == "" {
+		clientMsgID = idutil.GetMsgIDByMD5(params.SendID)
+	}
 	pbData := msg.SendMsgReq{
 		MsgData: &sdkws.MsgData{
 			SendID:           params.SendID,
 			GroupID:          params.GroupID,
-			ClientMsgID:      idutil.GetMsgIDByMD5(params.SendID),
+			ClientMsgID:      clientMsgID,
 			SenderPlatformID: params.SenderPlatformID,
 			SenderNickname:   params.SenderNickname,
 			SenderFaceURL:    params.SenderFaceURL,
@@ -119,6 +127,18 @@ func (m *MessageApi) RevokeMsg(c *gin.Context) {
 	a2r.Call(msg.MsgClient.RevokeMsg, m.Client, c)
 }
 
+func (m *MessageApi) EditMsg(c *gin.Context) {
+	a2r.Call(msgedit.MsgEditClient.EditMsg, m.EditClient, c)
+}
+
+func (m *MessageApi) AddReaction(c *gin.Context) {
+	a2r.Call(reaction.ReactionClient.AddReaction, m.ReactionClient, c)
+}
+
+func (m *MessageApi) RemoveReaction(c *gin.Context) {
+	a2r.Call(reaction.ReactionClient.RemoveReaction, m.ReactionClient, c)
+}
+
 func (m *MessageApi) MarkMsgsAsRead(c *gin.Context) {
 	a2r.Call(msg.MsgClient.MarkMsgsAsRead, m.Client, c)
 }
@@ -248,6 +268,42 @@ func (m *MessageApi) SendMessage(c *gin.Context) {
 	apiresp.GinSuccess(c, respPb)
 }
 
+// ScheduleSendMsg schedules a message, it is sent with the identity of its sender at the schedule time.
+func (m *MessageApi) ScheduleSendMsg(c *gin.Context) {
+	var req apistruct.ScheduleSendMsgReq
+	if err := c.BindJSON(&req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	sendMsgReq, err := m.getSendMsgReq(c, req.SendMsg)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	sendMsgReq.MsgData.RecvID = req.RecvID
+	if !authverify.IsAppManagerUid(c, m.imAdminUserID) {
+		// Users schedule their own messages, only app managers send system messages.
+		sendMsgReq.MsgData.MsgFrom = constant.UserMsgType
+	}
+	resp, err := m.ScheduledClient.ScheduleSendMsg(c, &scheduledmsg.ScheduleSendMsgReq{
+		MsgData:      sendMsgReq.MsgData,
+		ScheduleTime: req.ScheduleTime,
+	})
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	apiresp.GinSuccess(c, resp)
+}
+
+func (m *MessageApi) CancelScheduledMsg(c *gin.Context) {
+	a2r.Call(scheduledmsg.ScheduledMsgClient.CancelScheduledMsg, m.ScheduledClient, c)
+}
+
+func (m *MessageApi) GetScheduledMsgs(c *gin.Context) {
+	a2r.Call(scheduledmsg.ScheduledMsgClient.GetScheduledMsgs, m.ScheduledClient, c)
+}
+
 func (m *MessageApi) SendBusinessNotification(c *gin.Context) {
 	req := struct {
 		Key        string `json:"key"`
@@ -335,6 +391,7 @@ func (m *MessageApi) BatchSendMsg(c *gin.Context) {
 	}
 	for _, recvID := range recvIDs {
 		sendMsgReq.MsgData.RecvID = recvID
+		sendMsgReq.MsgData.ClientMsgID = idutil.GetMsgIDByMD5(req.SendID)
 		rpcResp, err := m.Client.SendMsg(c, sendMsgReq)
 		if err != nil {
 			resp.FailedIDs = append(resp.FailedIDs, recvID)
@@ -350,6 +407,56 @@ func (m *MessageApi) BatchSendMsg(c *gin.Context) {
 	apiresp.GinSuccess(c, resp)
 }
 
+// CreateBroadcast creates a job sending the message to many users in the background, unlike BatchSendMsg it
+// returns at once with the jobID the progress and results are queried with.
+func (m *MessageApi) CreateBroadcast(c *gin.Context) {
+	var req apistruct.CreateBroadcastReq
+	if err := c.BindJSON(&req); err != nil {
+		apiresp.GinError(c, errs.ErrArgs.WithDetail(err.Error()).Wrap())
+		return
+	}
+	if err := authverify.CheckAdmin(c, m.imAdminUserID); err != nil {
+		apiresp.GinError(c, errs.ErrNoPermission.WrapMsg("only app manager can send message"))
+		return
+	}
+	sendMsgReq, err := m.getSendMsgReq(c, req.SendMsg)
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	resp, err := m.BroadcastClient.CreateBroadcast(c, &broadcast.CreateBroadcastReq{
+		MsgData: sendMsgReq.MsgData,
+		Target: &broadcast.BroadcastTarget{
+			Type:            req.TargetType,
+			UserIDs:         req.RecvIDs,
+			CreateTimeBegin: req.CreateTimeBegin,
+			CreateTimeEnd:   req.CreateTimeEnd,
+		},
+		Rate: req.Rate,
+	})
+	if err != nil {
+		apiresp.GinError(c, err)
+		return
+	}
+	apiresp.GinSuccess(c, resp)
+}
+
+func (m *MessageApi) GetBroadcast(c *gin.Context) {
+	a2r.Call(broadcast.BroadcastClient.GetBroadcast, m.BroadcastClient, c)
+}
+
+func (m *MessageApi) GetBroadcasts(c *gin.Context) {
+	a2r.Call(broadcast.BroadcastClient.GetBroadcasts, m.BroadcastClient, c)
+}
+
+func (m *MessageApi) GetBroadcastResults(c *gin.Context) {
+	a2r.Call(broadcast.BroadcastClient.GetBroadcastResults, m.BroadcastClient, c)
+}
+
+func (m *MessageApi) CancelBroadcast(c *gin.Context) {
+	a2r.Call(broadcast.BroadcastClient.CancelBroadcast, m.BroadcastClient, c)
+}
+
 func (m *MessageApi) CheckMsgIsSendSuccess(c *gin.Context) {
 	a2r.Call(msg.MsgClient.GetSendMsgStatus, m.Client, c)
 }
@@ -0,0 +1,70 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/local"
+	"github.com/gin-gonic/gin"
+)
+
+// LocalObjectApi serves the signed object urls of the local object engine, the api and the third service share its directory.
+type LocalObjectApi struct {
+	local *local.Local
+}
+
+// NewLocalObjectApi returns nil when the third service does not store the objects locally.
+func NewLocalObjectApi(third *config.Third, share *config.Share) (*LocalObjectApi, error) {
+	if third.Object.Enable != "local" {
+		return nil, nil
+	}
+	l, err := local.NewLocal(*third.Object.Local.Build(share.Secret))
+	if err != nil {
+		return nil, err
+	}
+	return &LocalObjectApi{local: l}, nil
+}
+
+// Get serves signed downloads, other requests go on to next.
+func (o *LocalObjectApi) Get(next gin.HandlerFunc) gin.HandlerFunc {
+	if o == nil {
+		return next
+	}
+	return func(c *gin.Context) {
+		if !local.IsSigned(c.Request) {
+			next(c)
+			return
+		}
+		o.local.ServeObject(c.Writer, c.Request, c.Param("name"))
+	}
+}
+
+func (o *LocalObjectApi) Put(c *gin.Context) {
+	if o == nil {
+		c.String(http.StatusNotFound, "local object engine disabled")
+		return
+	}
+	o.local.ServeObject(c.Writer, c.Request, c.Param("name"))
+}
+
+func (o *LocalObjectApi) UploadFormData(c *gin.Context) {
+	if o == nil {
+		c.String(http.StatusNotFound, "local object engine disabled")
+		return
+	}
+	o.local.ServeFormData(c.Writer, c.Request)
+}
@@ -7,19 +7,23 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"net/http"
 	"strings"
 
 	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/rpctls"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/local"
 	"github.com/KyleYe/open-im-tools/apiresp"
 	"github.com/KyleYe/open-im-tools/discovery"
+	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
 	"github.com/KyleYe/open-im-tools/mw"
+	"github.com/KyleYe/open-im-tools/tokenverify"
 )
 
 func prommetricsGin() gin.HandlerFunc {
@@ -37,8 +41,12 @@ func prommetricsGin() gin.HandlerFunc {
 	}
 }
 
-func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.Engine {
-	disCov.AddOption(mw.GrpcClient(), grpc.WithTransportCredentials(insecure.NewCredentials()),
+func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) (*gin.Engine, error) {
+	creds, err := rpctls.New(&config.Share.TLS)
+	if err != nil {
+		return nil, err
+	}
+	disCov.AddOption(mw.GrpcClient(), creds.DialOption(),
 		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, "round_robin")))
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
@@ -54,8 +62,16 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 	conversationRpc := rpcclient.NewConversation(disCov, config.Share.RpcRegisterName.Conversation)
 	authRpc := rpcclient.NewAuth(disCov, config.Share.RpcRegisterName.Auth)
 	thirdRpc := rpcclient.NewThird(disCov, config.Share.RpcRegisterName.Third, config.API.Prometheus.GrafanaURL)
+	localObject, err := NewLocalObjectApi(&config.ThirdConfig, &config.Share)
+	if err != nil {
+		return nil, err
+	}
+	keySet, err := authverify.NewKeySet(&config.Share.TokenSigning, config.Share.Secret, false)
+	if err != nil {
+		return nil, err
+	}
 
-	r.Use(prommetricsGin(), gin.Recovery(), mw.CorsHandler(), mw.GinParseOperationID(), GinParseToken(authRpc))
+	r.Use(prommetricsGin(), gin.Recovery(), mw.CorsHandler(), mw.GinParseOperationID(), GinParseToken(authRpc, keySet))
 	u := NewUserApi(*userRpc)
 	m := NewMessageApi(messageRpc, userRpc, config.Share.IMAdminUserID)
 	userRouterGroup := r.Group("/user")
@@ -80,6 +96,10 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		userRouterGroup.POST("/process_user_command_get", u.ProcessUserCommandGet)
 		userRouterGroup.POST("/process_user_command_get_all", u.ProcessUserCommandGetAll)
 
+		userRouterGroup.POST("/update_location", u.UpdateLocation)
+		userRouterGroup.POST("/clear_location", u.ClearLocation)
+		userRouterGroup.POST("/get_nearby_users", u.GetNearbyUsers)
+
 		userRouterGroup.POST("/add_notification_account", u.AddNotificationAccount)
 		userRouterGroup.POST("/update_notification_account", u.UpdateNotificationAccountInfo)
 		userRouterGroup.POST("/search_notification_account", u.SearchNotificationAccount)
@@ -149,8 +169,10 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		a := NewAuthApi(*authRpc)
 		authRouterGroup.POST("/user_token", a.UserToken)
 		authRouterGroup.POST("/get_user_token", a.GetUserToken)
+		authRouterGroup.POST("/refresh_token", a.RefreshToken)
 		authRouterGroup.POST("/parse_token", a.ParseToken)
 		authRouterGroup.POST("/force_logout", a.ForceLogout)
+		authRouterGroup.GET("/jwks", JWKS(keySet))
 	}
 	// Third service
 	thirdGroup := r.Group("/third")
@@ -158,6 +180,9 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		t := NewThirdApi(*thirdRpc)
 		thirdGroup.GET("/prometheus", t.GetPrometheus)
 		thirdGroup.POST("/fcm_update_token", t.FcmUpdateToken)
+		thirdGroup.POST("/apns_update_token", t.ApnsUpdateToken)
+		thirdGroup.POST("/register_web_push", t.RegisterWebPushSubscription)
+		thirdGroup.POST("/unregister_web_push", t.UnregisterWebPushSubscription)
 		thirdGroup.POST("/set_app_badge", t.SetAppBadge)
 
 		logs := thirdGroup.Group("/logs")
@@ -175,7 +200,9 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		objectGroup.POST("/access_url", t.AccessURL)
 		objectGroup.POST("/initiate_form_data", t.InitiateFormData)
 		objectGroup.POST("/complete_form_data", t.CompleteFormData)
-		objectGroup.GET("/*name", t.ObjectRedirect)
+		objectGroup.POST("/"+local.FormDataPath, localObject.UploadFormData)
+		objectGroup.GET("/*name", localObject.Get(t.ObjectRedirect))
+		objectGroup.PUT("/*name", localObject.Put)
 	}
 	// Message
 	msgGroup := r.Group("/msg")
@@ -184,8 +211,14 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		msgGroup.POST("/search_msg", m.SearchMsg)
 		msgGroup.POST("/send_msg", m.SendMessage)
 		msgGroup.POST("/send_business_notification", m.SendBusinessNotification)
+		msgGroup.POST("/schedule_send", m.ScheduleSendMsg)
+		msgGroup.POST("/cancel_scheduled", m.CancelScheduledMsg)
+		msgGroup.POST("/list_scheduled", m.GetScheduledMsgs)
 		msgGroup.POST("/pull_msg_by_seq", m.PullMsgBySeqs)
 		msgGroup.POST("/revoke_msg", m.RevokeMsg)
+		msgGroup.POST("/edit_msg", m.EditMsg)
+		msgGroup.POST("/add_reaction", m.AddReaction)
+		msgGroup.POST("/remove_reaction", m.RemoveReaction)
 		msgGroup.POST("/mark_msgs_as_read", m.MarkMsgsAsRead)
 		msgGroup.POST("/mark_conversation_as_read", m.MarkConversationAsRead)
 		msgGroup.POST("/get_conversations_has_read_and_max_seq", m.GetConversationsHasReadAndMaxSeq)
@@ -198,6 +231,11 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		msgGroup.POST("/delete_msg_physical", m.DeleteMsgPhysical)
 
 		msgGroup.POST("/batch_send_msg", m.BatchSendMsg)
+		msgGroup.POST("/broadcast/create", m.CreateBroadcast)
+		msgGroup.POST("/broadcast/get", m.GetBroadcast)
+		msgGroup.POST("/broadcast/list", m.GetBroadcasts)
+		msgGroup.POST("/broadcast/results", m.GetBroadcastResults)
+		msgGroup.POST("/broadcast/cancel", m.CancelBroadcast)
 		msgGroup.POST("/check_msg_is_send_success", m.CheckMsgIsSendSuccess)
 		msgGroup.POST("/get_server_time", m.GetServerTime)
 	}
@@ -223,10 +261,10 @@ func newGinRouter(disCov discovery.SvcDiscoveryRegistry, config *Config) *gin.En
 		statisticsGroup.POST("/group/create", g.GroupCreateCount)
 		statisticsGroup.POST("/group/active", m.GetActiveGroup)
 	}
-	return r
+	return r, nil
 }
 
-func GinParseToken(authRPC *rpcclient.Auth) gin.HandlerFunc {
+func GinParseToken(authRPC *rpcclient.Auth, keySet *authverify.KeySet) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		switch c.Request.Method {
 		case http.MethodPost:
@@ -244,6 +282,12 @@ func GinParseToken(authRPC *rpcclient.Auth) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+			// Reject forged and expired tokens before asking the auth service about their state.
+			if _, err := tokenverify.GetClaimFromToken(token, keySet.Keyfunc); err != nil {
+				apiresp.GinError(c, errs.Wrap(err))
+				c.Abort()
+				return
+			}
 			resp, err := authRPC.ParseToken(c, token)
 			if err != nil {
 				apiresp.GinError(c, err)
@@ -262,4 +306,6 @@ var Whitelist = []string{
 	"/user/user_register",
 	"/auth/user_token",
 	"/auth/parse_token",
+	"/auth/refresh_token",
+	"/object/" + local.FormDataPath,
 }
@@ -25,6 +25,8 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/KyleYe/open-im-protocol/third"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/apns"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/webpush"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/a2r"
 	"github.com/KyleYe/open-im-tools/errs"
@@ -42,6 +44,18 @@ func (o *ThirdApi) FcmUpdateToken(c *gin.Context) {
 	a2r.Call(third.ThirdClient.FcmUpdateToken, o.Client, c)
 }
 
+func (o *ThirdApi) ApnsUpdateToken(c *gin.Context) {
+	a2r.Call(apns.ApnsClient.ApnsUpdateToken, o.ApnsClient, c)
+}
+
+func (o *ThirdApi) RegisterWebPushSubscription(c *gin.Context) {
+	a2r.Call(webpush.WebPushClient.RegisterWebPushSubscription, o.WebPushClient, c)
+}
+
+func (o *ThirdApi) UnregisterWebPushSubscription(c *gin.Context) {
+	a2r.Call(webpush.WebPushClient.UnregisterWebPushSubscription, o.WebPushClient, c)
+}
+
 func (o *ThirdApi) SetAppBadge(c *gin.Context) {
 	a2r.Call(third.ThirdClient.SetAppBadge, o.Client, c)
 }
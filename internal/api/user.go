@@ -18,6 +18,7 @@ import (
 	"github.com/KyleYe/open-im-protocol/constant"
 	"github.com/KyleYe/open-im-protocol/msggateway"
 	"github.com/KyleYe/open-im-protocol/user"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/location"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/a2r"
 	"github.com/KyleYe/open-im-tools/apiresp"
@@ -50,6 +51,18 @@ func (u *UserApi) GetUsersPublicInfo(c *gin.Context) {
 	a2r.Call(user.UserClient.GetDesignateUsers, u.Client, c)
 }
 
+func (u *UserApi) UpdateLocation(c *gin.Context) {
+	a2r.Call(location.LocationClient.UpdateLocation, u.LocationClient, c)
+}
+
+func (u *UserApi) ClearLocation(c *gin.Context) {
+	a2r.Call(location.LocationClient.ClearLocation, u.LocationClient, c)
+}
+
+func (u *UserApi) GetNearbyUsers(c *gin.Context) {
+	a2r.Call(location.LocationClient.GetNearbyUsers, u.LocationClient, c)
+}
+
 func (u *UserApi) GetAllUsersID(c *gin.Context) {
 	a2r.Call(user.UserClient.GetAllUserID, u.Client, c)
 }
@@ -77,6 +77,8 @@ type Client struct {
 	hbCancel       context.CancelFunc
 	subLock        *sync.Mutex
 	subUserIDs     map[string]struct{} // client conn subscription list
+	encoder        Encoder
+	isJson         bool // frames are JSON text instead of gob binary
 }
 
 // ResetClient updates the client's state with new connection and context information.
@@ -84,11 +86,18 @@ func (c *Client) ResetClient(ctx *UserConnContext, conn LongConn, longConnServer
 	c.w = new(sync.Mutex)
 	c.conn = conn
 	c.PlatformID = stringutil.StringToInt(ctx.GetPlatformID())
-	c.IsCompress = ctx.GetCompression()
+	c.isJson = ctx.IsJsonProtocol()
+	// JSON frames are text, gzip would turn them into binary frames the client does not expect.
+	c.IsCompress = ctx.GetCompression() && !c.isJson
 	c.IsBackground = ctx.GetBackground()
 	c.UserID = ctx.GetUserID()
 	c.ctx = ctx
 	c.longConnServer = longConnServer
+	if c.isJson {
+		c.encoder = jsonEncoder
+	} else {
+		c.encoder = longConnServer
+	}
 	c.IsBackground = false
 	c.closed.Store(false)
 	c.closedErr = nil
@@ -158,8 +167,16 @@ func (c *Client) readMessage() {
 				return
 			}
 		case MessageText:
-			c.closedErr = ErrNotSupportMessageProtocol
-			return
+			if !c.isJson {
+				c.closedErr = ErrNotSupportMessageProtocol
+				return
+			}
+			_ = c.conn.SetReadDeadline(pongWait)
+			parseDataErr := c.handleMessage(message)
+			if parseDataErr != nil {
+				c.closedErr = parseDataErr
+				return
+			}
 
 		case PingMessage:
 			err := c.writePongMsg("")
@@ -187,7 +204,7 @@ func (c *Client) handleMessage(message []byte) error {
 	var binaryReq = getReq()
 	defer freeReq(binaryReq)
 
-	err := c.longConnServer.Decode(message, binaryReq)
+	err := c.encoder.Decode(message, binaryReq)
 	if err != nil {
 		return err
 	}
@@ -284,16 +301,14 @@ func (c *Client) replyMessage(ctx context.Context, binaryReq *Req, err error, re
 }
 
 func (c *Client) PushMessage(ctx context.Context, msgData *sdkws.MsgData) error {
-	var msg sdkws.PushMessages
-	conversationID := msgprocessor.GetConversationIDByMsg(msgData)
-	m := map[string]*sdkws.PullMsgs{conversationID: {Msgs: []*sdkws.MsgData{msgData}}}
-	if msgprocessor.IsNotification(conversationID) {
-		msg.NotificationMsgs = m
-	} else {
-		msg.Msgs = m
-	}
-	log.ZDebug(ctx, "PushMessage", "msg", &msg)
-	data, err := proto.Marshal(&msg)
+	return c.PushMessages(ctx, []*sdkws.MsgData{msgData})
+}
+
+// PushMessages writes the messages in a single frame, grouped by their conversation in the order given.
+func (c *Client) PushMessages(ctx context.Context, msgDatas []*sdkws.MsgData) error {
+	msg := newPushMessages(msgDatas)
+	log.ZDebug(ctx, "PushMessage", "msg", msg)
+	data, err := proto.Marshal(msg)
 	if err != nil {
 		return err
 	}
@@ -305,6 +320,27 @@ func (c *Client) PushMessage(ctx context.Context, msgData *sdkws.MsgData) error
 	return c.writeBinaryMsg(resp)
 }
 
+func newPushMessages(msgDatas []*sdkws.MsgData) *sdkws.PushMessages {
+	var msg sdkws.PushMessages
+	for _, msgData := range msgDatas {
+		conversationID := msgprocessor.GetConversationIDByMsg(msgData)
+		m := &msg.Msgs
+		if msgprocessor.IsNotification(conversationID) {
+			m = &msg.NotificationMsgs
+		}
+		if *m == nil {
+			*m = make(map[string]*sdkws.PullMsgs)
+		}
+		pullMsgs, ok := (*m)[conversationID]
+		if !ok {
+			pullMsgs = &sdkws.PullMsgs{}
+			(*m)[conversationID] = pullMsgs
+		}
+		pullMsgs.Msgs = append(pullMsgs.Msgs, msgData)
+	}
+	return &msg
+}
+
 func (c *Client) KickOnlineMessage() error {
 	resp := Resp{
 		ReqIdentifier: WSKickOnlineMsg,
@@ -328,7 +364,7 @@ func (c *Client) writeBinaryMsg(resp Resp) error {
 		return nil
 	}
 
-	encodedBuf, err := c.longConnServer.Encode(resp)
+	encodedBuf, err := c.encoder.Encode(resp)
 	if err != nil {
 		return err
 	}
@@ -349,6 +385,9 @@ func (c *Client) writeBinaryMsg(resp Resp) error {
 		return c.conn.WriteMessage(MessageBinary, resultBuf)
 	}
 
+	if c.isJson {
+		return c.conn.WriteMessage(MessageText, encodedBuf)
+	}
 	return c.conn.WriteMessage(MessageBinary, encodedBuf)
 }
 
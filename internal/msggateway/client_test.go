@@ -0,0 +1,76 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/msgprocessor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPushMessages(t *testing.T) {
+	notification := msgprocessor.WithOptions(msgprocessor.NewMsgOptions(), msgprocessor.WithNotNotification(false))
+	msgs := []*sdkws.MsgData{
+		{GroupID: "g1", SessionType: constant.ReadGroupChatType, Seq: 1},
+		{GroupID: "g2", SessionType: constant.ReadGroupChatType, Seq: 1},
+		{GroupID: "g1", SessionType: constant.ReadGroupChatType, Seq: 2},
+		{GroupID: "g1", SessionType: constant.ReadGroupChatType, Seq: 1, Options: notification},
+	}
+	push := newPushMessages(msgs)
+	assert.Len(t, push.Msgs, 2)
+	assert.Equal(t, []*sdkws.MsgData{msgs[0], msgs[2]}, push.Msgs["sg_g1"].Msgs)
+	assert.Equal(t, []*sdkws.MsgData{msgs[1]}, push.Msgs["sg_g2"].Msgs)
+	assert.Len(t, push.NotificationMsgs, 1)
+	assert.Equal(t, []*sdkws.MsgData{msgs[3]}, push.NotificationMsgs["n_g1"].Msgs)
+}
+
+// recordConn keeps the frames written to the connection.
+type recordConn struct {
+	LongConn
+	messageTypes []int
+	messages     [][]byte
+}
+
+func (r *recordConn) SetWriteDeadline(time.Duration) error { return nil }
+
+func (r *recordConn) WriteMessage(messageType int, message []byte) error {
+	r.messageTypes = append(r.messageTypes, messageType)
+	r.messages = append(r.messages, message)
+	return nil
+}
+
+func TestJsonClientIsNotCompressed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/?compression=gzip&protocol=json&platformID=5", nil)
+	conn := &recordConn{}
+	var client Client
+	client.ResetClient(newContext(httptest.NewRecorder(), req), conn, nil)
+	assert.True(t, client.isJson)
+	assert.False(t, client.IsCompress)
+
+	assert.NoError(t, client.writeBinaryMsg(Resp{ReqIdentifier: WSKickOnlineMsg, OperationID: "op"}))
+	assert.Equal(t, []int{MessageText}, conn.messageTypes)
+	assert.JSONEq(t, `{"reqIdentifier":2002,"msgIncr":"","operationID":"op","errCode":0,"errMsg":""}`, string(conn.messages[0]))
+
+	req = httptest.NewRequest(http.MethodGet, "/?compression=gzip&platformID=5", nil)
+	client.ResetClient(newContext(httptest.NewRecorder(), req), conn, nil)
+	assert.False(t, client.isJson)
+	assert.True(t, client.IsCompress)
+}
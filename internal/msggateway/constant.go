@@ -27,6 +27,9 @@ const (
 	GzipCompressionProtocol = "gzip"
 	BackgroundStatus        = "isBackground"
 	SendResponse            = "isMsgResp"
+	Protocol                = "protocol"
+	JsonProtocol            = "json"
+	WebSocketProtocolHeader = "Sec-WebSocket-Protocol"
 )
 
 const (
@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
@@ -150,6 +151,24 @@ func (c *UserConnContext) GetCompression() bool {
 	return false
 }
 
+// IsJsonProtocol reports whether the client asked for JSON frames,
+// either by the protocol query parameter or by the WebSocket subprotocol.
+func (c *UserConnContext) IsJsonProtocol() bool {
+	if protocol, exists := c.Query(Protocol); exists {
+		return protocol == JsonProtocol
+	}
+	protocols, exists := c.GetHeader(WebSocketProtocolHeader)
+	if !exists {
+		return false
+	}
+	for _, protocol := range strings.Split(protocols, ",") {
+		if strings.TrimSpace(protocol) == JsonProtocol {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *UserConnContext) ShouldSendResp() bool {
 	errResp, exists := c.Query(SendResponse)
 	if exists {
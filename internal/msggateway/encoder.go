@@ -17,8 +17,14 @@ package msggateway
 import (
 	"bytes"
 	"encoding/gob"
+	"encoding/json"
 
+	"github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/push"
+	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-tools/errs"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 type Encoder interface {
@@ -49,3 +55,137 @@ func (g *GobEncoder) Decode(encodeData []byte, decodeData any) error {
 	}
 	return nil
 }
+
+// jsonPayload is the protobuf message carried in the data of the requests and responses of a ReqIdentifier.
+type jsonPayload struct {
+	req  proto.Message
+	resp proto.Message
+}
+
+var jsonPayloads = map[int32]jsonPayload{
+	WSGetNewestSeq:        {req: &sdkws.GetMaxSeqReq{}, resp: &sdkws.GetMaxSeqResp{}},
+	WSPullMsgBySeqList:    {req: &sdkws.PullMessageBySeqsReq{}, resp: &sdkws.PullMessageBySeqsResp{}},
+	WSSendMsg:             {req: &sdkws.MsgData{}, resp: &msg.SendMsgResp{}},
+	WSSendSignalMsg:       {req: &sdkws.MsgData{}, resp: &msg.SendMsgResp{}},
+	WSPushMsg:             {resp: &sdkws.PushMessages{}},
+	WSKickOnlineMsg:       {},
+	WsLogoutMsg:           {req: &push.DelUserPushTokenReq{}, resp: &push.DelUserPushTokenResp{}},
+	WsSetBackgroundStatus: {req: &sdkws.SetAppBackgroundStatusReq{}},
+	WsSubUserOnlineStatus: {req: &sdkws.SubUserOnlineStatus{}, resp: &sdkws.SubUserOnlineStatusTips{}},
+	WSDataError:           {},
+}
+
+type jsonReq struct {
+	ReqIdentifier int32           `json:"reqIdentifier"`
+	Token         string          `json:"token"`
+	SendID        string          `json:"sendID"`
+	OperationID   string          `json:"operationID"`
+	MsgIncr       string          `json:"msgIncr"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+type jsonResp struct {
+	ReqIdentifier int32           `json:"reqIdentifier"`
+	MsgIncr       string          `json:"msgIncr"`
+	OperationID   string          `json:"operationID"`
+	ErrCode       int             `json:"errCode"`
+	ErrMsg        string          `json:"errMsg"`
+	Data          json.RawMessage `json:"data,omitempty"`
+}
+
+// JsonEncoder encodes Req and Resp as JSON text frames, the protobuf data of each ReqIdentifier
+// is carried as a JSON object instead of bytes, so that clients do not need gob or protobuf.
+type JsonEncoder struct {
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+// jsonEncoder is shared by the clients using the JSON protocol, JsonEncoder holds no state.
+var jsonEncoder = NewJsonEncoder()
+
+func NewJsonEncoder() *JsonEncoder {
+	return &JsonEncoder{
+		marshal:   protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true},
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+func (j *JsonEncoder) Encode(data any) ([]byte, error) {
+	var resp *Resp
+	switch v := data.(type) {
+	case Resp:
+		resp = &v
+	case *Resp:
+		resp = v
+	default:
+		buf, err := json.Marshal(data)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "JsonEncoder.Encode failed", "action", "encode")
+		}
+		return buf, nil
+	}
+	jResp := jsonResp{
+		ReqIdentifier: resp.ReqIdentifier,
+		MsgIncr:       resp.MsgIncr,
+		OperationID:   resp.OperationID,
+		ErrCode:       resp.ErrCode,
+		ErrMsg:        resp.ErrMsg,
+	}
+	if len(resp.Data) > 0 {
+		payload := jsonPayloads[resp.ReqIdentifier].resp
+		if payload == nil {
+			return nil, errs.New("JsonEncoder.Encode unknown response data", "reqIdentifier", resp.ReqIdentifier).Wrap()
+		}
+		payload = payload.ProtoReflect().New().Interface()
+		if err := proto.Unmarshal(resp.Data, payload); err != nil {
+			return nil, errs.WrapMsg(err, "JsonEncoder.Encode failed", "action", "unmarshal", "reqIdentifier", resp.ReqIdentifier)
+		}
+		buf, err := j.marshal.Marshal(payload)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "JsonEncoder.Encode failed", "action", "marshal", "reqIdentifier", resp.ReqIdentifier)
+		}
+		jResp.Data = buf
+	}
+	buf, err := json.Marshal(&jResp)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "JsonEncoder.Encode failed", "action", "encode")
+	}
+	return buf, nil
+}
+
+func (j *JsonEncoder) Decode(encodeData []byte, decodeData any) error {
+	req, ok := decodeData.(*Req)
+	if !ok {
+		if err := json.Unmarshal(encodeData, decodeData); err != nil {
+			return errs.WrapMsg(err, "JsonEncoder.Decode failed", "action", "decode")
+		}
+		return nil
+	}
+	var jReq jsonReq
+	if err := json.Unmarshal(encodeData, &jReq); err != nil {
+		return errs.WrapMsg(err, "JsonEncoder.Decode failed", "action", "decode")
+	}
+	req.ReqIdentifier = jReq.ReqIdentifier
+	req.Token = jReq.Token
+	req.SendID = jReq.SendID
+	req.OperationID = jReq.OperationID
+	req.MsgIncr = jReq.MsgIncr
+	req.Data = nil
+	if len(jReq.Data) == 0 || string(jReq.Data) == "null" {
+		return nil
+	}
+	payload := jsonPayloads[jReq.ReqIdentifier].req
+	if payload == nil {
+		return errs.New("JsonEncoder.Decode unknown request data", "reqIdentifier", jReq.ReqIdentifier).Wrap()
+	}
+	payload = payload.ProtoReflect().New().Interface()
+	if err := j.unmarshal.Unmarshal(jReq.Data, payload); err != nil {
+		return errs.WrapMsg(err, "JsonEncoder.Decode failed", "action", "unmarshal", "reqIdentifier", jReq.ReqIdentifier)
+	}
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return errs.WrapMsg(err, "JsonEncoder.Decode failed", "action", "marshal", "reqIdentifier", jReq.ReqIdentifier)
+	}
+	req.Data = data
+	return nil
+}
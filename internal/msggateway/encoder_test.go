@@ -0,0 +1,61 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestJsonEncoderDecodeReq(t *testing.T) {
+	frame := `{"reqIdentifier":1003,"sendID":"u1","operationID":"op","msgIncr":"1",
+		"data":{"sendID":"u1","recvID":"u2","clientMsgID":"c1","sessionType":1,"contentType":101,"content":"eyJjb250ZW50IjoiaGkifQ=="}}`
+	var req Req
+	assert.NoError(t, NewJsonEncoder().Decode([]byte(frame), &req))
+	assert.Equal(t, int32(WSSendMsg), req.ReqIdentifier)
+	assert.Equal(t, "u1", req.SendID)
+
+	var msgData sdkws.MsgData
+	assert.NoError(t, proto.Unmarshal(req.Data, &msgData))
+	assert.Equal(t, "c1", msgData.ClientMsgID)
+	assert.Equal(t, `{"content":"hi"}`, string(msgData.Content))
+
+	assert.Error(t, NewJsonEncoder().Decode([]byte(`{"reqIdentifier":9999,"data":{}}`), &req))
+}
+
+func TestJsonEncoderEncodeResp(t *testing.T) {
+	data, err := proto.Marshal(&msg.SendMsgResp{ServerMsgID: "s1", ClientMsgID: "c1", SendTime: 100})
+	assert.NoError(t, err)
+	buf, err := NewJsonEncoder().Encode(Resp{ReqIdentifier: WSSendMsg, MsgIncr: "1", Data: data})
+	assert.NoError(t, err)
+
+	var resp struct {
+		ReqIdentifier int32          `json:"reqIdentifier"`
+		Data          map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(buf, &resp))
+	assert.Equal(t, int32(WSSendMsg), resp.ReqIdentifier)
+	assert.Equal(t, "s1", resp.Data["serverMsgID"])
+	assert.Equal(t, "100", resp.Data["sendTime"])
+
+	buf, err = NewJsonEncoder().Encode(Resp{ReqIdentifier: WSKickOnlineMsg})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(buf), `"data"`)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/startrpc"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/batchpush"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/errs"
@@ -37,6 +38,7 @@ import (
 func (s *Server) InitServer(ctx context.Context, config *Config, disCov discovery.SvcDiscoveryRegistry, server *grpc.Server) error {
 	s.LongConnServer.SetDiscoveryRegistry(disCov, config)
 	msggateway.RegisterMsgGatewayServer(server, s)
+	batchpush.RegisterBatchPushServer(server, s)
 	s.userRcp = rpcclient.NewUserRpcClient(disCov, config.Share.RpcRegisterName.User, config.Share.IMAdminUserID)
 	if s.ready != nil {
 		return s.ready(s)
@@ -128,11 +130,19 @@ func (s *Server) GetUsersOnlineStatus(
 }
 
 func (s *Server) OnlineBatchPushOneMsg(ctx context.Context, req *msggateway.OnlineBatchPushOneMsgReq) (*msggateway.OnlineBatchPushOneMsgResp, error) {
-	// todo implement
-	return nil, nil
+	return &msggateway.OnlineBatchPushOneMsgResp{
+		SinglePushResult: s.pushToUsers(ctx, req.PushToUserIDs, func(userID string) *msggateway.SingleMsgToUserResults {
+			return s.pushToUser(ctx, userID, req.MsgData)
+		}),
+	}, nil
 }
 
 func (s *Server) pushToUser(ctx context.Context, userID string, msgData *sdkws.MsgData) *msggateway.SingleMsgToUserResults {
+	return s.pushMsgsToUser(ctx, userID, []*sdkws.MsgData{msgData})
+}
+
+// pushMsgsToUser pushes the messages to every connection of the user, each connection gets them in one frame.
+func (s *Server) pushMsgsToUser(ctx context.Context, userID string, msgDatas []*sdkws.MsgData) *msggateway.SingleMsgToUserResults {
 	clients, ok := s.LongConnServer.GetUserAllCons(userID)
 	if !ok {
 		log.ZDebug(ctx, "push user not online", "userID", userID)
@@ -154,7 +164,7 @@ func (s *Server) pushToUser(ctx context.Context, userID string, msgData *sdkws.M
 		}
 		if !client.IsBackground ||
 			(client.IsBackground && client.PlatformID != constant.IOSPlatformID) {
-			err := client.PushMessage(ctx, msgData)
+			err := client.PushMessages(ctx, msgDatas)
 			if err != nil {
 				userPlatform.ResultCode = int64(servererrs.ErrPushMsgErr.Code())
 			} else {
@@ -171,52 +181,95 @@ func (s *Server) pushToUser(ctx context.Context, userID string, msgData *sdkws.M
 }
 
 func (s *Server) SuperGroupOnlineBatchPushOneMsg(ctx context.Context, req *msggateway.OnlineBatchPushOneMsgReq) (*msggateway.OnlineBatchPushOneMsgResp, error) {
-	if len(req.PushToUserIDs) == 0 {
-		return &msggateway.OnlineBatchPushOneMsgResp{}, nil
+	return &msggateway.OnlineBatchPushOneMsgResp{
+		SinglePushResult: s.pushToUsers(ctx, req.PushToUserIDs, func(userID string) *msggateway.SingleMsgToUserResults {
+			return s.pushToUser(ctx, userID, req.MsgData)
+		}),
+	}, nil
+}
+
+func (s *Server) BatchPushMsgs(ctx context.Context, req *batchpush.BatchPushMsgsReq) (*batchpush.BatchPushMsgsResp, error) {
+	// The messages of a user in the order of the request, so that each connection gets them in one frame.
+	var (
+		userIDs  []string
+		userMsgs = make(map[string][]int)
+	)
+	for i, msg := range req.Msgs {
+		for _, userID := range msg.PushToUserIDs {
+			if _, ok := userMsgs[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			userMsgs[userID] = append(userMsgs[userID], i)
+		}
+	}
+	userResults := s.pushToUsers(ctx, userIDs, func(userID string) *msggateway.SingleMsgToUserResults {
+		indexes := userMsgs[userID]
+		msgDatas := make([]*sdkws.MsgData, 0, len(indexes))
+		for _, i := range indexes {
+			msgDatas = append(msgDatas, req.Msgs[i].MsgData)
+		}
+		return s.pushMsgsToUser(ctx, userID, msgDatas)
+	})
+	resp := &batchpush.BatchPushMsgsResp{Results: make([]*batchpush.PushMsgResult, len(req.Msgs))}
+	for i, msg := range req.Msgs {
+		resp.Results[i] = &batchpush.PushMsgResult{
+			SinglePushResult: make([]*msggateway.SingleMsgToUserResults, 0, len(msg.PushToUserIDs)),
+		}
+	}
+	for _, result := range userResults {
+		for _, i := range userMsgs[result.UserID] {
+			resp.Results[i].SinglePushResult = append(resp.Results[i].SinglePushResult, result)
+		}
+	}
+	return resp, nil
+}
+
+// pushToUsers runs push for every user on the queue, users not pushed to before ctx is done get an empty result.
+func (s *Server) pushToUsers(ctx context.Context, userIDs []string, push func(userID string) *msggateway.SingleMsgToUserResults) []*msggateway.SingleMsgToUserResults {
+	if len(userIDs) == 0 {
+		return nil
 	}
-	ch := make(chan *msggateway.SingleMsgToUserResults, len(req.PushToUserIDs))
+	ch := make(chan *msggateway.SingleMsgToUserResults, len(userIDs))
 	var count atomic.Int64
-	count.Add(int64(len(req.PushToUserIDs)))
-	for i := range req.PushToUserIDs {
-		userID := req.PushToUserIDs[i]
+	count.Add(int64(len(userIDs)))
+	for i := range userIDs {
+		userID := userIDs[i]
 		err := s.queue.PushCtx(ctx, func() {
-			ch <- s.pushToUser(ctx, userID, req.MsgData)
+			ch <- push(userID)
 			if count.Add(-1) == 0 {
 				close(ch)
 			}
 		})
 		if err != nil {
-			if count.Add(-1) == 0 {
-				close(ch)
-			}
 			log.ZError(ctx, "pushToUser MemoryQueue failed", err, "userID", userID)
 			ch <- &msggateway.SingleMsgToUserResults{
 				UserID: userID,
 			}
+			if count.Add(-1) == 0 {
+				close(ch)
+			}
 		}
 	}
-	resp := &msggateway.OnlineBatchPushOneMsgResp{
-		SinglePushResult: make([]*msggateway.SingleMsgToUserResults, 0, len(req.PushToUserIDs)),
-	}
+	results := make([]*msggateway.SingleMsgToUserResults, 0, len(userIDs))
 	for {
 		select {
 		case <-ctx.Done():
-			log.ZError(ctx, "SuperGroupOnlineBatchPushOneMsg ctx done", context.Cause(ctx))
-			userIDSet := datautil.SliceSet(req.PushToUserIDs)
-			for _, results := range resp.SinglePushResult {
-				delete(userIDSet, results.UserID)
+			log.ZError(ctx, "pushToUsers ctx done", context.Cause(ctx))
+			userIDSet := datautil.SliceSet(userIDs)
+			for _, result := range results {
+				delete(userIDSet, result.UserID)
 			}
 			for userID := range userIDSet {
-				resp.SinglePushResult = append(resp.SinglePushResult, &msggateway.SingleMsgToUserResults{
+				results = append(results, &msggateway.SingleMsgToUserResults{
 					UserID: userID,
 				})
 			}
-			return resp, nil
+			return results
 		case res, ok := <-ch:
 			if !ok {
-				return resp, nil
+				return results
 			}
-			resp.SinglePushResult = append(resp.SinglePushResult, res)
+			results = append(results, res)
 		}
 	}
 }
@@ -0,0 +1,106 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msggateway
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/msggateway"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/batchpush"
+	"github.com/stretchr/testify/assert"
+)
+
+// userConns is a LongConnServer knowing only the connections of the users.
+type userConns struct {
+	LongConnServer
+	clients map[string][]*Client
+}
+
+func (u *userConns) GetUserAllCons(userID string) ([]*Client, bool) {
+	clients, ok := u.clients[userID]
+	return clients, ok
+}
+
+// failConn fails every write.
+type failConn struct {
+	recordConn
+}
+
+func (f *failConn) WriteMessage(int, []byte) error { return errors.New("broken pipe") }
+
+func newJsonClient(userID string, platformID string, conn LongConn) *Client {
+	req := httptest.NewRequest("GET", "/?protocol=json&sendID="+userID+"&platformID="+platformID, nil)
+	var client Client
+	client.ResetClient(newContext(httptest.NewRecorder(), req), conn, nil)
+	return &client
+}
+
+func resultUserIDs(results []*msggateway.SingleMsgToUserResults) map[string]*msggateway.SingleMsgToUserResults {
+	users := make(map[string]*msggateway.SingleMsgToUserResults, len(results))
+	for _, result := range results {
+		users[result.UserID] = result
+	}
+	return users
+}
+
+func TestBatchPushMsgs(t *testing.T) {
+	u1Conn := &recordConn{}
+	conns := &userConns{clients: map[string][]*Client{
+		"u1": {newJsonClient("u1", "2", u1Conn)},
+		"u2": {newJsonClient("u2", "5", &failConn{})},
+	}}
+	s := NewServer(0, conns, &Config{}, nil)
+	msgs := []*sdkws.MsgData{
+		{SendID: "s", RecvID: "u1", ClientMsgID: "m0", SessionType: constant.SingleChatType, Seq: 1},
+		{SendID: "s", RecvID: "u1", ClientMsgID: "m1", SessionType: constant.SingleChatType, Seq: 2},
+		{SendID: "s", RecvID: "u1", ClientMsgID: "m2", SessionType: constant.SingleChatType, Seq: 3},
+	}
+	resp, err := s.BatchPushMsgs(context.Background(), &batchpush.BatchPushMsgsReq{Msgs: []*batchpush.PushMsg{
+		{MsgData: msgs[0], PushToUserIDs: []string{"u1", "u2"}},
+		{MsgData: msgs[1], PushToUserIDs: []string{"u1", "u3"}},
+		{MsgData: msgs[2]},
+	}})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Results, 3)
+
+	// every message gets the results of its own users only
+	first := resultUserIDs(resp.Results[0].SinglePushResult)
+	assert.Len(t, first, 2)
+	assert.True(t, first["u1"].OnlinePush)
+	assert.Equal(t, int32(constant.AndroidPlatformID), first["u1"].Resp[0].RecvPlatFormID)
+	assert.False(t, first["u2"].OnlinePush)
+	assert.Equal(t, int64(servererrs.ErrPushMsgErr.Code()), first["u2"].Resp[0].ResultCode)
+
+	second := resultUserIDs(resp.Results[1].SinglePushResult)
+	assert.Len(t, second, 2)
+	assert.True(t, second["u1"].OnlinePush)
+	assert.False(t, second["u3"].OnlinePush)
+	assert.Empty(t, second["u3"].Resp)
+
+	assert.Empty(t, resp.Results[2].SinglePushResult)
+
+	// both messages of u1 are sent in one frame
+	assert.Len(t, u1Conn.messages, 1)
+	assert.True(t, strings.Contains(string(u1Conn.messages[0]), "m0"))
+	assert.True(t, strings.Contains(string(u1Conn.messages[0]), "m1"))
+	assert.False(t, strings.Contains(string(u1Conn.messages[0]), "m2"))
+}
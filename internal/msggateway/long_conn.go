@@ -69,6 +69,7 @@ func (d *GWebSocket) GenerateLongConn(w http.ResponseWriter, r *http.Request) er
 	upgrader := &websocket.Upgrader{
 		HandshakeTimeout: d.handshakeTimeout,
 		CheckOrigin:      func(r *http.Request) bool { return true },
+		Subprotocols:     []string{JsonProtocol},
 	}
 	if d.writeBufferSize > 0 { // default is 4kb.
 		upgrader.WriteBufferSize = d.writeBufferSize
@@ -0,0 +1,184 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgtransfer
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mq/kafka"
+	"google.golang.org/protobuf/proto"
+)
+
+// Headers attached to the records of the dead-letter topic.
+const (
+	DeadLetterErrorHeader          = "deadLetterError"
+	DeadLetterConversationIDHeader = "deadLetterConversationID"
+	DeadLetterAttemptHeader        = "deadLetterAttempt"
+	DeadLetterStageHeader          = "deadLetterStage"
+)
+
+// Stages a batch can fail at, a record is replayed into the topic its stage consumes from.
+const (
+	// DeadLetterStageRedis records hold a single sdkws.MsgData that never reached the cache.
+	DeadLetterStageRedis = "redis"
+	// DeadLetterStageMongo records hold a msg.MsgDataToMongoByMQ that is cached but was not handed over to mongo.
+	DeadLetterStageMongo = "mongo"
+)
+
+func isDeadLetterHeader(key string) bool {
+	switch key {
+	case DeadLetterErrorHeader, DeadLetterConversationIDHeader, DeadLetterAttemptHeader, DeadLetterStageHeader:
+		return true
+	default:
+		return false
+	}
+}
+
+// ContextHeaders drops the dead-letter headers, leaving the ones kafka.GetContextWithMQHeader expects.
+func ContextHeaders(headers []*sarama.RecordHeader) []*sarama.RecordHeader {
+	res := make([]*sarama.RecordHeader, 0, len(headers))
+	for _, header := range headers {
+		if !isDeadLetterHeader(string(header.Key)) {
+			res = append(res, header)
+		}
+	}
+	return res
+}
+
+// DeadLetterHeader returns the value of a dead-letter header, empty when absent.
+func DeadLetterHeader(headers []*sarama.RecordHeader, key string) string {
+	for _, header := range headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// DeadLetterAttempt returns how many times the record has already been dead-lettered.
+func DeadLetterAttempt(headers []*sarama.RecordHeader) int {
+	attempt, _ := strconv.Atoi(DeadLetterHeader(headers, DeadLetterAttemptHeader))
+	return attempt
+}
+
+// Waits between the attempts to write a dead letter.
+const (
+	deadLetterMinBackoff = 100 * time.Millisecond
+	deadLetterMaxBackoff = 10 * time.Second
+)
+
+type deadLetterProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+// newDeadLetterProducer returns nil when no dead-letter topic is configured.
+func newDeadLetterProducer(kafkaConf *config.Kafka) (*deadLetterProducer, error) {
+	if kafkaConf.ToDeadLetterTopic == "" {
+		return nil, nil
+	}
+	conf, err := kafka.BuildProducerConfig(*kafkaConf.Build())
+	if err != nil {
+		return nil, err
+	}
+	producer, err := kafka.NewProducer(conf, kafkaConf.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterProducer{topic: kafkaConf.ToDeadLetterTopic, producer: producer}, nil
+}
+
+func (d *deadLetterProducer) send(ctx context.Context, key, conversationID, stage string, attempt int, cause error, msg proto.Message) error {
+	value, err := proto.Marshal(msg)
+	if err != nil {
+		return errs.WrapMsg(err, "kafka proto Marshal err")
+	}
+	headers, err := kafka.GetMQHeaderWithContext(ctx)
+	if err != nil {
+		log.ZWarn(ctx, "dead letter without context headers", err, "conversationID", conversationID)
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(DeadLetterErrorHeader), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(DeadLetterConversationIDHeader), Value: []byte(conversationID)},
+		sarama.RecordHeader{Key: []byte(DeadLetterAttemptHeader), Value: []byte(strconv.Itoa(attempt))},
+		sarama.RecordHeader{Key: []byte(DeadLetterStageHeader), Value: []byte(stage)},
+	)
+	_, _, err = d.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   d.topic,
+		Key:     sarama.StringEncoder(key),
+		Value:   sarama.ByteEncoder(value),
+		Headers: headers,
+	})
+	if err != nil {
+		return errs.WrapMsg(err, "send dead letter error", "topic", d.topic)
+	}
+	prommetrics.MsgDeadLetterCounter.Inc()
+	return nil
+}
+
+// sendDeadLetter writes a dead letter until it succeeds, as the offsets of the batch are committed afterwards.
+// It only gives up when msg-transfer stops, the handler then stops committing so the batch is consumed again.
+func (och *OnlineHistoryRedisConsumerHandler) sendDeadLetter(ctx context.Context, key, conversationID, stage string,
+	attempt int, cause error, msg proto.Message) error {
+	backoff := deadLetterMinBackoff
+	for {
+		err := och.deadLetter.send(ctx, key, conversationID, stage, attempt, cause, msg)
+		if err == nil {
+			return nil
+		}
+		log.ZError(ctx, "write dead letter failed, retrying", err, "conversationID", conversationID, "stage", stage, "backoff", backoff)
+		select {
+		case <-och.ctx.Done():
+			och.failed.Store(true)
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, deadLetterMaxBackoff)
+	}
+}
+
+// toDeadLetter keeps the messages that could not be inserted into the cache, one record per message.
+func (och *OnlineHistoryRedisConsumerHandler) toDeadLetter(key, conversationID string, msgs []*ContextMsg, cause error) error {
+	if och.deadLetter == nil {
+		return nil
+	}
+	for _, msg := range msgs {
+		if err := och.sendDeadLetter(msg.ctx, key, conversationID, DeadLetterStageRedis, msg.attempt+1, cause, msg.message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toMongoDeadLetter keeps a cached batch that could not be handed over to the mongo topic.
+func (och *OnlineHistoryRedisConsumerHandler) toMongoDeadLetter(ctx context.Context, key, conversationID string,
+	msgs []*ContextMsg, data *pbmsg.MsgDataToMongoByMQ, cause error) error {
+	if och.deadLetter == nil {
+		return nil
+	}
+	var attempt int
+	for _, msg := range msgs {
+		attempt = max(attempt, msg.attempt)
+	}
+	return och.sendDeadLetter(ctx, key, conversationID, DeadLetterStageMongo, attempt+1, cause, data)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgtransfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeMsgDatabase fails the cache or the mongo hand over, the methods the handler does not reach panic.
+type fakeMsgDatabase struct {
+	controller.CommonMsgDatabase
+	cacheErr error
+	mongoErr error
+	pushed   int
+}
+
+func (f *fakeMsgDatabase) BatchInsertChat2Cache(_ context.Context, _ string, msgs []*sdkws.MsgData) (int64, bool, error) {
+	if f.cacheErr != nil {
+		return 0, false, f.cacheErr
+	}
+	return int64(len(msgs)), false, nil
+}
+
+func (f *fakeMsgDatabase) MsgToMongoMQ(context.Context, string, string, []*sdkws.MsgData, int64) error {
+	return f.mongoErr
+}
+
+func (f *fakeMsgDatabase) MsgToPushMQ(context.Context, string, string, *sdkws.MsgData) (int32, int64, error) {
+	f.pushed++
+	return 0, 0, nil
+}
+
+// recordDeadLetters returns a handler whose dead letters are appended to records.
+func recordDeadLetters(t *testing.T, db controller.CommonMsgDatabase, n int, records *[]*sarama.ProducerMessage) *OnlineHistoryRedisConsumerHandler {
+	producer := mocks.NewSyncProducer(t, nil)
+	t.Cleanup(func() { producer.Close() })
+	for i := 0; i < n; i++ {
+		producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			*records = append(*records, msg)
+			return nil
+		})
+	}
+	return &OnlineHistoryRedisConsumerHandler{
+		msgDatabase: db,
+		deadLetter:  &deadLetterProducer{topic: "deadLetter", producer: producer},
+		ctx:         context.Background(),
+	}
+}
+
+func contextMsgs(attempts ...int) []*ContextMsg {
+	msgs := make([]*ContextMsg, 0, len(attempts))
+	for i, attempt := range attempts {
+		msgs = append(msgs, &ContextMsg{
+			message: &sdkws.MsgData{ClientMsgID: string(rune('a' + i)), SendID: "u1"},
+			ctx:     context.Background(),
+			attempt: attempt,
+		})
+	}
+	return msgs
+}
+
+func headerMap(headers []sarama.RecordHeader) map[string]string {
+	res := make(map[string]string, len(headers))
+	for _, header := range headers {
+		res[string(header.Key)] = string(header.Value)
+	}
+	return res
+}
+
+func TestDeadLetterOnCacheFailure(t *testing.T) {
+	var records []*sarama.ProducerMessage
+	db := &fakeMsgDatabase{cacheErr: errors.New("redis down")}
+	och := recordDeadLetters(t, db, 2, &records)
+	och.handleNotification(context.Background(), "key", "n_u1", contextMsgs(0, 2), nil)
+
+	assert.Len(t, records, 2)
+	assert.Zero(t, db.pushed)
+	for i, record := range records {
+		assert.Equal(t, "deadLetter", record.Topic)
+		headers := headerMap(record.Headers)
+		assert.Equal(t, DeadLetterStageRedis, headers[DeadLetterStageHeader])
+		assert.Equal(t, "n_u1", headers[DeadLetterConversationIDHeader])
+		assert.Equal(t, "redis down", headers[DeadLetterErrorHeader])
+		value, err := record.Value.Encode()
+		assert.NoError(t, err)
+		var msg sdkws.MsgData
+		assert.NoError(t, proto.Unmarshal(value, &msg))
+		assert.Equal(t, string(rune('a'+i)), msg.ClientMsgID)
+	}
+	assert.Equal(t, "1", headerMap(records[0].Headers)[DeadLetterAttemptHeader])
+	assert.Equal(t, "3", headerMap(records[1].Headers)[DeadLetterAttemptHeader])
+}
+
+func TestDeadLetterOnMongoFailure(t *testing.T) {
+	var records []*sarama.ProducerMessage
+	db := &fakeMsgDatabase{mongoErr: errors.New("kafka down")}
+	och := recordDeadLetters(t, db, 1, &records)
+	och.handleNotification(context.Background(), "key", "n_u1", contextMsgs(1, 0), nil)
+
+	if assert.Len(t, records, 1) {
+		headers := headerMap(records[0].Headers)
+		assert.Equal(t, DeadLetterStageMongo, headers[DeadLetterStageHeader])
+		assert.Equal(t, "2", headers[DeadLetterAttemptHeader])
+		value, err := records[0].Value.Encode()
+		assert.NoError(t, err)
+		var data pbmsg.MsgDataToMongoByMQ
+		assert.NoError(t, proto.Unmarshal(value, &data))
+		assert.Equal(t, int64(2), data.LastSeq)
+		assert.Len(t, data.MsgData, 2)
+	}
+	// The messages are cached, they are still pushed.
+	assert.Equal(t, 2, db.pushed)
+}
+
+func TestNoDeadLetterOnSuccess(t *testing.T) {
+	var records []*sarama.ProducerMessage
+	och := recordDeadLetters(t, &fakeMsgDatabase{}, 0, &records)
+	och.handleNotification(context.Background(), "key", "n_u1", contextMsgs(0), nil)
+	assert.Empty(t, records)
+}
+
+func TestDeadLetterRetried(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	defer producer.Close()
+	producer.ExpectSendMessageAndFail(errors.New("kafka down"))
+	producer.ExpectSendMessageAndSucceed()
+	db := &fakeMsgDatabase{cacheErr: errors.New("redis down")}
+	och := &OnlineHistoryRedisConsumerHandler{
+		msgDatabase: db,
+		deadLetter:  &deadLetterProducer{topic: "deadLetter", producer: producer},
+		ctx:         context.Background(),
+	}
+	och.handleNotification(context.Background(), "key", "n_u1", contextMsgs(0), nil)
+	assert.False(t, och.failed.Load())
+}
+
+func TestDeadLetterNotWritten(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	defer producer.Close()
+	producer.ExpectSendMessageAndFail(errors.New("kafka down"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	och := &OnlineHistoryRedisConsumerHandler{
+		msgDatabase: &fakeMsgDatabase{cacheErr: errors.New("redis down")},
+		deadLetter:  &deadLetterProducer{topic: "deadLetter", producer: producer},
+		ctx:         ctx,
+	}
+	assert.Error(t, och.toDeadLetter("key", "n_u1", contextMsgs(0, 0), errors.New("redis down")))
+	// The batch stays uncommitted.
+	assert.True(t, och.failed.Load())
+}
+
+func TestContextHeaders(t *testing.T) {
+	headers := []*sarama.RecordHeader{
+		{Key: []byte("operationID"), Value: []byte("op")},
+		{Key: []byte(DeadLetterErrorHeader), Value: []byte("err")},
+		{Key: []byte(DeadLetterAttemptHeader), Value: []byte("2")},
+	}
+	assert.Len(t, ContextHeaders(headers), 1)
+	assert.Equal(t, 2, DeadLetterAttempt(headers))
+	assert.Equal(t, "", DeadLetterHeader(headers, DeadLetterStageHeader))
+}
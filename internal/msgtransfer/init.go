@@ -23,6 +23,7 @@ import (
 	"syscall"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/rpctls"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/redis"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database/mgo"
 	"github.com/KyleYe/open-im-tools/db/mongoutil"
@@ -38,7 +39,6 @@ import (
 	"github.com/KyleYe/open-im-tools/mw"
 	"github.com/KyleYe/open-im-tools/system/program"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type MsgTransfer struct {
@@ -77,7 +77,11 @@ func Start(ctx context.Context, index int, config *Config) error {
 	if err != nil {
 		return err
 	}
-	client.AddOption(mw.GrpcClient(), grpc.WithTransportCredentials(insecure.NewCredentials()),
+	creds, err := rpctls.New(&config.Share.TLS)
+	if err != nil {
+		return err
+	}
+	client.AddOption(mw.GrpcClient(), creds.DialOption(),
 		grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, "round_robin")))
 	msgModel := redis.NewMsgCache(rdb)
 	msgDocModel, err := mgo.NewMsgMongo(mgocli.GetDB())
@@ -117,6 +121,7 @@ func Start(ctx context.Context, index int, config *Config) error {
 
 func (m *MsgTransfer) Start(index int, config *Config) error {
 	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.historyCH.ctx = m.ctx
 	var (
 		netDone = make(chan struct{}, 1)
 		netErr  error
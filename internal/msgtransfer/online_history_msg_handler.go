@@ -18,10 +18,12 @@ import (
 	"context"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
@@ -48,6 +50,8 @@ const (
 type ContextMsg struct {
 	message *sdkws.MsgData
 	ctx     context.Context
+	// attempt is the number of times the message has been dead-lettered before
+	attempt int
 }
 
 type OnlineHistoryRedisConsumerHandler struct {
@@ -58,6 +62,12 @@ type OnlineHistoryRedisConsumerHandler struct {
 	msgDatabase           controller.CommonMsgDatabase
 	conversationRpcClient *rpcclient.ConversationRpcClient
 	groupRpcClient        *rpcclient.GroupRpcClient
+
+	deadLetter *deadLetterProducer
+	// ctx is done when msg-transfer stops, a dead letter that is not written by then fails its batch
+	ctx context.Context
+	// failed is set when a batch could not be kept, no offset is committed afterwards
+	failed atomic.Bool
 }
 
 func NewOnlineHistoryRedisConsumerHandler(kafkaConf *config.Kafka, database controller.CommonMsgDatabase,
@@ -66,8 +76,14 @@ func NewOnlineHistoryRedisConsumerHandler(kafkaConf *config.Kafka, database cont
 	if err != nil {
 		return nil, err
 	}
+	deadLetter, err := newDeadLetterProducer(kafkaConf)
+	if err != nil {
+		return nil, err
+	}
 	var och OnlineHistoryRedisConsumerHandler
 	och.msgDatabase = database
+	och.deadLetter = deadLetter
+	och.ctx = context.Background()
 
 	b := batcher.New[sarama.ConsumerMessage](
 		batcher.WithSize(size),
@@ -126,8 +142,9 @@ func (och *OnlineHistoryRedisConsumerHandler) parseConsumerMessages(ctx context.
 		}
 		log.ZDebug(ctx, "consumer.kafka.GetContextWithMQHeader", "len", len(consumerMessages[i].Headers),
 			"header", strings.Join(arr, ", "))
-		ctxMsg.ctx = kafka.GetContextWithMQHeader(consumerMessages[i].Headers)
+		ctxMsg.ctx = kafka.GetContextWithMQHeader(ContextHeaders(consumerMessages[i].Headers))
 		ctxMsg.message = msgFromMQ
+		ctxMsg.attempt = DeadLetterAttempt(consumerMessages[i].Headers)
 		log.ZDebug(ctx, "message parse finish", "message", msgFromMQ, "key",
 			string(consumerMessages[i].Key))
 		ctxMessages = append(ctxMessages, ctxMsg)
@@ -160,6 +177,7 @@ func (och *OnlineHistoryRedisConsumerHandler) categorizeMessageLists(totalMsgs [
 				ctxMsg := &ContextMsg{
 					message: msg,
 					ctx:     v.ctx,
+					attempt: v.attempt,
 				}
 				storageMsgList = append(storageMsgList, ctxMsg)
 			}
@@ -190,6 +208,9 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(ctx context.Context, key
 		lastSeq, isNewConversation, err := och.msgDatabase.BatchInsertChat2Cache(ctx, conversationID, storageMessageList)
 		if err != nil && errs.Unwrap(err) != redis.Nil {
 			log.ZError(ctx, "batch data insert to redis err", err, "storageMsgList", storageMessageList)
+			if err := och.toDeadLetter(key, conversationID, storageList, err); err != nil {
+				log.ZError(ctx, "msg to dead letter error, the batch is not committed", err, "conversationID", conversationID)
+			}
 			return
 		}
 		if isNewConversation {
@@ -225,6 +246,10 @@ func (och *OnlineHistoryRedisConsumerHandler) handleMsg(ctx context.Context, key
 		if err != nil {
 			log.ZError(ctx, "Msg To MongoDB MQ error", err, "conversationID",
 				conversationID, "storageList", storageMessageList, "lastSeq", lastSeq)
+			if err := och.toMongoDeadLetter(ctx, key, conversationID, storageList,
+				&pbmsg.MsgDataToMongoByMQ{LastSeq: lastSeq, ConversationID: conversationID, MsgData: storageMessageList}, err); err != nil {
+				log.ZError(ctx, "mongo batch to dead letter error, the batch is not committed", err, "conversationID", conversationID, "lastSeq", lastSeq)
+			}
 		}
 		och.toPushTopic(ctx, key, conversationID, storageList)
 	}
@@ -242,6 +267,9 @@ func (och *OnlineHistoryRedisConsumerHandler) handleNotification(ctx context.Con
 		if err != nil {
 			log.ZError(ctx, "notification batch insert to redis error", err, "conversationID", conversationID,
 				"storageList", storageMessageList)
+			if err := och.toDeadLetter(key, conversationID, storageList, err); err != nil {
+				log.ZError(ctx, "notification to dead letter error, the batch is not committed", err, "conversationID", conversationID)
+			}
 			return
 		}
 		log.ZDebug(ctx, "success to next topic", "conversationID", conversationID)
@@ -249,6 +277,10 @@ func (och *OnlineHistoryRedisConsumerHandler) handleNotification(ctx context.Con
 		if err != nil {
 			log.ZError(ctx, "Msg To MongoDB MQ error", err, "conversationID",
 				conversationID, "storageList", storageMessageList, "lastSeq", lastSeq)
+			if err := och.toMongoDeadLetter(ctx, key, conversationID, storageList,
+				&pbmsg.MsgDataToMongoByMQ{LastSeq: lastSeq, ConversationID: conversationID, MsgData: storageMessageList}, err); err != nil {
+				log.ZError(ctx, "mongo batch to dead letter error, the batch is not committed", err, "conversationID", conversationID, "lastSeq", lastSeq)
+			}
 		}
 		och.toPushTopic(ctx, key, conversationID, storageList)
 	}
@@ -284,6 +316,10 @@ func (och *OnlineHistoryRedisConsumerHandler) ConsumeClaim(session sarama.Consum
 	log.ZInfo(context.Background(), "online new session msg come", "highWaterMarkOffset",
 		claim.HighWaterMarkOffset(), "topic", claim.Topic(), "partition", claim.Partition())
 	och.redisMessageBatches.OnComplete = func(lastMessage *sarama.ConsumerMessage, totalCount int) {
+		if och.failed.Load() {
+			// A batch was not kept, leave it and the ones after it to the next start.
+			return
+		}
 		session.MarkMessage(lastMessage, "")
 		session.Commit()
 	}
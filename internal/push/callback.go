@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
 
 	"github.com/KyleYe/open-im-protocol/constant"
@@ -138,6 +139,19 @@ func (c *ConsumerHandler) webhookBeforeGroupOnlinePush(
 	})
 }
 
+func webhookAfterPushTokenInvalid(ctx context.Context, client *webhook.Client, after *config.AfterConfig, provider string, token *options.TokenResult) {
+	req := &callbackstruct.CallbackAfterPushTokenInvalidReq{
+		CallbackCommand: callbackstruct.CallbackAfterPushTokenInvalidCommand,
+		UserID:          token.UserID,
+		PlatformID:      token.PlatformID,
+		Platform:        constant.PlatformIDToName(token.PlatformID),
+		Provider:        provider,
+		Token:           token.Token,
+		Reason:          token.Reason,
+	}
+	client.AsyncPost(ctx, req.GetCallbackCommand(), req, &callbackstruct.CallbackAfterPushTokenInvalidResp{}, after)
+}
+
 func GetContent(msg *sdkws.MsgData) string {
 	if msg.ContentType >= constant.NotificationBegin && msg.ContentType <= constant.NotificationEnd {
 		var notification sdkws.NotificationElem
@@ -0,0 +1,47 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apns
+
+const (
+	pushTypeAlert = "alert"
+	pushTypeVoip  = "voip"
+
+	voipTopicSuffix = ".voip"
+	// maxCollapseIDLength is the limit of the apns-collapse-id header in bytes
+	maxCollapseIDLength = 64
+)
+
+type Alert struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type Aps struct {
+	Alert          *Alert `json:"alert,omitempty"`
+	Badge          *int   `json:"badge,omitempty"`
+	Sound          string `json:"sound,omitempty"`
+	ThreadID       string `json:"thread-id,omitempty"`
+	MutableContent int    `json:"mutable-content,omitempty"`
+}
+
+// Payload is the JSON body of a notification. Alert notifications are described by Aps,
+// VoIP pushes are handed to the app by PushKit and carry the title and content themselves.
+type Payload struct {
+	Aps         *Aps   `json:"aps,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Content     string `json:"content,omitempty"`
+	Ex          string `json:"ex,omitempty"`
+	ClientMsgID string `json:"clientMsgID,omitempty"`
+}
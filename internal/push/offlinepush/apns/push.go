@@ -0,0 +1,349 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apns
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	productionEndpoint  = "https://api.push.apple.com"
+	developmentEndpoint = "https://api.sandbox.push.apple.com"
+
+	// tokenLifetime keeps the provider token below the hour after which APNs rejects it,
+	// and above the 20 minutes under which refreshing is throttled.
+	tokenLifetime = 50 * time.Minute
+	// pushConcurrency is the number of users pushed at the same time over the HTTP/2 connection.
+	pushConcurrency = 16
+	requestTimeout  = 10 * time.Second
+)
+
+var Terminal = []int{constant.IOSPlatformID, constant.IPadPlatformID}
+
+// Client pushes to Apple devices over HTTP/2 with token based authentication.
+type Client struct {
+	conf       *config.Push
+	endpoint   string
+	key        *ecdsa.PrivateKey
+	httpClient *http.Client
+	cache      cache.ThirdCache
+
+	mu          sync.Mutex
+	token       string
+	tokenIssued time.Time
+}
+
+// NewClient loads the .p8 key located within the project's configuration directory.
+func NewClient(pushConf *config.Push, cache cache.ThirdCache, configPath string) (*Client, error) {
+	if pushConf.Apns.KeyFilePath == "" || pushConf.Apns.KeyID == "" || pushConf.Apns.TeamID == "" || pushConf.Apns.BundleID == "" {
+		return nil, errs.New("apns keyFilePath, keyID, teamID and bundleID are required").Wrap()
+	}
+	data, err := os.ReadFile(filepath.Join(configPath, pushConf.Apns.KeyFilePath))
+	if err != nil {
+		return nil, errs.WrapMsg(err, "read apns key failed", "path", pushConf.Apns.KeyFilePath)
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM(data)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "parse apns key failed", "path", pushConf.Apns.KeyFilePath)
+	}
+	httpClient := &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &http.Transport{ForceAttemptHTTP2: true, MaxIdleConnsPerHost: pushConcurrency},
+	}
+	return newClient(pushConf, cache, key, httpClient), nil
+}
+
+func newClient(pushConf *config.Push, cache cache.ThirdCache, key *ecdsa.PrivateKey, httpClient *http.Client) *Client {
+	endpoint := pushConf.Apns.Endpoint
+	if endpoint == "" {
+		if pushConf.IOSPush.Production {
+			endpoint = productionEndpoint
+		} else {
+			endpoint = developmentEndpoint
+		}
+	}
+	return &Client{
+		conf:       pushConf,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		key:        key,
+		httpClient: httpClient,
+		cache:      cache,
+	}
+}
+
+// invalidReasons are the errors of APNs about tokens that will never be accepted again.
+var invalidReasons = map[string]bool{
+	"BadDeviceToken":         true,
+	"Unregistered":           true,
+	"DeviceTokenNotForTopic": true,
+}
+
+// notification is one request to APNs.
+type notification struct {
+	platformID  int
+	deviceToken string
+	pushType    string
+	topic       string
+	collapseID  string
+	payload     *Payload
+}
+
+func (c *Client) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	var (
+		mu      sync.Mutex
+		fail    int
+		errMsg  strings.Builder
+		results []*options.TokenResult
+	)
+	g := errgroup.Group{}
+	g.SetLimit(pushConcurrency)
+	for _, userID := range userIDs {
+		userID := userID
+		g.Go(func() error {
+			userResults, n, err := c.pushUser(ctx, userID, title, content, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, userResults...)
+			if err != nil {
+				fail += n
+				errMsg.WriteString(err.Error())
+				errMsg.WriteByte('.')
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if fail != 0 {
+		return results, errs.New(fmt.Sprintf("%d message send failed;err:%s", fail, errMsg.String())).Wrap()
+	}
+	return results, nil
+}
+
+// pushUser sends the notification to every Apple device of the user and returns the rejected tokens and
+// the number of failed devices. Signaling goes to the devices with a VoIP token as a VoIP push, the other devices get an alert.
+func (c *Client) pushUser(ctx context.Context, userID string, title, content string, opts *options.Opts) ([]*options.TokenResult, int, error) {
+	voip := opts.Signal != nil && opts.Signal.ClientMsgID != ""
+	var notifications []*notification
+	var alert *Payload
+	for _, platformID := range Terminal {
+		if !opts.HasPlatform(platformID) {
+			continue
+		}
+		deviceToken, voipToken, err := c.cache.GetApnsToken(ctx, userID, platformID)
+		if err != nil {
+			if errs.Unwrap(err) != redis.Nil {
+				log.ZWarn(ctx, "get apns token failed", err, "userID", userID, "platformID", platformID)
+			}
+			continue
+		}
+		if voip && voipToken != "" {
+			notifications = append(notifications, &notification{
+				platformID:  platformID,
+				deviceToken: voipToken,
+				pushType:    pushTypeVoip,
+				topic:       c.conf.Apns.BundleID + voipTopicSuffix,
+				payload:     &Payload{Title: title, Content: content, Ex: opts.Ex, ClientMsgID: opts.Signal.ClientMsgID},
+			})
+			continue
+		}
+		if deviceToken == "" {
+			continue
+		}
+		if alert == nil {
+			badge, err := c.badge(ctx, userID, opts)
+			if err != nil {
+				return []*options.TokenResult{{UserID: userID, Reason: err.Error()}}, 1, err
+			}
+			alert = &Payload{
+				Aps: &Aps{
+					Alert:    &Alert{Title: title, Body: content},
+					Badge:    badge,
+					Sound:    opts.IOSPushSound,
+					ThreadID: opts.ConversationID,
+				},
+				Ex: opts.Ex,
+			}
+			if c.conf.Apns.MutableContent {
+				alert.Aps.MutableContent = 1
+			}
+			if voip {
+				alert.ClientMsgID = opts.Signal.ClientMsgID
+			}
+		}
+		notifications = append(notifications, &notification{
+			platformID:  platformID,
+			deviceToken: deviceToken,
+			pushType:    pushTypeAlert,
+			topic:       c.conf.Apns.BundleID,
+			collapseID:  c.collapseID(opts),
+			payload:     alert,
+		})
+	}
+	var (
+		fail    int
+		errMsg  []string
+		results []*options.TokenResult
+	)
+	for _, n := range notifications {
+		reason, err := c.send(ctx, n)
+		if err == nil {
+			continue
+		}
+		if reason == "" {
+			reason = err.Error()
+		}
+		results = append(results, &options.TokenResult{
+			UserID:     userID,
+			PlatformID: n.platformID,
+			Token:      n.deviceToken,
+			Reason:     reason,
+			Invalid:    invalidReasons[reason],
+		})
+		if invalidReasons[reason] {
+			continue
+		}
+		fail++
+		errMsg = append(errMsg, err.Error())
+	}
+	if fail != 0 {
+		return results, fail, errs.New(strings.Join(errMsg, ";"), "userID", userID).Wrap()
+	}
+	return results, 0, nil
+}
+
+// badge works out the badge of the alert the same way as the fcm pusher.
+func (c *Client) badge(ctx context.Context, userID string, opts *options.Opts) (*int, error) {
+	if opts.IOSBadgeCount {
+		unreadCountSum, err := c.cache.IncrUserBadgeUnreadCountSum(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return &unreadCountSum, nil
+	}
+	unreadCountSum, err := c.cache.GetUserBadgeUnreadCountSum(ctx, userID)
+	if err != nil && errs.Unwrap(err) != redis.Nil {
+		return nil, err
+	}
+	if unreadCountSum == 0 {
+		unreadCountSum = 1
+	}
+	return &unreadCountSum, nil
+}
+
+func (c *Client) collapseID(opts *options.Opts) string {
+	if !c.conf.Apns.CollapseByConversation || opts.ConversationID == "" {
+		return ""
+	}
+	if len(opts.ConversationID) <= maxCollapseIDLength {
+		return opts.ConversationID
+	}
+	sum := md5.Sum([]byte(opts.ConversationID))
+	return hex.EncodeToString(sum[:])
+}
+
+// send returns the reason APNs gave for rejecting the notification.
+func (c *Client) send(ctx context.Context, n *notification) (string, error) {
+	body, err := json.Marshal(n.payload)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	token, err := c.providerToken()
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint+"/3/device/"+n.deviceToken, bytes.NewReader(body))
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	req.Header.Set("authorization", "bearer "+token)
+	req.Header.Set("apns-push-type", n.pushType)
+	req.Header.Set("apns-topic", n.topic)
+	req.Header.Set("apns-priority", "10")
+	if n.pushType == pushTypeVoip {
+		// A call is useless once missed, it is not stored for later delivery
+		req.Header.Set("apns-expiration", "0")
+	}
+	if n.collapseID != "" {
+		req.Header.Set("apns-collapse-id", n.collapseID)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return "", nil
+	}
+	var res struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&res)
+	if res.Reason == "ExpiredProviderToken" {
+		c.resetProviderToken(token)
+	}
+	return res.Reason, errs.New("apns push failed", "status", resp.StatusCode, "reason", res.Reason, "apnsID", resp.Header.Get("apns-id")).Wrap()
+}
+
+// providerToken returns the JWT authenticating the requests, signed again once tokenLifetime passed.
+func (c *Client) providerToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if c.token != "" && now.Sub(c.tokenIssued) < tokenLifetime {
+		return c.token, nil
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": c.conf.Apns.TeamID,
+		"iat": now.Unix(),
+	})
+	t.Header["kid"] = c.conf.Apns.KeyID
+	token, err := t.SignedString(c.key)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	c.token = token
+	c.tokenIssued = now
+	return token, nil
+}
+
+func (c *Client) resetProviderToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token == token {
+		c.token = ""
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+type tokenCache struct {
+	cache.ThirdCache
+	tokens map[string][2]string
+}
+
+func (c *tokenCache) GetApnsToken(_ context.Context, account string, platformID int) (string, string, error) {
+	if platformID != constant.IOSPlatformID {
+		return "", "", redis.Nil
+	}
+	t, ok := c.tokens[account]
+	if !ok {
+		return "", "", redis.Nil
+	}
+	return t[0], t[1], nil
+}
+
+func (c *tokenCache) GetUserBadgeUnreadCountSum(context.Context, string) (int, error) {
+	return 3, nil
+}
+
+type stubRequest struct {
+	proto   int
+	path    string
+	header  http.Header
+	payload Payload
+}
+
+func newStub(t *testing.T, key *ecdsa.PrivateKey) (*httptest.Server, *[]stubRequest) {
+	var (
+		mu       sync.Mutex
+		requests []stubRequest
+	)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("authorization"), "bearer ")
+		_, err := jwt.Parse(token, func(*jwt.Token) (any, error) { return &key.PublicKey, nil })
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason":"InvalidProviderToken"}`))
+			return
+		}
+		var payload Payload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		requests = append(requests, stubRequest{proto: r.ProtoMajor, path: r.URL.Path, header: r.Header, payload: payload})
+		mu.Unlock()
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		case strings.HasSuffix(r.URL.Path, "/busy"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"reason":"ServiceUnavailable"}`))
+		}
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func newTestClient(t *testing.T) (*Client, *[]stubRequest) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	srv, requests := newStub(t, key)
+	conf := &config.Push{}
+	conf.Apns.KeyID = "KEY"
+	conf.Apns.TeamID = "TEAM"
+	conf.Apns.BundleID = "io.openim.app"
+	conf.Apns.Endpoint = srv.URL
+	conf.Apns.MutableContent = true
+	conf.Apns.CollapseByConversation = true
+	c := newClient(conf, &tokenCache{tokens: map[string][2]string{
+		"alice": {"alice-device", "alice-voip"},
+		"bob":   {"bob-device", ""},
+		"eve":   {"bad", ""},
+		"carol": {"busy", ""},
+	}}, key, srv.Client())
+	return c, requests
+}
+
+func TestPushAlert(t *testing.T) {
+	c, requests := newTestClient(t)
+	opts := &options.Opts{Signal: &options.Signal{}, IOSPushSound: "default", Ex: "ex", ConversationID: "si_alice_bob"}
+	results, err := c.Push(context.Background(), []string{"alice", "bob", "nobody"}, "title", "content", opts)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Len(t, *requests, 2)
+	for _, r := range *requests {
+		assert.Equal(t, 2, r.proto)
+		assert.Equal(t, "alert", r.header.Get("apns-push-type"))
+		assert.Equal(t, "io.openim.app", r.header.Get("apns-topic"))
+		assert.Equal(t, "si_alice_bob", r.header.Get("apns-collapse-id"))
+		assert.Equal(t, "title", r.payload.Aps.Alert.Title)
+		assert.Equal(t, "content", r.payload.Aps.Alert.Body)
+		assert.Equal(t, 1, r.payload.Aps.MutableContent)
+		assert.Equal(t, 3, *r.payload.Aps.Badge)
+		assert.Equal(t, "ex", r.payload.Ex)
+	}
+}
+
+func TestPushVoip(t *testing.T) {
+	c, requests := newTestClient(t)
+	opts := &options.Opts{Signal: &options.Signal{ClientMsgID: "call"}}
+	_, err := c.Push(context.Background(), []string{"alice", "bob"}, "call", "incoming", opts)
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 2)
+	for _, r := range *requests {
+		switch r.path {
+		case "/3/device/alice-voip":
+			assert.Equal(t, "voip", r.header.Get("apns-push-type"))
+			assert.Equal(t, "io.openim.app.voip", r.header.Get("apns-topic"))
+			assert.Equal(t, "0", r.header.Get("apns-expiration"))
+			assert.Nil(t, r.payload.Aps)
+			assert.Equal(t, "call", r.payload.ClientMsgID)
+		case "/3/device/bob-device":
+			assert.Equal(t, "alert", r.header.Get("apns-push-type"))
+			assert.Equal(t, "call", r.payload.Aps.Alert.Title)
+		default:
+			t.Fatalf("unexpected request %s", r.path)
+		}
+	}
+}
+
+func TestPushFailed(t *testing.T) {
+	c, _ := newTestClient(t)
+	// An invalid token is reported, it is not a failure of the push
+	results, err := c.Push(context.Background(), []string{"bob", "eve"}, "title", "content", &options.Opts{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*options.TokenResult{{UserID: "eve", PlatformID: constant.IOSPlatformID, Token: "bad", Reason: "BadDeviceToken", Invalid: true}}, results)
+
+	results, err = c.Push(context.Background(), []string{"bob", "carol"}, "title", "content", &options.Opts{})
+	assert.ErrorContains(t, err, "ServiceUnavailable")
+	assert.Len(t, results, 1)
+	assert.False(t, results[0].Invalid)
+}
+
+func TestPushPlatforms(t *testing.T) {
+	c, requests := newTestClient(t)
+	// The tokens are on iOS only, a push limited to iPad reaches nobody
+	_, err := c.Push(context.Background(), []string{"alice", "bob"}, "title", "content", &options.Opts{PlatformIDs: []int{constant.IPadPlatformID}})
+	assert.NoError(t, err)
+	assert.Empty(t, *requests)
+
+	_, err = c.Push(context.Background(), []string{"alice", "bob"}, "title", "content", &options.Opts{PlatformIDs: []int{constant.IOSPlatformID}})
+	assert.NoError(t, err)
+	assert.Len(t, *requests, 2)
+}
@@ -26,6 +26,6 @@ func NewClient() *Dummy {
 type Dummy struct {
 }
 
-func (d *Dummy) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
-	return nil
+func (d *Dummy) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	return nil, nil
 }
@@ -75,97 +75,113 @@ func NewClient(pushConf *config.Push, cache cache.ThirdCache, fcmConfigPath stri
 	return &Fcm{fcmMsgCli: fcmMsgClient, cache: cache}, nil
 }
 
-func (f *Fcm) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
-	// accounts->registrationToken
-	allTokens := make(map[string][]string, 0)
-	for _, account := range userIDs {
-		var personTokens []string
-		for _, v := range Terminal {
-			Token, err := f.cache.GetFcmToken(ctx, account, v)
-			if err == nil {
-				personTokens = append(personTokens, Token)
+// target is the device of a message.
+type target struct {
+	userID     string
+	platformID int
+	token      string
+}
+
+func (f *Fcm) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	notification := &messaging.Notification{Title: title, Body: content}
+	var (
+		messages []*messaging.Message
+		targets  []target
+		results  []*options.TokenResult
+		fail     int
+		errMsg   []string
+	)
+	send := func() {
+		if len(messages) == 0 {
+			return
+		}
+		response, err := f.fcmMsgCli.SendAll(ctx, messages)
+		if err != nil {
+			fail += len(messages)
+			errMsg = append(errMsg, err.Error())
+			for _, t := range targets {
+				results = append(results, &options.TokenResult{
+					UserID:     t.userID,
+					PlatformID: t.platformID,
+					Token:      t.token,
+					Reason:     err.Error(),
+				})
+			}
+		} else {
+			for i, resp := range response.Responses {
+				if resp.Success {
+					continue
+				}
+				// A dead token is reported instead of failing the push, it would fail every retry
+				invalid := messaging.IsRegistrationTokenNotRegistered(resp.Error) || messaging.IsMismatchedCredential(resp.Error)
+				results = append(results, &options.TokenResult{
+					UserID:     targets[i].userID,
+					PlatformID: targets[i].platformID,
+					Token:      targets[i].token,
+					Reason:     resp.Error.Error(),
+					Invalid:    invalid,
+				})
+				if !invalid {
+					fail++
+					errMsg = append(errMsg, resp.Error.Error())
+				}
 			}
 		}
-		allTokens[account] = personTokens
+		messages = messages[:0]
+		targets = targets[:0]
 	}
-	Success := 0
-	Fail := 0
-	notification := &messaging.Notification{}
-	notification.Body = content
-	notification.Title = title
-	var messages []*messaging.Message
-	var sendErrBuilder strings.Builder
-	var msgErrBuilder strings.Builder
-	for userID, personTokens := range allTokens {
-		apns := &messaging.APNSConfig{Payload: &messaging.APNSPayload{Aps: &messaging.Aps{Sound: opts.IOSPushSound}}}
-		messageCount := len(messages)
-		if messageCount >= SinglePushCountLimit {
-			response, err := f.fcmMsgCli.SendAll(ctx, messages)
-			if err != nil {
-				Fail = Fail + messageCount
-				// Record push error
-				sendErrBuilder.WriteString(err.Error())
-				sendErrBuilder.WriteByte('.')
-			} else {
-				Success = Success + response.SuccessCount
-				Fail = Fail + response.FailureCount
-				if response.FailureCount != 0 {
-					// Record message error
-					for i := range response.Responses {
-						if !response.Responses[i].Success {
-							msgErrBuilder.WriteString(response.Responses[i].Error.Error())
-							msgErrBuilder.WriteByte('.')
-						}
-					}
-				}
+	for _, userID := range userIDs {
+		var userTargets []target
+		for _, platformID := range Terminal {
+			if !opts.HasPlatform(platformID) {
+				continue
+			}
+			token, err := f.cache.GetFcmToken(ctx, userID, platformID)
+			if err == nil {
+				userTargets = append(userTargets, target{userID: userID, platformID: platformID, token: token})
 			}
-			messages = messages[0:0]
 		}
+		apns := &messaging.APNSConfig{Payload: &messaging.APNSPayload{Aps: &messaging.Aps{Sound: opts.IOSPushSound}}}
 		if opts.IOSBadgeCount {
 			unreadCountSum, err := f.cache.IncrUserBadgeUnreadCountSum(ctx, userID)
 			if err == nil {
 				apns.Payload.Aps.Badge = &unreadCountSum
 			} else {
-				// log.Error(operationID, "IncrUserBadgeUnreadCountSum redis err", err.Error(), uid)
-				Fail++
+				fail++
+				errMsg = append(errMsg, err.Error())
+				results = append(results, &options.TokenResult{UserID: userID, Reason: err.Error()})
 				continue
 			}
 		} else {
 			unreadCountSum, err := f.cache.GetUserBadgeUnreadCountSum(ctx, userID)
 			if err == nil && unreadCountSum != 0 {
 				apns.Payload.Aps.Badge = &unreadCountSum
-			} else if err == redis.Nil || unreadCountSum == 0 {
+			} else if errs.Unwrap(err) == redis.Nil || unreadCountSum == 0 {
 				zero := 1
 				apns.Payload.Aps.Badge = &zero
 			} else {
-				// log.Error(operationID, "GetUserBadgeUnreadCountSum redis err", err.Error(), uid)
-				Fail++
+				fail++
+				errMsg = append(errMsg, err.Error())
+				results = append(results, &options.TokenResult{UserID: userID, Reason: err.Error()})
 				continue
 			}
 		}
-		for _, token := range personTokens {
-			temp := &messaging.Message{
+		for _, t := range userTargets {
+			if len(messages) >= SinglePushCountLimit {
+				send()
+			}
+			messages = append(messages, &messaging.Message{
 				Data:         map[string]string{"ex": opts.Ex},
-				Token:        token,
+				Token:        t.token,
 				Notification: notification,
 				APNS:         apns,
-			}
-			messages = append(messages, temp)
-		}
-	}
-	messageCount := len(messages)
-	if messageCount > 0 {
-		response, err := f.fcmMsgCli.SendAll(ctx, messages)
-		if err != nil {
-			Fail = Fail + messageCount
-		} else {
-			Success = Success + response.SuccessCount
-			Fail = Fail + response.FailureCount
+			})
+			targets = append(targets, t)
 		}
 	}
-	if Fail != 0 {
-		return errs.New(fmt.Sprintf("%d message send failed;send err:%s;message err:%s",
-			Fail, sendErrBuilder.String(), msgErrBuilder.String())).Wrap()
+	send()
+	if fail != 0 {
+		return results, errs.New(fmt.Sprintf("%d message send failed;err:%s", fail, strings.Join(errMsg, "."))).Wrap()
 	}
-	return nil
+	return results, nil
 }
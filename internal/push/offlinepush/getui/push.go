@@ -68,7 +68,13 @@ func NewClient(pushConf *config.Push, cache cache.ThirdCache) *Client {
 	}
 }
 
-func (g *Client) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
+// Push pushes to the userID aliases, getui reports no device tokens.
+// Every device of the alias is pushed, opts.PlatformIDs can not limit the push.
+func (g *Client) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	return nil, g.push(ctx, userIDs, title, content, opts)
+}
+
+func (g *Client) push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
 	token, err := g.cache.GetGetuiToken(ctx)
 	if err != nil {
 		if errs.Unwrap(err) == redis.Nil {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
 
+	"github.com/KyleYe/open-im-protocol/constant"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/jpush/body"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-tools/utils/httputil"
@@ -52,9 +53,26 @@ func (j *JPush) getAuthorization(appKey string, masterSecret string) string {
 	return Authorization
 }
 
-func (j *JPush) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
+// Push pushes to the userID aliases, jpush reports no device tokens.
+func (j *JPush) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	return nil, j.push(ctx, userIDs, title, content, opts)
+}
+
+func (j *JPush) push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error {
 	var pf body.Platform
-	pf.SetAll()
+	if len(opts.PlatformIDs) == 0 {
+		pf.SetAll()
+	} else {
+		if opts.HasPlatform(constant.IOSPlatformID) || opts.HasPlatform(constant.IPadPlatformID) {
+			_ = pf.SetIOS()
+		}
+		if opts.HasPlatform(constant.AndroidPlatformID) {
+			_ = pf.SetAndroid()
+		}
+		if pf.Os == nil {
+			return nil
+		}
+	}
 	var au body.Audience
 	au.SetAlias(userIDs)
 	var no body.Notification
@@ -16,37 +16,91 @@ package offlinepush
 
 import (
 	"context"
+
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/apns"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/dummy"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/fcm"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/getui"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/jpush"
 	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/webpush"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-tools/errs"
 )
 
 const (
 	geTUI    = "geTui"
 	firebase = "fcm"
 	jPush    = "jpush"
+	apple    = "apns"
+	webPush  = "webpush"
 )
 
 // OfflinePusher Offline Pusher.
 type OfflinePusher interface {
-	Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) error
+	// Push returns the devices the provider failed to push, along with the error of the failed pushes.
+	Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error)
 }
 
-func NewOfflinePusher(pushConf *config.Push, cache cache.ThirdCache, fcmConfigPath string) (OfflinePusher, error) {
+// NewOfflinePushers creates the enabled providers by name.
+func NewOfflinePushers(pushConf *config.Push, cache cache.ThirdCache, fcmConfigPath string) (map[string]OfflinePusher, error) {
+	pushers := make(map[string]OfflinePusher, len(pushConf.Enable))
+	for _, provider := range pushConf.Enable {
+		if _, ok := pushers[provider]; ok {
+			continue
+		}
+		pusher, err := newOfflinePusher(provider, pushConf, cache, fcmConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		pushers[provider] = pusher
+	}
+	return pushers, nil
+}
+
+func newOfflinePusher(provider string, pushConf *config.Push, cache cache.ThirdCache, fcmConfigPath string) (OfflinePusher, error) {
 	var offlinePusher OfflinePusher
-	switch pushConf.Enable {
+	switch provider {
 	case geTUI:
 		offlinePusher = getui.NewClient(pushConf, cache)
 	case firebase:
-		return fcm.NewClient(pushConf, cache, fcmConfigPath)
+		client, err := fcm.NewClient(pushConf, cache, fcmConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		offlinePusher = client
 	case jPush:
 		offlinePusher = jpush.NewClient(pushConf)
+	case apple:
+		client, err := apns.NewClient(pushConf, cache, fcmConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		offlinePusher = client
+	case webPush:
+		client, err := webpush.NewClient(pushConf, cache)
+		if err != nil {
+			return nil, err
+		}
+		offlinePusher = client
 	default:
 		offlinePusher = dummy.NewClient()
 	}
 	return offlinePusher, nil
 }
+
+// NewOfflinePusher returns the pusher of the single enabled provider, or a Router over the pushers when routes are configured.
+func NewOfflinePusher(pushConf *config.Push, cache cache.ThirdCache, pushers map[string]OfflinePusher) (OfflinePusher, error) {
+	if len(pushConf.Routes) > 0 {
+		return NewRouter(pushConf.Routes, cache, pushers)
+	}
+	switch len(pushConf.Enable) {
+	case 0:
+		return dummy.NewClient(), nil
+	case 1:
+		return pushers[pushConf.Enable[0]], nil
+	default:
+		return nil, errs.New("routes are required when several offline push providers are enabled", "enable", pushConf.Enable).Wrap()
+	}
+}
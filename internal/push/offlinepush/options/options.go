@@ -1,14 +1,36 @@
 package options
 
+import "slices"
+
 // Opts opts.
 type Opts struct {
-	Signal        *Signal
-	IOSPushSound  string
-	IOSBadgeCount bool
-	Ex            string
+	Signal         *Signal
+	IOSPushSound   string
+	IOSBadgeCount  bool
+	Ex             string
+	ConversationID string
+	// PlatformIDs limits the push to the devices on these platforms, every device is pushed when empty
+	PlatformIDs []int
+}
+
+// HasPlatform reports whether the devices on the platform are pushed.
+func (o *Opts) HasPlatform(platformID int) bool {
+	return len(o.PlatformIDs) == 0 || slices.Contains(o.PlatformIDs, platformID)
 }
 
 // Signal message id.
 type Signal struct {
 	ClientMsgID string
 }
+
+// TokenResult is a device the provider failed to push. Failures not tied to a device, e.g. reading the
+// badge of the user, come without a Token, so the failed users of a push are known from its results.
+type TokenResult struct {
+	UserID     string
+	PlatformID int
+	Token      string
+	// Reason is the error of the provider
+	Reason string
+	// Invalid tokens are unregistered or malformed, the provider will never accept them again
+	Invalid bool
+}
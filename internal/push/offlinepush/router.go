@@ -0,0 +1,213 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinepush
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/redis/go-redis/v9"
+)
+
+type route struct {
+	platformIDs []int
+	providers   []string
+}
+
+// Router pushes each user with several providers. Every route picks the first of its providers holding a token
+// of the user for one of its platforms, so a user with an iPhone and an Android phone can get APNs and GeTui pushes.
+type Router struct {
+	routes []route
+	// providers in the order of the configuration, the pushes of one message run in this order
+	providers []string
+	pushers   map[string]OfflinePusher
+	cache     cache.ThirdCache
+}
+
+func NewRouter(routes []config.PushRoute, cache cache.ThirdCache, pushers map[string]OfflinePusher) (*Router, error) {
+	r := &Router{pushers: pushers, cache: cache}
+	seen := make(map[string]struct{})
+	for i, conf := range routes {
+		if len(conf.Platforms) == 0 || len(conf.Providers) == 0 {
+			return nil, errs.New("push route without platforms or providers", "route", i).Wrap()
+		}
+		rt := route{providers: conf.Providers}
+		for _, platform := range conf.Platforms {
+			platformID := constant.PlatformNameToID(platform)
+			if platformID == 0 {
+				return nil, errs.New("unknown platform of push route", "route", i, "platform", platform).Wrap()
+			}
+			rt.platformIDs = append(rt.platformIDs, platformID)
+		}
+		for _, provider := range conf.Providers {
+			if _, ok := pushers[provider]; !ok {
+				return nil, errs.New("push route provider is not enabled", "route", i, "provider", provider).Wrap()
+			}
+			if _, ok := seen[provider]; !ok {
+				seen[provider] = struct{}{}
+				r.providers = append(r.providers, provider)
+			}
+		}
+		r.routes = append(r.routes, rt)
+	}
+	return r, nil
+}
+
+// routeGroup is the users a provider pushes on the same platforms.
+type routeGroup struct {
+	provider    string
+	platformIDs []int
+	userIDs     []string
+}
+
+// Push calls every provider with the platforms of the routes it was picked for, so a device is not pushed
+// by one provider as well when the route of its platform picked another one.
+func (r *Router) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	var (
+		groups     []*routeGroup
+		groupIndex = make(map[string]*routeGroup)
+	)
+	for _, userID := range userIDs {
+		providerPlatforms := make(map[string][]int, len(r.routes))
+		var providers []string
+		for _, rt := range r.routes {
+			provider, err := r.route(ctx, rt, userID)
+			if err != nil {
+				log.ZWarn(ctx, "offline push route failed", err, "userID", userID, "platformIDs", rt.platformIDs)
+				continue
+			}
+			if provider == "" {
+				continue
+			}
+			if _, ok := providerPlatforms[provider]; !ok {
+				providers = append(providers, provider)
+			}
+			providerPlatforms[provider] = append(providerPlatforms[provider], rt.platformIDs...)
+		}
+		for _, provider := range providers {
+			platformIDs := providerPlatforms[provider]
+			slices.Sort(platformIDs)
+			platformIDs = slices.Compact(platformIDs)
+			key := fmt.Sprint(provider, platformIDs)
+			group, ok := groupIndex[key]
+			if !ok {
+				group = &routeGroup{provider: provider, platformIDs: platformIDs}
+				groupIndex[key] = group
+				groups = append(groups, group)
+			}
+			group.userIDs = append(group.userIDs, userID)
+		}
+	}
+	var (
+		results  []*options.TokenResult
+		pushErrs []error
+	)
+	for _, provider := range r.providers {
+		for _, group := range groups {
+			if group.provider != provider {
+				continue
+			}
+			groupOpts := *opts
+			groupOpts.PlatformIDs = group.platformIDs
+			res, err := r.pushers[provider].Push(ctx, group.userIDs, title, content, &groupOpts)
+			results = append(results, res...)
+			if err != nil {
+				pushErrs = append(pushErrs, errs.WrapMsg(err, "offline push failed", "provider", provider, "platformIDs", group.platformIDs))
+			}
+		}
+	}
+	return results, errors.Join(pushErrs...)
+}
+
+// route returns the provider pushing the user for the route, empty when the user has no token for it.
+func (r *Router) route(ctx context.Context, rt route, userID string) (string, error) {
+	for _, provider := range rt.providers {
+		for _, platformID := range rt.platformIDs {
+			ok, err := r.hasToken(ctx, provider, userID, platformID)
+			if err != nil {
+				return "", err
+			}
+			if ok {
+				return provider, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// hasToken reports whether the provider can reach the device of the user on the platform.
+// geTui and jpush push to the userID alias and the device registers with the provider itself, the server can not
+// tell whether it did, so they always match. Put them last in a route, as the fallback of the other providers.
+func (r *Router) hasToken(ctx context.Context, provider string, userID string, platformID int) (bool, error) {
+	switch provider {
+	case firebase:
+		_, err := r.cache.GetFcmToken(ctx, userID, platformID)
+		return found(err)
+	case apple:
+		deviceToken, voipToken, err := r.cache.GetApnsToken(ctx, userID, platformID)
+		if ok, err := found(err); !ok {
+			return false, err
+		}
+		return deviceToken != "" || voipToken != "", nil
+	case webPush:
+		if platformID != constant.WebPlatformID {
+			return false, nil
+		}
+		subscriptions, err := r.cache.GetWebPushSubscriptions(ctx, userID)
+		if err != nil {
+			return false, err
+		}
+		return len(subscriptions) > 0, nil
+	default:
+		return true, nil
+	}
+}
+
+func found(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if errs.Unwrap(err) == redis.Nil {
+		return false, nil
+	}
+	return false, err
+}
+
+// DelInvalidToken removes the token the provider reported invalid, unless the device registered another token since.
+// Only the rejected one of the APNs device token and VoIP token is removed.
+func DelInvalidToken(ctx context.Context, cache cache.ThirdCache, provider string, token *options.TokenResult) (bool, error) {
+	switch provider {
+	case firebase:
+		current, err := cache.GetFcmToken(ctx, token.UserID, token.PlatformID)
+		if ok, err := found(err); !ok || current != token.Token {
+			return false, err
+		}
+		return true, cache.DelFcmToken(ctx, token.UserID, token.PlatformID)
+	case apple:
+		return cache.DelApnsTokenIfEqual(ctx, token.UserID, token.PlatformID, token.Token)
+	case webPush:
+		return true, cache.DelWebPushSubscriptions(ctx, token.UserID, token.Token)
+	default:
+		return false, nil
+	}
+}
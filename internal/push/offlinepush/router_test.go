@@ -0,0 +1,138 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinepush
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordPusher struct {
+	userIDs     []string
+	platformIDs [][]int
+	err         error
+}
+
+func (p *recordPusher) Push(_ context.Context, userIDs []string, _, _ string, opts *options.Opts) ([]*options.TokenResult, error) {
+	p.userIDs = append(p.userIDs, userIDs...)
+	p.platformIDs = append(p.platformIDs, opts.PlatformIDs)
+	if p.err != nil {
+		return []*options.TokenResult{{UserID: userIDs[0], Reason: p.err.Error()}}, p.err
+	}
+	return nil, nil
+}
+
+type tokenCache struct {
+	cache.ThirdCache
+	fcm map[string]int
+	// fcmAll are the users with a fcm token on every platform
+	fcmAll map[string]bool
+	apns   map[string]int
+	web    map[string]bool
+}
+
+func (c *tokenCache) GetFcmToken(_ context.Context, account string, platformID int) (string, error) {
+	if c.fcm[account] == platformID || c.fcmAll[account] {
+		return "fcm", nil
+	}
+	return "", redis.Nil
+}
+
+func (c *tokenCache) GetApnsToken(_ context.Context, account string, platformID int) (string, string, error) {
+	if c.apns[account] == platformID {
+		return "apns", "", nil
+	}
+	return "", "", redis.Nil
+}
+
+func (c *tokenCache) GetWebPushSubscriptions(_ context.Context, userID string) ([]*model.WebPushSubscription, error) {
+	if c.web[userID] {
+		return []*model.WebPushSubscription{{Endpoint: "https://push.example.com"}}, nil
+	}
+	return nil, nil
+}
+
+func TestRouter(t *testing.T) {
+	pushers := map[string]OfflinePusher{
+		firebase: &recordPusher{},
+		apple:    &recordPusher{},
+		webPush:  &recordPusher{},
+		geTUI:    &recordPusher{},
+	}
+	tokens := &tokenCache{
+		fcm:  map[string]int{"android": constant.AndroidPlatformID, "iosFcm": constant.IOSPlatformID},
+		apns: map[string]int{"ios": constant.IOSPlatformID, "both": constant.IPadPlatformID},
+		web:  map[string]bool{"browser": true, "both": true},
+	}
+	r, err := NewRouter([]config.PushRoute{
+		{Platforms: []string{"IOS", "IPad"}, Providers: []string{apple, firebase}},
+		{Platforms: []string{"Android"}, Providers: []string{firebase, geTUI}},
+		{Platforms: []string{"Web"}, Providers: []string{webPush}},
+	}, tokens, pushers)
+	assert.NoError(t, err)
+
+	users := []string{"android", "iosFcm", "ios", "both", "browser"}
+	results, err := r.Push(context.Background(), users, "title", "content", &options.Opts{})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, []string{"ios", "both"}, pushers[apple].(*recordPusher).userIDs)
+	assert.Equal(t, []string{"android", "iosFcm"}, pushers[firebase].(*recordPusher).userIDs)
+	// geTui pushes by alias, it is the fallback of the Android devices without fcm token
+	assert.Equal(t, []string{"iosFcm", "ios", "both", "browser"}, pushers[geTUI].(*recordPusher).userIDs)
+	assert.Equal(t, []string{"both", "browser"}, pushers[webPush].(*recordPusher).userIDs)
+	// every provider only pushes the platforms of the routes it was picked for
+	assert.Equal(t, [][]int{{constant.IOSPlatformID, constant.IPadPlatformID}}, pushers[apple].(*recordPusher).platformIDs)
+	assert.Equal(t, [][]int{{constant.AndroidPlatformID}, {constant.IOSPlatformID, constant.IPadPlatformID}}, pushers[firebase].(*recordPusher).platformIDs)
+	assert.Equal(t, [][]int{{constant.AndroidPlatformID}}, pushers[geTUI].(*recordPusher).platformIDs)
+	assert.Equal(t, [][]int{{constant.WebPlatformID}}, pushers[webPush].(*recordPusher).platformIDs)
+
+	pushers[apple].(*recordPusher).err = errors.New("apns down")
+	results, err = r.Push(context.Background(), []string{"ios", "android"}, "title", "content", &options.Opts{})
+	assert.ErrorContains(t, err, "apns down")
+	assert.Equal(t, []*options.TokenResult{{UserID: "ios", Reason: "apns down"}}, results)
+	assert.Equal(t, []string{"android", "iosFcm", "android"}, pushers[firebase].(*recordPusher).userIDs)
+}
+
+func TestRouterMergesPlatformsOfProvider(t *testing.T) {
+	fcm := &recordPusher{}
+	r, err := NewRouter([]config.PushRoute{
+		{Platforms: []string{"Android"}, Providers: []string{firebase}},
+		{Platforms: []string{"IOS"}, Providers: []string{firebase}},
+	}, &tokenCache{fcmAll: map[string]bool{"u1": true}}, map[string]OfflinePusher{firebase: fcm})
+	assert.NoError(t, err)
+	_, err = r.Push(context.Background(), []string{"u1"}, "title", "content", &options.Opts{})
+	assert.NoError(t, err)
+	// one call covering both routes picking fcm
+	assert.Equal(t, []string{"u1"}, fcm.userIDs)
+	assert.Equal(t, [][]int{{constant.IOSPlatformID, constant.AndroidPlatformID}}, fcm.platformIDs)
+	// the web token of u1 is not pushed, no route covers the platform
+}
+
+func TestNewRouter(t *testing.T) {
+	pushers := map[string]OfflinePusher{firebase: &recordPusher{}}
+	_, err := NewRouter([]config.PushRoute{{Platforms: []string{"Phone"}, Providers: []string{firebase}}}, nil, pushers)
+	assert.Error(t, err)
+	_, err = NewRouter([]config.PushRoute{{Platforms: []string{"IOS"}, Providers: []string{apple}}}, nil, pushers)
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/KyleYe/open-im-tools/errs"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	saltLength  = 16
+	keyLength   = 65
+	tagLength   = 16
+	headerSize  = saltLength + 4 + 1 + keyLength
+	recordSize  = 4096
+	paddingSize = 1
+	// maxPlaintextSize fits the whole message in the 4096 bytes every push service accepts
+	maxPlaintextSize = recordSize - headerSize - paddingSize - tagLength
+)
+
+// encrypt encrypts the plaintext for the browser with the aes128gcm content coding of RFC 8291,
+// as a single record whose header carries the ephemeral public key of the server.
+func encrypt(plaintext []byte, p256dh []byte, auth []byte) ([]byte, error) {
+	if len(plaintext) > maxPlaintextSize {
+		return nil, errs.New("web push payload too large", "size", len(plaintext)).Wrap()
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(p256dh)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "invalid p256dh")
+	}
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	salt := make([]byte, saltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return encryptWith(plaintext, uaPublic, auth, asPrivate, salt)
+}
+
+func encryptWith(plaintext []byte, uaPublic *ecdh.PublicKey, auth []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+	cek, nonce, err := deriveKeys(ecdhSecret, auth, uaPublic.Bytes(), asPublic, salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	body := make([]byte, headerSize, headerSize+len(plaintext)+paddingSize+tagLength)
+	copy(body, salt)
+	binary.BigEndian.PutUint32(body[saltLength:], recordSize)
+	body[saltLength+4] = keyLength
+	copy(body[saltLength+5:], asPublic)
+	// 0x02 delimits the last and only record
+	record := append(append(make([]byte, 0, len(plaintext)+paddingSize), plaintext...), 0x02)
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// deriveKeys derives the content encryption key and the nonce from the shared secret.
+func deriveKeys(ecdhSecret, auth, uaPublic, asPublic, salt []byte) (cek []byte, nonce []byte, err error) {
+	keyInfo := append(append([]byte("WebPush: info\x00"), uaPublic...), asPublic...)
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ecdhSecret, auth, keyInfo), ikm); err != nil {
+		return nil, nil, errs.Wrap(err)
+	}
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek = make([]byte, 16)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: aes128gcm\x00")), cek); err != nil {
+		return nil, nil, errs.Wrap(err)
+	}
+	nonce = make([]byte, 12)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte("Content-Encoding: nonce\x00")), nonce); err != nil {
+		return nil, nil, errs.Wrap(err)
+	}
+	return cek, nonce, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,356 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	pbwebpush "github.com/KyleYe/open-im-server/v3/pkg/protocol/webpush"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// vapidLifetime is the validity of the VAPID token, push services reject tokens valid for more than 24 hours
+	vapidLifetime = 12 * time.Hour
+	// vapidRefresh is the age after which a new VAPID token is signed
+	vapidRefresh = vapidLifetime / 2
+
+	pushConcurrency = 16
+	requestTimeout  = 10 * time.Second
+	defaultTTL      = 24 * 60 * 60
+)
+
+// Notification is the JSON decrypted by the service worker of the browser in its push event.
+type Notification struct {
+	Title          string `json:"title"`
+	Body           string `json:"body"`
+	Ex             string `json:"ex,omitempty"`
+	ConversationID string `json:"conversationID,omitempty"`
+	ClientMsgID    string `json:"clientMsgID,omitempty"`
+}
+
+type vapidToken struct {
+	token  string
+	issued time.Time
+}
+
+// Client pushes to browsers through their push services with the Web Push protocol (RFC 8030),
+// encrypting the payloads (RFC 8291) and identifying the server with VAPID (RFC 8292).
+type Client struct {
+	conf       *config.Push
+	cache      cache.ThirdCache
+	httpClient *http.Client
+	key        *ecdsa.PrivateKey
+	// publicKey is the base64url encoded public key sent in the k parameter of the authorization
+	publicKey string
+
+	mu     sync.Mutex
+	tokens map[string]vapidToken
+}
+
+func NewClient(pushConf *config.Push, cache cache.ThirdCache) (*Client, error) {
+	if pushConf.WebPush.PrivateKey == "" || pushConf.WebPush.Subject == "" {
+		return nil, errs.New("web push privateKey and subject are required").Wrap()
+	}
+	key, err := parseVapidKey(pushConf.WebPush.PrivateKey, pushConf.WebPush.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(pushConf, cache, key, newHTTPClient())
+}
+
+// newHTTPClient returns the client posting to the endpoints of the subscriptions. The endpoints come from the browsers,
+// so the dialed addresses must be public, whatever their host names resolve to, and redirects are not followed.
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{Timeout: requestTimeout, Control: checkDialAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Through a proxy the address of the proxy would be checked instead of the endpoint.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkDialAddress is the net.Dialer Control rejecting the addresses of the internal network.
+func checkDialAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	if ip := net.ParseIP(host); ip == nil || !pbwebpush.IsPublicIP(ip) {
+		return errs.New("web push endpoint address is not public", "address", address).Wrap()
+	}
+	return nil
+}
+
+func newClient(pushConf *config.Push, cache cache.ThirdCache, key *ecdsa.PrivateKey, httpClient *http.Client) (*Client, error) {
+	pub, err := key.PublicKey.ECDH()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &Client{
+		conf:       pushConf,
+		cache:      cache,
+		httpClient: httpClient,
+		key:        key,
+		publicKey:  base64.RawURLEncoding.EncodeToString(pub.Bytes()),
+		tokens:     make(map[string]vapidToken),
+	}, nil
+}
+
+func decodeKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
+
+// parseVapidKey parses the raw P-256 private key, checking it against the public key when given.
+func parseVapidKey(privateKey string, publicKey string) (*ecdsa.PrivateKey, error) {
+	d, err := decodeKey(privateKey)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "invalid web push privateKey")
+	}
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "invalid web push privateKey")
+	}
+	pub := key.PublicKey().Bytes()
+	if publicKey != "" {
+		expect, err := decodeKey(publicKey)
+		if err != nil || !bytes.Equal(expect, pub) {
+			return nil, errs.New("web push publicKey does not match privateKey").Wrap()
+		}
+	}
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(pub[1:33]),
+			Y:     new(big.Int).SetBytes(pub[33:]),
+		},
+		D: new(big.Int).SetBytes(d),
+	}, nil
+}
+
+func (c *Client) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	if !opts.HasPlatform(constant.WebPlatformID) {
+		return nil, nil
+	}
+	var (
+		mu      sync.Mutex
+		fail    int
+		errMsg  strings.Builder
+		results []*options.TokenResult
+	)
+	g := errgroup.Group{}
+	g.SetLimit(pushConcurrency)
+	for _, userID := range userIDs {
+		userID := userID
+		g.Go(func() error {
+			userResults, n, err := c.pushUser(ctx, userID, title, content, opts)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, userResults...)
+			if err != nil {
+				fail += n
+				errMsg.WriteString(err.Error())
+				errMsg.WriteByte('.')
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if fail != 0 {
+		return results, errs.New(fmt.Sprintf("%d message send failed;err:%s", fail, errMsg.String())).Wrap()
+	}
+	return results, nil
+}
+
+// pushUser sends the notification to every browser of the user and returns the rejected subscriptions, whose token
+// is the endpoint, and the number of failed subscriptions.
+func (c *Client) pushUser(ctx context.Context, userID string, title, content string, opts *options.Opts) ([]*options.TokenResult, int, error) {
+	subscriptions, err := c.cache.GetWebPushSubscriptions(ctx, userID)
+	if err != nil {
+		return []*options.TokenResult{{UserID: userID, PlatformID: constant.WebPlatformID, Reason: err.Error()}}, 1, err
+	}
+	if len(subscriptions) == 0 {
+		return nil, 0, nil
+	}
+	payload, err := newPayload(title, content, opts)
+	if err != nil {
+		return []*options.TokenResult{{UserID: userID, PlatformID: constant.WebPlatformID, Reason: err.Error()}}, len(subscriptions), err
+	}
+	var (
+		fail    int
+		errMsg  []string
+		results []*options.TokenResult
+	)
+	for _, subscription := range subscriptions {
+		expired, err := c.send(ctx, subscription, payload, opts)
+		if expired {
+			results = append(results, &options.TokenResult{
+				UserID:     userID,
+				PlatformID: constant.WebPlatformID,
+				Token:      subscription.Endpoint,
+				Reason:     "subscription expired",
+				Invalid:    true,
+			})
+			continue
+		}
+		if err != nil {
+			fail++
+			errMsg = append(errMsg, err.Error())
+			results = append(results, &options.TokenResult{
+				UserID:     userID,
+				PlatformID: constant.WebPlatformID,
+				Token:      subscription.Endpoint,
+				Reason:     err.Error(),
+			})
+		}
+	}
+	if fail != 0 {
+		return results, fail, errs.New(strings.Join(errMsg, ";"), "userID", userID).Wrap()
+	}
+	return results, 0, nil
+}
+
+// newPayload marshals the notification, shortening the body until it fits in a single record.
+func newPayload(title, content string, opts *options.Opts) ([]byte, error) {
+	n := Notification{Title: title, Body: content, Ex: opts.Ex, ConversationID: opts.ConversationID}
+	if opts.Signal != nil {
+		n.ClientMsgID = opts.Signal.ClientMsgID
+	}
+	for {
+		data, err := json.Marshal(&n)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		if len(data) <= maxPlaintextSize || n.Body == "" {
+			return data, nil
+		}
+		over := len(data) - maxPlaintextSize
+		if over >= len(n.Body) {
+			n.Body = ""
+			continue
+		}
+		body := n.Body[:len(n.Body)-over]
+		for !utf8.ValidString(body) {
+			body = body[:len(body)-1]
+		}
+		n.Body = body
+	}
+}
+
+// send delivers the payload to the push service of the subscription, expired reports a subscription
+// the push service answered 404 or 410 for.
+func (c *Client) send(ctx context.Context, subscription *model.WebPushSubscription, payload []byte, opts *options.Opts) (expired bool, err error) {
+	p256dh, err := decodeKey(subscription.P256dh)
+	if err != nil {
+		return false, errs.WrapMsg(err, "invalid p256dh", "endpoint", subscription.Endpoint)
+	}
+	auth, err := decodeKey(subscription.Auth)
+	if err != nil {
+		return false, errs.WrapMsg(err, "invalid auth", "endpoint", subscription.Endpoint)
+	}
+	body, err := encrypt(payload, p256dh, auth)
+	if err != nil {
+		return false, err
+	}
+	token, err := c.vapidToken(subscription.Endpoint)
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	ttl := c.conf.WebPush.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	req.Header.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+	if opts.Signal != nil && opts.Signal.ClientMsgID != "" {
+		req.Header.Set("Urgency", "high")
+	} else {
+		req.Header.Set("Urgency", "normal")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return true, nil
+	default:
+		return false, errs.New("web push failed", "status", resp.StatusCode, "endpoint", subscription.Endpoint).Wrap()
+	}
+}
+
+// vapidToken returns the VAPID JWT for the origin of the push service.
+func (c *Client) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errs.WrapMsg(err, "invalid endpoint", "endpoint", endpoint)
+	}
+	audience := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if t, ok := c.tokens[audience]; ok && now.Sub(t.issued) < vapidRefresh {
+		return t.token, nil
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": now.Add(vapidLifetime).Unix(),
+		"sub": c.conf.WebPush.Subject,
+	}).SignedString(c.key)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	c.tokens[audience] = vapidToken{token: token, issued: now}
+	return token, nil
+}
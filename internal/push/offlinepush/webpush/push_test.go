@@ -0,0 +1,176 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type browser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+type subscriptionCache struct {
+	cache.ThirdCache
+	subscriptions map[string][]*model.WebPushSubscription
+}
+
+func (c *subscriptionCache) GetWebPushSubscriptions(_ context.Context, userID string) ([]*model.WebPushSubscription, error) {
+	return c.subscriptions[userID], nil
+}
+
+// decrypt is the browser side of encrypt.
+func (b *browser) decrypt(t *testing.T, body []byte) []byte {
+	salt := body[:saltLength]
+	asPublic := body[saltLength+5 : headerSize]
+	pub, err := ecdh.P256().NewPublicKey(asPublic)
+	assert.NoError(t, err)
+	secret, err := b.key.ECDH(pub)
+	assert.NoError(t, err)
+	cek, nonce, err := deriveKeys(secret, b.auth, b.key.PublicKey().Bytes(), asPublic, salt)
+	assert.NoError(t, err)
+	gcm, err := newGCM(cek)
+	assert.NoError(t, err)
+	plaintext, err := gcm.Open(nil, nonce, body[headerSize:], nil)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x02), plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestPush(t *testing.T) {
+	vapid, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	b := &browser{auth: make([]byte, 16)}
+	b.key, err = ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	_, _ = rand.Read(b.auth)
+
+	var (
+		mu       sync.Mutex
+		received []Notification
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		authorization := strings.TrimPrefix(r.Header.Get("Authorization"), "vapid ")
+		params := strings.Split(authorization, ", ")
+		assert.Len(t, params, 2)
+		token, err := jwt.Parse(strings.TrimPrefix(params[0], "t="), func(*jwt.Token) (any, error) { return &vapid.PublicKey, nil })
+		assert.NoError(t, err)
+		assert.True(t, token.Claims.(jwt.MapClaims).VerifyAudience("http://"+r.Host, true))
+		assert.Equal(t, "aes128gcm", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "60", r.Header.Get("TTL"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		var n Notification
+		assert.NoError(t, json.Unmarshal(b.decrypt(t, body), &n))
+		mu.Lock()
+		received = append(received, n)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	subscription := func(path string) *model.WebPushSubscription {
+		return &model.WebPushSubscription{
+			Endpoint: srv.URL + path,
+			P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+			Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+		}
+	}
+	subscriptions := &subscriptionCache{subscriptions: map[string][]*model.WebPushSubscription{
+		"alice": {subscription("/alice"), subscription("/gone")},
+		"bob":   {subscription("/bob")},
+	}}
+	conf := &config.Push{}
+	conf.WebPush.Subject = "mailto:admin@example.com"
+	conf.WebPush.TTL = 60
+	c, err := newClient(conf, subscriptions, vapid, srv.Client())
+	assert.NoError(t, err)
+
+	opts := &options.Opts{Signal: &options.Signal{}, Ex: "ex", ConversationID: "si_alice_bob"}
+	results, err := c.Push(context.Background(), []string{"alice", "bob", "nobody"}, "title", "content", opts)
+	assert.NoError(t, err)
+	assert.Len(t, received, 2)
+	for _, n := range received {
+		assert.Equal(t, Notification{Title: "title", Body: "content", Ex: "ex", ConversationID: "si_alice_bob"}, n)
+	}
+	assert.Len(t, results, 1)
+	assert.Equal(t, "alice", results[0].UserID)
+	assert.Equal(t, srv.URL+"/gone", results[0].Token)
+	assert.True(t, results[0].Invalid)
+}
+
+func TestNewPayload(t *testing.T) {
+	data, err := newPayload("title", strings.Repeat("消息", maxPlaintextSize), &options.Opts{})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxPlaintextSize)
+	var n Notification
+	assert.NoError(t, json.Unmarshal(data, &n))
+	assert.NotEmpty(t, n.Body)
+}
+
+func TestParseVapidKey(t *testing.T) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	private := base64.RawURLEncoding.EncodeToString(key.Bytes())
+	public := base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	_, err = parseVapidKey(private, public)
+	assert.NoError(t, err)
+	_, err = parseVapidKey(private, public[:len(public)-2]+"AA")
+	assert.Error(t, err)
+}
+
+func TestHTTPClientRejectsInternalAddresses(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	// The loopback address of the test server is refused when dialing, not only when subscribing.
+	client := newHTTPClient()
+	_, err := client.Post(srv.URL, "application/octet-stream", nil)
+	assert.Error(t, err)
+	assert.False(t, called)
+	assert.Error(t, checkDialAddress("tcp", "10.0.0.1:443", nil))
+	assert.Error(t, checkDialAddress("tcp", "[fe80::1]:443", nil))
+	assert.NoError(t, checkDialAddress("tcp", "203.0.113.1:443", nil))
+
+	// A redirect is answered as is, it is not followed.
+	assert.Equal(t, http.ErrUseLastResponse, client.CheckRedirect(nil, nil))
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/KyleYe/open-im-protocol/msggateway"
 	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/batchpush"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/log"
 	"github.com/KyleYe/open-im-tools/utils/datautil"
@@ -18,6 +19,8 @@ type OnlinePusher interface {
 		pushToUserIDs []string) (wsResults []*msggateway.SingleMsgToUserResults, err error)
 	GetOnlinePushFailedUserIDs(ctx context.Context, msg *sdkws.MsgData, wsResults []*msggateway.SingleMsgToUserResults,
 		pushToUserIDs *[]string) []string
+	// GetConnsAndOnlineBatchPush pushes the msgs in one call per gateway, the results are in the order of msgs.
+	GetConnsAndOnlineBatchPush(ctx context.Context, msgs []*batchpush.PushMsg) (wsResults [][]*msggateway.SingleMsgToUserResults, err error)
 }
 
 type emptyOnlinePUsher struct{}
@@ -36,6 +39,10 @@ func (u emptyOnlinePUsher) GetOnlinePushFailedUserIDs(ctx context.Context, msg *
 	log.ZWarn(ctx, "emptyOnlinePUsher GetOnlinePushFailedUserIDs", nil)
 	return nil
 }
+func (emptyOnlinePUsher) GetConnsAndOnlineBatchPush(ctx context.Context, msgs []*batchpush.PushMsg) (wsResults [][]*msggateway.SingleMsgToUserResults, err error) {
+	log.ZWarn(ctx, "emptyOnlinePUsher GetConnsAndOnlineBatchPush", nil)
+	return make([][]*msggateway.SingleMsgToUserResults, len(msgs)), nil
+}
 
 func NewOnlinePusher(disCov discovery.SvcDiscoveryRegistry, config *Config) OnlinePusher {
 	switch config.Discovery.Enable {
@@ -45,6 +52,10 @@ func NewOnlinePusher(disCov discovery.SvcDiscoveryRegistry, config *Config) Onli
 		return NewDefaultAllNode(disCov, config)
 	case "etcd":
 		return NewDefaultAllNode(disCov, config)
+	case "direct":
+		return NewDefaultAllNode(disCov, config)
+	case "standalone":
+		return NewDefaultAllNode(disCov, config)
 	default:
 		return newEmptyOnlinePUsher()
 	}
@@ -114,6 +125,65 @@ func (d *DefaultAllNode) GetConnsAndOnlinePush(ctx context.Context, msg *sdkws.M
 	return wsResults, nil
 }
 
+func (d *DefaultAllNode) GetConnsAndOnlineBatchPush(ctx context.Context, msgs []*batchpush.PushMsg) (wsResults [][]*msggateway.SingleMsgToUserResults, err error) {
+	conns, err := d.disCov.GetConns(ctx, d.config.Share.RpcRegisterName.MessageGateway)
+	if err != nil {
+		return nil, err
+	}
+	if len(conns) == 0 {
+		log.ZWarn(ctx, "get gateway conn 0 ", nil)
+	}
+	indexes := make([]int, len(msgs))
+	for i := range msgs {
+		indexes[i] = i
+	}
+	batches := make(map[*grpc.ClientConn]*gatewayBatch, len(conns))
+	for _, conn := range conns {
+		batches[conn] = &gatewayBatch{req: &batchpush.BatchPushMsgsReq{Msgs: msgs}, indexes: indexes}
+	}
+	return batchPushToGateways(ctx, d.config.RpcConfig.MaxConcurrentWorkers, len(msgs), batches), nil
+}
+
+// gatewayBatch is the part of a batch pushed to one gateway, indexes maps its msgs to the msgs of the batch.
+type gatewayBatch struct {
+	req     *batchpush.BatchPushMsgsReq
+	indexes []int
+}
+
+// batchPushToGateways pushes every gateway its part of the batch and merges the results by msg, gateways failing are left out like in GetConnsAndOnlinePush.
+func batchPushToGateways(ctx context.Context, maxWorkers int, size int, batches map[*grpc.ClientConn]*gatewayBatch) [][]*msggateway.SingleMsgToUserResults {
+	var (
+		mu        sync.Mutex
+		wg        = errgroup.Group{}
+		wsResults = make([][]*msggateway.SingleMsgToUserResults, size)
+	)
+	if maxWorkers < 3 {
+		maxWorkers = 3
+	}
+	wg.SetLimit(maxWorkers)
+	for conn, batch := range batches {
+		conn, batch := conn, batch
+		wg.Go(func() error {
+			reply, err := batchpush.NewBatchPushClient(conn).BatchPushMsgs(ctx, batch.req)
+			if err != nil {
+				log.ZError(ctx, "BatchPushMsgs", err, "target", conn.Target(), "msgs", len(batch.req.Msgs))
+				return nil
+			}
+			log.ZDebug(ctx, "batch push result", "reply", reply)
+			mu.Lock()
+			defer mu.Unlock()
+			for i, result := range reply.Results {
+				if i < len(batch.indexes) {
+					wsResults[batch.indexes[i]] = append(wsResults[batch.indexes[i]], result.SinglePushResult...)
+				}
+			}
+			return nil
+		})
+	}
+	_ = wg.Wait()
+	return wsResults
+}
+
 func (d *DefaultAllNode) GetOnlinePushFailedUserIDs(_ context.Context, msg *sdkws.MsgData,
 	wsResults []*msggateway.SingleMsgToUserResults, pushToUserIDs *[]string) []string {
 
@@ -197,6 +267,46 @@ func (k *K8sStaticConsistentHash) GetConnsAndOnlinePush(ctx context.Context, msg
 	_ = wg.Wait()
 	return wsResults, nil
 }
+func (k *K8sStaticConsistentHash) GetConnsAndOnlineBatchPush(ctx context.Context, msgs []*batchpush.PushMsg) (wsResults [][]*msggateway.SingleMsgToUserResults, err error) {
+	userHosts := make(map[string]string)
+	batches := make(map[*grpc.ClientConn]*gatewayBatch)
+	hostConns := make(map[string]*grpc.ClientConn)
+	for i, msg := range msgs {
+		// The users of the msg on each host
+		hostUsers := make(map[string][]string)
+		for _, userID := range msg.PushToUserIDs {
+			host, ok := userHosts[userID]
+			if !ok {
+				host, err = k.disCov.GetUserIdHashGatewayHost(ctx, userID)
+				if err != nil {
+					log.ZError(ctx, "get msg gateway hash error", err)
+					return nil, err
+				}
+				userHosts[userID] = host
+			}
+			hostUsers[host] = append(hostUsers[host], userID)
+		}
+		for host, userIDs := range hostUsers {
+			conn, ok := hostConns[host]
+			if !ok {
+				conn, _ = k.disCov.GetConn(ctx, host)
+				hostConns[host] = conn
+			}
+			if conn == nil {
+				continue
+			}
+			batch, ok := batches[conn]
+			if !ok {
+				batch = &gatewayBatch{req: &batchpush.BatchPushMsgsReq{}}
+				batches[conn] = batch
+			}
+			batch.req.Msgs = append(batch.req.Msgs, &batchpush.PushMsg{MsgData: msg.MsgData, PushToUserIDs: userIDs})
+			batch.indexes = append(batch.indexes, i)
+		}
+	}
+	return batchPushToGateways(ctx, k.config.RpcConfig.MaxConcurrentWorkers, len(msgs), batches), nil
+}
+
 func (k *K8sStaticConsistentHash) GetOnlinePushFailedUserIDs(_ context.Context, _ *sdkws.MsgData,
 	wsResults []*msggateway.SingleMsgToUserResults, _ *[]string) []string {
 	var needOfflinePushUserIDs []string
@@ -0,0 +1,129 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/msggateway"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/batchpush"
+	"github.com/KyleYe/open-im-tools/discovery"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// recordGateway reports every user of a batch as pushed online and keeps the requests.
+type recordGateway struct {
+	batchpush.UnimplementedBatchPushServer
+	mu   sync.Mutex
+	reqs []*batchpush.BatchPushMsgsReq
+}
+
+func (g *recordGateway) BatchPushMsgs(_ context.Context, req *batchpush.BatchPushMsgsReq) (*batchpush.BatchPushMsgsResp, error) {
+	g.mu.Lock()
+	g.reqs = append(g.reqs, req)
+	g.mu.Unlock()
+	resp := &batchpush.BatchPushMsgsResp{Results: make([]*batchpush.PushMsgResult, len(req.Msgs))}
+	for i, msg := range req.Msgs {
+		resp.Results[i] = &batchpush.PushMsgResult{}
+		for _, userID := range msg.PushToUserIDs {
+			resp.Results[i].SinglePushResult = append(resp.Results[i].SinglePushResult, &msggateway.SingleMsgToUserResults{UserID: userID, OnlinePush: true})
+		}
+	}
+	return resp, nil
+}
+
+func (g *recordGateway) requests() []*batchpush.BatchPushMsgsReq {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.reqs
+}
+
+func startGateway(t *testing.T) (*recordGateway, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	gateway := &recordGateway{}
+	srv := grpc.NewServer()
+	batchpush.RegisterBatchPushServer(srv, gateway)
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+	return gateway, listener.Addr().String()
+}
+
+// hashHosts places every user on a fixed gateway host as the k8s discovery does by hashing.
+type hashHosts struct {
+	discovery.SvcDiscoveryRegistry
+	userHosts map[string]string
+}
+
+func (h *hashHosts) GetUserIdHashGatewayHost(_ context.Context, userID string) (string, error) {
+	return h.userHosts[userID], nil
+}
+
+func (h *hashHosts) GetConn(ctx context.Context, host string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if host == "down" {
+		return nil, errs.New("gateway down").Wrap()
+	}
+	return grpc.DialContext(ctx, host, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+func pushedUserIDs(results []*msggateway.SingleMsgToUserResults) []string {
+	userIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		userIDs = append(userIDs, result.UserID)
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+func TestK8sBatchPushSplitsByHost(t *testing.T) {
+	gatewayA, hostA := startGateway(t)
+	gatewayB, hostB := startGateway(t)
+	disCov := &hashHosts{userHosts: map[string]string{"a1": hostA, "a2": hostA, "b1": hostB, "d1": "down"}}
+	k := NewK8sStaticConsistentHash(disCov, &Config{})
+	msgs := []*batchpush.PushMsg{
+		{MsgData: &sdkws.MsgData{ClientMsgID: "m0"}, PushToUserIDs: []string{"a1", "b1", "a2"}},
+		{MsgData: &sdkws.MsgData{ClientMsgID: "m1"}, PushToUserIDs: []string{"b1"}},
+		{MsgData: &sdkws.MsgData{ClientMsgID: "m2"}, PushToUserIDs: []string{"a2", "d1"}},
+	}
+	results, err := k.GetConnsAndOnlineBatchPush(context.Background(), msgs)
+	assert.NoError(t, err)
+
+	// one call per host carrying only the msgs and users on that host
+	reqsA, reqsB := gatewayA.requests(), gatewayB.requests()
+	assert.Len(t, reqsA, 1)
+	assert.Len(t, reqsA[0].Msgs, 2)
+	assert.Equal(t, "m0", reqsA[0].Msgs[0].MsgData.ClientMsgID)
+	assert.Equal(t, []string{"a1", "a2"}, reqsA[0].Msgs[0].PushToUserIDs)
+	assert.Equal(t, "m2", reqsA[0].Msgs[1].MsgData.ClientMsgID)
+	assert.Equal(t, []string{"a2"}, reqsA[0].Msgs[1].PushToUserIDs)
+	assert.Len(t, reqsB, 1)
+	assert.Len(t, reqsB[0].Msgs, 2)
+	assert.Equal(t, []string{"b1"}, reqsB[0].Msgs[0].PushToUserIDs)
+	assert.Equal(t, []string{"b1"}, reqsB[0].Msgs[1].PushToUserIDs)
+
+	// the results are merged back in the order of msgs, users on an unreachable host are left out
+	assert.Len(t, results, 3)
+	assert.Equal(t, []string{"a1", "a2", "b1"}, pushedUserIDs(results[0]))
+	assert.Equal(t, []string{"b1"}, pushedUserIDs(results[1]))
+	assert.Equal(t, []string{"a2"}, pushedUserIDs(results[2]))
+}
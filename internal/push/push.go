@@ -8,6 +8,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/redis"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
 	"github.com/KyleYe/open-im-tools/db/redisutil"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"google.golang.org/grpc"
@@ -43,6 +44,9 @@ func (p pushServer) DelUserPushToken(ctx context.Context,
 	if err = p.database.DelFcmToken(ctx, req.UserID, int(req.PlatformID)); err != nil {
 		return nil, err
 	}
+	if err = p.database.DelApnsToken(ctx, req.UserID, int(req.PlatformID)); err != nil {
+		return nil, err
+	}
 	return &pbpush.DelUserPushTokenResp{}, nil
 }
 
@@ -52,11 +56,27 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 	cacheModel := redis.NewThirdCache(rdb)
-	offlinePusher, err := offlinepush.NewOfflinePusher(&config.RpcConfig, cacheModel, config.FcmConfigPath)
+	pushers, err := offlinepush.NewOfflinePushers(&config.RpcConfig, cacheModel, config.FcmConfigPath)
+	if err != nil {
+		return err
+	}
+	database := controller.NewPushDatabase(cacheModel, redis.NewPushRetryCache(rdb))
+	webhookClient := webhook.NewWebhookClient(config.WebhooksConfig.URL)
+	for provider, pusher := range pushers {
+		pushers[provider] = newTokenPruner(provider, pusher, cacheModel, webhookClient, &config.WebhooksConfig.AfterPushTokenInvalid)
+	}
+	if config.RpcConfig.Retry.Enable {
+		// Every provider has its own retry queue and circuit breaker
+		for provider, pusher := range pushers {
+			retry := newRetryPusher(provider, pusher, database, &config.RpcConfig.Retry)
+			go retry.Start(ctx)
+			pushers[provider] = retry
+		}
+	}
+	offlinePusher, err := offlinepush.NewOfflinePusher(&config.RpcConfig, cacheModel, pushers)
 	if err != nil {
 		return err
 	}
-	database := controller.NewPushDatabase(cacheModel)
 
 	consumer, err := NewConsumerHandler(config, offlinePusher, rdb, client)
 	if err != nil {
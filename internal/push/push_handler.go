@@ -29,6 +29,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
 	"github.com/KyleYe/open-im-server/v3/pkg/msgprocessor"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/batchpush"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpccache"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-server/v3/pkg/util/conversationutil"
@@ -80,26 +81,42 @@ func NewConsumerHandler(config *Config, offlinePusher offlinepush.OfflinePusher,
 	return &consumerHandler, nil
 }
 
-func (c *ConsumerHandler) handleMs2PsChat(ctx context.Context, msg []byte) {
+// pushMsg is a message consumed from the push topic with the context it was produced with.
+type pushMsg struct {
+	ctx  context.Context
+	data *pbchat.PushMsgDataToMQ
+}
+
+func (c *ConsumerHandler) parseMs2PsChat(ctx context.Context, msg []byte) *pushMsg {
 	msgFromMQ := pbchat.PushMsgDataToMQ{}
 	if err := proto.Unmarshal(msg, &msgFromMQ); err != nil {
 		log.ZError(ctx, "push Unmarshal msg err", err, "msg", string(msg))
-		return
-	}
-	pbData := &pbpush.PushMsgReq{
-		MsgData:        msgFromMQ.MsgData,
-		ConversationID: msgFromMQ.ConversationID,
+		return nil
 	}
 	sec := msgFromMQ.MsgData.SendTime / 1000
 	nowSec := timeutil.GetCurrentTimestampBySecond()
-	log.ZDebug(ctx, "push msg", "msg", pbData.String(), "sec", sec, "nowSec", nowSec)
+	log.ZDebug(ctx, "push msg", "msg", msgFromMQ.String(), "sec", sec, "nowSec", nowSec)
 	if nowSec-sec > 10 {
-		return
+		return nil
+	}
+	return &pushMsg{ctx: ctx, data: &msgFromMQ}
+}
+
+func (c *ConsumerHandler) handleMs2PsChat(ctx context.Context, msg []byte) {
+	if m := c.parseMs2PsChat(ctx, msg); m != nil {
+		c.pushMsg(m)
+	}
+}
+
+func (c *ConsumerHandler) pushMsg(m *pushMsg) {
+	pbData := &pbpush.PushMsgReq{
+		MsgData:        m.data.MsgData,
+		ConversationID: m.data.ConversationID,
 	}
 	var err error
-	switch msgFromMQ.MsgData.SessionType {
+	switch pbData.MsgData.SessionType {
 	case constant.ReadGroupChatType:
-		err = c.Push2Group(ctx, pbData.MsgData.GroupID, pbData.MsgData)
+		err = c.Push2Group(m.ctx, pbData.MsgData.GroupID, pbData.MsgData)
 	default:
 		var pushUserIDList []string
 		isSenderSync := datautil.GetSwitchFromOptions(pbData.MsgData.Options, constant.IsSenderSync)
@@ -108,10 +125,40 @@ func (c *ConsumerHandler) handleMs2PsChat(ctx context.Context, msg []byte) {
 		} else {
 			pushUserIDList = append(pushUserIDList, pbData.MsgData.RecvID, pbData.MsgData.SendID)
 		}
-		err = c.Push2User(ctx, pushUserIDList, pbData.MsgData)
+		err = c.Push2User(m.ctx, pushUserIDList, pbData.MsgData)
 	}
 	if err != nil {
-		log.ZWarn(ctx, "push failed", err, "msg", pbData.String())
+		log.ZWarn(m.ctx, "push failed", err, "msg", pbData.String())
+	}
+}
+
+// handleMs2PsChats pushes the messages of a batch, the group messages of each group are pushed together.
+func (c *ConsumerHandler) handleMs2PsChats(msgs []*sarama.ConsumerMessage) {
+	var (
+		groupIDs  []string
+		groupMsgs = make(map[string][]*pushMsg)
+	)
+	for _, msg := range msgs {
+		m := c.parseMs2PsChat(c.pushConsumerGroup.GetContextFromMsg(msg), msg.Value)
+		if m == nil {
+			continue
+		}
+		if m.data.MsgData.SessionType != constant.ReadGroupChatType {
+			c.pushMsg(m)
+			continue
+		}
+		groupID := m.data.MsgData.GroupID
+		if _, ok := groupMsgs[groupID]; !ok {
+			groupIDs = append(groupIDs, groupID)
+		}
+		groupMsgs[groupID] = append(groupMsgs[groupID], m)
+	}
+	for _, groupID := range groupIDs {
+		if ms := groupMsgs[groupID]; len(ms) == 1 {
+			c.pushMsg(ms[0])
+		} else {
+			c.Push2Groups(groupID, ms)
+		}
 	}
 }
 
@@ -120,10 +167,30 @@ func (*ConsumerHandler) Setup(sarama.ConsumerGroupSession) error { return nil }
 func (*ConsumerHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
 
 func (c *ConsumerHandler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for msg := range claim.Messages() {
-		ctx := c.pushConsumerGroup.GetContextFromMsg(msg)
-		c.handleMs2PsChat(ctx, msg.Value)
-		sess.MarkMessage(msg, "")
+	msgs := claim.Messages()
+	for msg := range msgs {
+		if c.config.RpcConfig.MaxBatchPushMsgs <= 1 {
+			ctx := c.pushConsumerGroup.GetContextFromMsg(msg)
+			c.handleMs2PsChat(ctx, msg.Value)
+			sess.MarkMessage(msg, "")
+			continue
+		}
+		// Take what is already buffered along, so that a burst is pushed in a few calls instead of one per message.
+		batch := []*sarama.ConsumerMessage{msg}
+	buffered:
+		for len(batch) < c.config.RpcConfig.MaxBatchPushMsgs {
+			select {
+			case msg, ok := <-msgs:
+				if !ok {
+					break buffered
+				}
+				batch = append(batch, msg)
+			default:
+				break buffered
+			}
+		}
+		c.handleMs2PsChats(batch)
+		sess.MarkMessage(batch[len(batch)-1], "")
 	}
 	return nil
 }
@@ -216,15 +283,54 @@ func (c *ConsumerHandler) GetConnsAndOnlinePush(ctx context.Context, msg *sdkws.
 	return result, nil
 }
 
-func (c *ConsumerHandler) Push2Group(ctx context.Context, groupID string, msg *sdkws.MsgData) (err error) {
-	log.ZDebug(ctx, "Get group msg from msg_transfer and push msg", "msg", msg.String(), "groupID", groupID)
-	var pushToUserIDs []string
-	if err = c.webhookBeforeGroupOnlinePush(ctx, &c.config.WebhooksConfig.BeforeGroupOnlinePush, groupID, msg,
-		&pushToUserIDs); err != nil {
-		return err
+// GetConnsAndOnlineBatchPush is GetConnsAndOnlinePush for many messages, the results are in the order of msgs.
+func (c *ConsumerHandler) GetConnsAndOnlineBatchPush(ctx context.Context, msgs []*sdkws.MsgData, pushToUserIDs [][]string) ([][]*msggateway.SingleMsgToUserResults, error) {
+	online := make(map[string]bool)
+	req := make([]*batchpush.PushMsg, 0, len(msgs))
+	// The index of each pushed msg in msgs, msgs without online users are left out
+	indexes := make([]int, 0, len(msgs))
+	results := make([][]*msggateway.SingleMsgToUserResults, len(msgs))
+	for i, msg := range msgs {
+		var onlineUserIDs []string
+		for _, userID := range pushToUserIDs[i] {
+			isOnline, ok := online[userID]
+			if !ok {
+				var err error
+				isOnline, err = c.onlineCache.GetUserOnline(ctx, userID)
+				if err != nil {
+					return nil, err
+				}
+				online[userID] = isOnline
+			}
+			if isOnline {
+				onlineUserIDs = append(onlineUserIDs, userID)
+			} else {
+				results[i] = append(results[i], &msggateway.SingleMsgToUserResults{
+					UserID: userID,
+				})
+			}
+		}
+		if len(onlineUserIDs) > 0 {
+			req = append(req, &batchpush.PushMsg{MsgData: msg, PushToUserIDs: onlineUserIDs})
+			indexes = append(indexes, i)
+		}
+	}
+	if len(req) == 0 {
+		return results, nil
+	}
+	wsResults, err := c.onlinePusher.GetConnsAndOnlineBatchPush(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	for i, wsResult := range wsResults {
+		results[indexes[i]] = append(results[indexes[i]], wsResult...)
 	}
+	return results, nil
+}
 
-	err = c.groupMessagesHandler(ctx, groupID, &pushToUserIDs, msg)
+func (c *ConsumerHandler) Push2Group(ctx context.Context, groupID string, msg *sdkws.MsgData) (err error) {
+	log.ZDebug(ctx, "Get group msg from msg_transfer and push msg", "msg", msg.String(), "groupID", groupID)
+	pushToUserIDs, err := c.groupPushUserIDs(ctx, groupID, msg)
 	if err != nil {
 		return err
 	}
@@ -235,7 +341,60 @@ func (c *ConsumerHandler) Push2Group(ctx context.Context, groupID string, msg *s
 	}
 
 	log.ZDebug(ctx, "group push result", "result", wsResults, "msg", msg)
+	return c.groupOfflinePush(ctx, groupID, msg, pushToUserIDs, wsResults)
+}
+
+// Push2Groups pushes the messages of one group, the gateways get all of them in one call.
+func (c *ConsumerHandler) Push2Groups(groupID string, msgs []*pushMsg) {
+	var (
+		pushed        []*pushMsg
+		pushToUserIDs [][]string
+	)
+	for _, m := range msgs {
+		log.ZDebug(m.ctx, "Get group msg from msg_transfer and push msg", "msg", m.data.MsgData.String(), "groupID", groupID)
+		userIDs, err := c.groupPushUserIDs(m.ctx, groupID, m.data.MsgData)
+		if err != nil {
+			log.ZWarn(m.ctx, "push failed", err, "msg", m.data.MsgData.String())
+			continue
+		}
+		pushed = append(pushed, m)
+		pushToUserIDs = append(pushToUserIDs, userIDs)
+	}
+	if len(pushed) == 0 {
+		return
+	}
+	ctx := pushed[0].ctx
+	msgDatas := datautil.Slice(pushed, func(m *pushMsg) *sdkws.MsgData { return m.data.MsgData })
+	wsResults, err := c.GetConnsAndOnlineBatchPush(ctx, msgDatas, pushToUserIDs)
+	if err != nil {
+		log.ZWarn(ctx, "batch push failed", err, "groupID", groupID, "msgs", len(msgDatas))
+		return
+	}
+	for i, m := range pushed {
+		log.ZDebug(m.ctx, "group push result", "result", wsResults[i], "msg", m.data.MsgData)
+		if err := c.groupOfflinePush(m.ctx, groupID, m.data.MsgData, pushToUserIDs[i], wsResults[i]); err != nil {
+			log.ZWarn(m.ctx, "push failed", err, "msg", m.data.MsgData.String())
+		}
+	}
+}
+
+// groupPushUserIDs returns the users a group message is pushed to.
+func (c *ConsumerHandler) groupPushUserIDs(ctx context.Context, groupID string, msg *sdkws.MsgData) ([]string, error) {
+	var pushToUserIDs []string
+	if err := c.webhookBeforeGroupOnlinePush(ctx, &c.config.WebhooksConfig.BeforeGroupOnlinePush, groupID, msg,
+		&pushToUserIDs); err != nil {
+		return nil, err
+	}
+
+	if err := c.groupMessagesHandler(ctx, groupID, &pushToUserIDs, msg); err != nil {
+		return nil, err
+	}
+	return pushToUserIDs, nil
+}
 
+// groupOfflinePush pushes a group message offline to the users it was not pushed to online.
+func (c *ConsumerHandler) groupOfflinePush(ctx context.Context, groupID string, msg *sdkws.MsgData, pushToUserIDs []string,
+	wsResults []*msggateway.SingleMsgToUserResults) (err error) {
 	if !c.shouldPushOffline(ctx, msg) {
 		return nil
 	}
@@ -321,7 +480,7 @@ func (c *ConsumerHandler) offlinePushMsg(ctx context.Context, msg *sdkws.MsgData
 	if err != nil {
 		return err
 	}
-	err = c.offlinePusher.Push(ctx, offlinePushUserIDs, title, content, opts)
+	_, err = c.offlinePusher.Push(ctx, offlinePushUserIDs, title, content, opts)
 	if err != nil {
 		prommetrics.MsgOfflinePushFailedCounter.Inc()
 		return err
@@ -348,7 +507,10 @@ func (c *ConsumerHandler) getOfflinePushInfos(msg *sdkws.MsgData) (title, conten
 		IsAtSelf   bool     `json:"isAtSelf"`
 	}
 
-	opts = &options.Opts{Signal: &options.Signal{}}
+	opts = &options.Opts{Signal: &options.Signal{}, ConversationID: msgprocessor.GetConversationIDByMsg(msg)}
+	if msg.ContentType == constant.SignalingNotification {
+		opts.Signal.ClientMsgID = msg.ClientMsgID
+	}
 	if msg.OfflinePushInfo != nil {
 		opts.IOSBadgeCount = msg.OfflinePushInfo.IOSBadgeCount
 		opts.IOSPushSound = msg.OfflinePushInfo.IOSPushSound
@@ -0,0 +1,277 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"github.com/KyleYe/open-im-tools/utils/idutil"
+)
+
+const (
+	retryInterval  = time.Second
+	retryBatchSize = 100
+	// retryLease is how long a claimed retry is held by its push instance, a retry the instance did not finish
+	// by then, because it stopped for example, is taken again.
+	retryLease = time.Minute
+)
+
+// offlinePushRetry is a failed offline push waiting in the retry queue.
+type offlinePushRetry struct {
+	ID          string        `json:"id"`
+	OperationID string        `json:"operationID"`
+	UserIDs     []string      `json:"userIDs"`
+	Title       string        `json:"title"`
+	Content     string        `json:"content"`
+	Opts        *options.Opts `json:"opts"`
+	// CreateTime is the time of the first failure in milliseconds
+	CreateTime int64 `json:"createTime"`
+	Attempt    int   `json:"attempt"`
+}
+
+// retryPusher queues the pushes the provider failed and retries them with exponential backoff until their maximum age.
+// Pushes are not sent to a provider whose circuit breaker is open, they go to the queue directly.
+type retryPusher struct {
+	provider string
+	pusher   offlinepush.OfflinePusher
+	database controller.PushDatabase
+	conf     *config.PushRetry
+	breaker  *circuitBreaker
+}
+
+func newRetryPusher(provider string, pusher offlinepush.OfflinePusher, database controller.PushDatabase, conf *config.PushRetry) *retryPusher {
+	return &retryPusher{
+		provider: provider,
+		pusher:   pusher,
+		database: database,
+		conf:     conf,
+		breaker:  newCircuitBreaker(conf.BreakerThreshold, time.Duration(conf.BreakerCooldown)*time.Second),
+	}
+}
+
+func (r *retryPusher) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	var (
+		results []*options.TokenResult
+		err     error
+		failed  = userIDs
+	)
+	if r.breaker.Allow() {
+		results, err = r.push(ctx, userIDs, title, content, opts)
+		if err == nil {
+			return results, nil
+		}
+		failed = failedUserIDs(userIDs, results)
+	} else {
+		err = errBreakerOpen(r.provider)
+	}
+	// The first attempt counted the message into the badge already
+	retryOpts := *opts
+	retryOpts.IOSBadgeCount = false
+	retry := &offlinePushRetry{
+		ID:          idutil.OperationIDGenerator(),
+		OperationID: mcontext.GetOperationID(ctx),
+		UserIDs:     failed,
+		Title:       title,
+		Content:     content,
+		Opts:        &retryOpts,
+		CreateTime:  time.Now().UnixMilli(),
+	}
+	if qerr := r.requeue(ctx, retry); qerr != nil {
+		log.ZError(ctx, "queue offline push retry failed", qerr, "provider", r.provider, "userIDs", failed)
+	}
+	return results, err
+}
+
+func errBreakerOpen(provider string) error {
+	return errs.New("offline push circuit breaker open", "provider", provider).Wrap()
+}
+
+// push calls the provider and reports the outcome to the breaker, the caller checked the breaker allows it.
+func (r *retryPusher) push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	results, err := r.pusher.Push(ctx, userIDs, title, content, opts)
+	if err != nil {
+		r.breaker.Failure()
+		return results, err
+	}
+	r.breaker.Success()
+	return results, nil
+}
+
+// failedUserIDs returns the users with a device failed for a reason other than an invalid token, which would fail again.
+// All users are failed when the provider reports none of them, as getui and jpush do not report per device.
+func failedUserIDs(userIDs []string, results []*options.TokenResult) []string {
+	failed := make(map[string]struct{})
+	for _, result := range results {
+		if !result.Invalid {
+			failed[result.UserID] = struct{}{}
+		}
+	}
+	if len(failed) == 0 {
+		return userIDs
+	}
+	return datautil.Filter(userIDs, func(userID string) (string, bool) {
+		_, ok := failed[userID]
+		return userID, ok
+	})
+}
+
+// backoff returns the delay before the attempt after the given one.
+func (r *retryPusher) backoff(attempt int) time.Duration {
+	backoff := time.Duration(r.conf.MinBackoff) * time.Second
+	maxBackoff := time.Duration(r.conf.MaxBackoff) * time.Second
+	for i := 0; i < attempt && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// requeue schedules the next attempt of the retry, the retry is abandoned when it would be due after its maximum age.
+func (r *retryPusher) requeue(ctx context.Context, retry *offlinePushRetry) error {
+	due := time.Now().Add(r.backoff(retry.Attempt))
+	if due.After(time.UnixMilli(retry.CreateTime).Add(time.Duration(r.conf.MaxAge) * time.Second)) {
+		prommetrics.MsgOfflinePushRetryAbandonedCounter.WithLabelValues(r.provider).Inc()
+		log.ZWarn(ctx, "offline push retry abandoned", nil, "provider", r.provider, "id", retry.ID, "attempt", retry.Attempt, "userIDs", retry.UserIDs)
+		return nil
+	}
+	data, err := json.Marshal(retry)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	return r.database.AddPushRetry(ctx, r.provider, due, string(data))
+}
+
+// Start retries the due pushes until ctx is done.
+func (r *retryPusher) Start(ctx context.Context) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for ctx.Err() == nil && r.retryDue(ctx) == retryBatchSize {
+			}
+		}
+	}
+}
+
+// retryDue runs one batch of due retries and returns its size. A retry is deleted once it was pushed or requeued,
+// the retries left when ctx is done are taken again after their lease.
+func (r *retryPusher) retryDue(ctx context.Context) int {
+	ctx = mcontext.SetOperationID(ctx, fmt.Sprintf("push_retry_%d_%d", os.Getpid(), time.Now().UnixMilli()))
+	items, err := r.database.ClaimPushRetries(ctx, r.provider, retryLease, retryBatchSize)
+	if err != nil {
+		log.ZError(ctx, "claim offline push retries failed", err, "provider", r.provider)
+		return 0
+	}
+	for _, item := range items {
+		if ctx.Err() != nil {
+			break
+		}
+		var retry offlinePushRetry
+		if err := json.Unmarshal([]byte(item), &retry); err != nil {
+			log.ZError(ctx, "offline push retry unmarshal failed", err, "retry", item)
+		} else {
+			r.retry(mcontext.SetOperationID(ctx, retry.OperationID), &retry)
+		}
+		if err := r.database.DeletePushRetry(ctx, r.provider, item); err != nil {
+			log.ZError(ctx, "delete offline push retry failed", err, "provider", r.provider, "retry", item)
+		}
+	}
+	return len(items)
+}
+
+// retry pushes the users of the retry again, the users failing again are requeued.
+// A retry the open breaker holds back is requeued without counting an attempt.
+func (r *retryPusher) retry(ctx context.Context, retry *offlinePushRetry) {
+	if !r.breaker.Allow() {
+		log.ZDebug(ctx, "offline push retry held back by circuit breaker", "provider", r.provider, "id", retry.ID, "attempt", retry.Attempt)
+		if err := r.requeue(ctx, retry); err != nil {
+			log.ZError(ctx, "requeue offline push retry failed", err, "provider", r.provider, "id", retry.ID)
+		}
+		return
+	}
+	retry.Attempt++
+	results, err := r.push(ctx, retry.UserIDs, retry.Title, retry.Content, retry.Opts)
+	if err != nil {
+		retry.UserIDs = failedUserIDs(retry.UserIDs, results)
+		log.ZWarn(ctx, "offline push retry failed", err, "provider", r.provider, "id", retry.ID, "attempt", retry.Attempt, "userIDs", retry.UserIDs)
+		if err := r.requeue(ctx, retry); err != nil {
+			log.ZError(ctx, "requeue offline push retry failed", err, "provider", r.provider, "id", retry.ID)
+		}
+		return
+	}
+	prommetrics.MsgOfflinePushRetrySuccessCounter.WithLabelValues(r.provider).Inc()
+	log.ZInfo(ctx, "offline push retry success", "provider", r.provider, "id", retry.ID, "attempt", retry.Attempt)
+}
+
+// circuitBreaker opens after threshold consecutive failures. Once cooldown passed it lets one call through,
+// which closes it again on success. The other calls are rejected meanwhile, they do not wait for it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.threshold <= 0 || b.failures < b.threshold {
+		return true
+	}
+	now := b.now()
+	if now.Before(b.openUntil) {
+		return false
+	}
+	// Half open, the calls until this one succeeds or another cooldown passed are rejected
+	b.openUntil = now.Add(b.cooldown)
+	return true
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.threshold > 0 && b.failures >= b.threshold {
+		b.openUntil = b.now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := newCircuitBreaker(2, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	b.Failure()
+	assert.True(t, b.Allow())
+	b.Failure()
+	assert.False(t, b.Allow())
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, b.Allow())
+	assert.False(t, b.Allow())
+
+	b.Success()
+	assert.True(t, b.Allow())
+}
+
+func TestRetryBackoff(t *testing.T) {
+	r := &retryPusher{conf: &config.PushRetry{MinBackoff: 5, MaxBackoff: 60}}
+	assert.Equal(t, 5*time.Second, r.backoff(0))
+	assert.Equal(t, 10*time.Second, r.backoff(1))
+	assert.Equal(t, 40*time.Second, r.backoff(3))
+	assert.Equal(t, 60*time.Second, r.backoff(4))
+	assert.Equal(t, 60*time.Second, r.backoff(100))
+}
+
+// failingPusher fails the devices of the users in failed, the users in invalid have a dead token.
+type failingPusher struct {
+	failed  map[string]bool
+	invalid map[string]bool
+	calls   [][]string
+	opts    []options.Opts
+}
+
+func (f *failingPusher) Push(_ context.Context, userIDs []string, _, _ string, opts *options.Opts) ([]*options.TokenResult, error) {
+	f.calls = append(f.calls, userIDs)
+	f.opts = append(f.opts, *opts)
+	var (
+		results []*options.TokenResult
+		err     error
+	)
+	for _, userID := range userIDs {
+		switch {
+		case f.invalid[userID]:
+			results = append(results, &options.TokenResult{UserID: userID, Token: "dead", Invalid: true})
+		case f.failed[userID]:
+			results = append(results, &options.TokenResult{UserID: userID, Token: "t", Reason: "unavailable"})
+			err = errors.New("unavailable")
+		}
+	}
+	return results, err
+}
+
+// queuedRetries keeps the retries added to the queue, the claimed ones are handed out once and kept until deleted.
+type queuedRetries struct {
+	controller.PushDatabase
+	retries []*offlinePushRetry
+	claimed []string
+	deleted []string
+}
+
+func (q *queuedRetries) ClaimPushRetries(context.Context, string, time.Duration, int) ([]string, error) {
+	return q.claimed, nil
+}
+
+func (q *queuedRetries) DeletePushRetry(_ context.Context, _ string, retry string) error {
+	q.deleted = append(q.deleted, retry)
+	return nil
+}
+
+func (q *queuedRetries) AddPushRetry(_ context.Context, _ string, _ time.Time, retry string) error {
+	var r offlinePushRetry
+	if err := json.Unmarshal([]byte(retry), &r); err != nil {
+		return err
+	}
+	q.retries = append(q.retries, &r)
+	return nil
+}
+
+func TestRetryWithoutBadgeCount(t *testing.T) {
+	ctx := context.Background()
+	pusher := &failingPusher{failed: map[string]bool{"u1": true, "u2": true}}
+	queue := &queuedRetries{}
+	r := newRetryPusher("fcm", pusher, queue, &config.PushRetry{MinBackoff: 1, MaxBackoff: 10, MaxAge: 600, BreakerThreshold: 2, BreakerCooldown: 30})
+
+	_, err := r.Push(ctx, []string{"u1", "u2"}, "title", "content", &options.Opts{IOSBadgeCount: true})
+	assert.Error(t, err)
+	assert.True(t, pusher.opts[0].IOSBadgeCount)
+	assert.Len(t, queue.retries, 1)
+	retry := queue.retries[0]
+	assert.Equal(t, []string{"u1", "u2"}, retry.UserIDs)
+	// the badge was counted by the first attempt
+	assert.False(t, retry.Opts.IOSBadgeCount)
+
+	r.retry(ctx, retry)
+	assert.False(t, pusher.opts[1].IOSBadgeCount)
+	assert.Len(t, queue.retries, 2)
+	assert.Equal(t, 1, queue.retries[1].Attempt)
+}
+
+func TestRetryOnlyFailedUsers(t *testing.T) {
+	ctx := context.Background()
+	pusher := &failingPusher{failed: map[string]bool{"u2": true, "u3": true}, invalid: map[string]bool{"u4": true}}
+	queue := &queuedRetries{}
+	r := newRetryPusher("fcm", pusher, queue, &config.PushRetry{MinBackoff: 1, MaxBackoff: 10, MaxAge: 600, BreakerThreshold: 2, BreakerCooldown: 30})
+
+	_, err := r.Push(ctx, []string{"u1", "u2", "u3", "u4"}, "title", "content", &options.Opts{})
+	assert.Error(t, err)
+	assert.Len(t, queue.retries, 1)
+	retry := queue.retries[0]
+	assert.Equal(t, []string{"u2", "u3"}, retry.UserIDs)
+
+	// u3 succeeds on the retry, only u2 is queued again
+	delete(pusher.failed, "u3")
+	r.retry(ctx, retry)
+	assert.Equal(t, []string{"u2", "u3"}, pusher.calls[1])
+	assert.Len(t, queue.retries, 2)
+	assert.Equal(t, []string{"u2"}, queue.retries[1].UserIDs)
+}
+
+func TestRetryHeldBackByBreaker(t *testing.T) {
+	ctx := context.Background()
+	pusher := &failingPusher{failed: map[string]bool{"u1": true}}
+	queue := &queuedRetries{}
+	r := newRetryPusher("fcm", pusher, queue, &config.PushRetry{MinBackoff: 1, MaxBackoff: 10, MaxAge: 600, BreakerThreshold: 1, BreakerCooldown: 30})
+
+	_, err := r.Push(ctx, []string{"u1"}, "title", "content", &options.Opts{})
+	assert.Error(t, err)
+	assert.Len(t, pusher.calls, 1)
+
+	// the breaker is open, the retry is requeued without calling the provider or counting an attempt
+	retry := queue.retries[0]
+	r.retry(ctx, retry)
+	assert.Len(t, pusher.calls, 1)
+	assert.Len(t, queue.retries, 2)
+	assert.Equal(t, 0, queue.retries[1].Attempt)
+	assert.Equal(t, []string{"u1"}, queue.retries[1].UserIDs)
+
+	// a push while the breaker is open is queued for all its users
+	_, err = r.Push(ctx, []string{"u1", "u2"}, "title", "content", &options.Opts{})
+	assert.Error(t, err)
+	assert.Len(t, pusher.calls, 1)
+	assert.Equal(t, []string{"u1", "u2"}, queue.retries[2].UserIDs)
+}
+
+func TestRetryDueDeletesHandledRetries(t *testing.T) {
+	pusher := &failingPusher{failed: map[string]bool{"u2": true}}
+	queue := &queuedRetries{}
+	r := newRetryPusher("fcm", pusher, queue, &config.PushRetry{MinBackoff: 1, MaxBackoff: 10, MaxAge: 600, BreakerThreshold: 2, BreakerCooldown: 30})
+	for _, userID := range []string{"u1", "u2"} {
+		data, err := json.Marshal(&offlinePushRetry{ID: userID, UserIDs: []string{userID}, Opts: &options.Opts{}, CreateTime: time.Now().UnixMilli()})
+		assert.NoError(t, err)
+		queue.claimed = append(queue.claimed, string(data))
+	}
+	queue.claimed = append(queue.claimed, "not a retry")
+
+	// The pushed, the requeued and the unreadable retries are all deleted from the queue.
+	assert.Equal(t, 3, r.retryDue(context.Background()))
+	assert.Equal(t, queue.claimed, queue.deleted)
+	assert.Len(t, queue.retries, 1)
+	assert.Equal(t, []string{"u2"}, queue.retries[0].UserIDs)
+
+	// The retries claimed after ctx is done are left to be taken again once their lease expires.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	queue.deleted = nil
+	r.retryDue(ctx)
+	assert.Empty(t, queue.deleted)
+	assert.Len(t, pusher.calls, 2)
+}
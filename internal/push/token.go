@@ -0,0 +1,61 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
+	"github.com/KyleYe/open-im-tools/log"
+)
+
+// tokenPruner removes the device tokens the provider reports invalid, so later pushes no longer waste calls on them.
+type tokenPruner struct {
+	provider      string
+	pusher        offlinepush.OfflinePusher
+	cache         cache.ThirdCache
+	webhookClient *webhook.Client
+	after         *config.AfterConfig
+}
+
+func newTokenPruner(provider string, pusher offlinepush.OfflinePusher, cache cache.ThirdCache, webhookClient *webhook.Client, after *config.AfterConfig) *tokenPruner {
+	return &tokenPruner{provider: provider, pusher: pusher, cache: cache, webhookClient: webhookClient, after: after}
+}
+
+func (p *tokenPruner) Push(ctx context.Context, userIDs []string, title, content string, opts *options.Opts) ([]*options.TokenResult, error) {
+	results, err := p.pusher.Push(ctx, userIDs, title, content, opts)
+	for _, result := range results {
+		if !result.Invalid {
+			continue
+		}
+		prommetrics.MsgOfflinePushInvalidTokenCounter.WithLabelValues(p.provider).Inc()
+		removed, err := offlinepush.DelInvalidToken(ctx, p.cache, p.provider, result)
+		if err != nil {
+			log.ZWarn(ctx, "remove invalid push token failed", err, "provider", p.provider, "userID", result.UserID, "platformID", result.PlatformID)
+			continue
+		}
+		if !removed {
+			continue
+		}
+		log.ZInfo(ctx, "invalid push token removed", "provider", p.provider, "userID", result.UserID, "platformID", result.PlatformID, "reason", result.Reason)
+		webhookAfterPushTokenInvalid(ctx, p.webhookClient, p.after, p.provider, result)
+	}
+	return results, err
+}
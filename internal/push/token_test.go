@@ -0,0 +1,97 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/internal/push/offlinepush/options"
+	"github.com/KyleYe/open-im-server/v3/pkg/callbackstruct"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+type resultPusher []*options.TokenResult
+
+func (p resultPusher) Push(context.Context, []string, string, string, *options.Opts) ([]*options.TokenResult, error) {
+	return p, nil
+}
+
+type fcmTokenCache struct {
+	cache.ThirdCache
+	tokens map[string]string
+}
+
+func (c *fcmTokenCache) GetFcmToken(_ context.Context, account string, _ int) (string, error) {
+	token, ok := c.tokens[account]
+	if !ok {
+		return "", redis.Nil
+	}
+	return token, nil
+}
+
+func (c *fcmTokenCache) DelFcmToken(_ context.Context, account string, _ int) error {
+	delete(c.tokens, account)
+	return nil
+}
+
+func TestTokenPruner(t *testing.T) {
+	callbacks := make(chan callbackstruct.CallbackAfterPushTokenInvalidReq, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req callbackstruct.CallbackAfterPushTokenInvalidReq
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		callbacks <- req
+		_, _ = w.Write([]byte(`{"errCode":0}`))
+	}))
+	defer srv.Close()
+
+	tokens := &fcmTokenCache{tokens: map[string]string{"alice": "dead", "bob": "refreshed", "carol": "busy"}}
+	pusher := resultPusher{
+		{UserID: "alice", PlatformID: constant.AndroidPlatformID, Token: "dead", Reason: "UNREGISTERED", Invalid: true},
+		// The device registered a new token after the push
+		{UserID: "bob", PlatformID: constant.AndroidPlatformID, Token: "old", Reason: "UNREGISTERED", Invalid: true},
+		{UserID: "carol", PlatformID: constant.AndroidPlatformID, Token: "busy", Reason: "UNAVAILABLE"},
+	}
+	p := newTokenPruner("fcm", pusher, tokens, webhook.NewWebhookClient(srv.URL), &config.AfterConfig{Enable: true, Timeout: 5})
+	results, err := p.Push(context.Background(), []string{"alice", "bob", "carol"}, "title", "content", &options.Opts{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, map[string]string{"bob": "refreshed", "carol": "busy"}, tokens.tokens)
+
+	select {
+	case req := <-callbacks:
+		assert.Equal(t, callbackstruct.CallbackAfterPushTokenInvalidCommand, req.GetCallbackCommand())
+		assert.Equal(t, "alice", req.UserID)
+		assert.Equal(t, "fcm", req.Provider)
+		assert.Equal(t, "dead", req.Token)
+		assert.Equal(t, "Android", req.Platform)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not called")
+	}
+	select {
+	case req := <-callbacks:
+		t.Fatalf("unexpected webhook for %s", req.UserID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
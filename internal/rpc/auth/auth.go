@@ -29,6 +29,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/session"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/errs"
@@ -39,6 +40,7 @@ import (
 
 type authServer struct {
 	authDatabase   controller.AuthDatabase
+	keySet         *authverify.KeySet
 	userRpcClient  *rpcclient.UserRpcClient
 	RegisterCenter discovery.SvcDiscoveryRegistry
 	config         *Config
@@ -56,61 +58,93 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	if err != nil {
 		return err
 	}
+	keySet, err := authverify.NewKeySet(&config.Share.TokenSigning, config.Share.Secret, true)
+	if err != nil {
+		return err
+	}
 	userRpcClient := rpcclient.NewUserRpcClient(client, config.Share.RpcRegisterName.User, config.Share.IMAdminUserID)
-	pbauth.RegisterAuthServer(server, &authServer{
+	tokenPolicy := &config.RpcConfig.TokenPolicy
+	s := &authServer{
 		userRpcClient:  &userRpcClient,
 		RegisterCenter: client,
 		authDatabase: controller.NewAuthDatabase(
-			redis2.NewTokenCacheModel(rdb, config.RpcConfig.TokenPolicy.Expire),
-			config.Share.Secret,
-			config.RpcConfig.TokenPolicy.Expire,
+			redis2.NewTokenCacheModel(rdb, tokenPolicy.Expire, tokenPolicy.Refresh.Expire),
+			keySet,
+			tokenPolicy.Expire,
+			tokenPolicy.Refresh.Expire,
+			tokenPolicy.Refresh.AccessExpire,
 		),
+		keySet: keySet,
 		config: config,
-	})
+	}
+	pbauth.RegisterAuthServer(server, s)
+	session.RegisterSessionServer(server, &sessionServer{authServer: s})
 	return nil
 }
 
-func (s *authServer) UserToken(ctx context.Context, req *pbauth.UserTokenReq) (*pbauth.UserTokenResp, error) {
-	resp := pbauth.UserTokenResp{}
-	if req.Secret != s.config.Share.Secret {
-		return nil, errs.ErrNoPermission.WrapMsg("secret invalid")
+func (s *authServer) refreshEnabled() bool {
+	return s.config.RpcConfig.TokenPolicy.Refresh.Expire > 0
+}
+
+// checkUserToken checks a token request authorized by the secret.
+func (s *authServer) checkUserToken(ctx context.Context, secret string, userID string) error {
+	if secret != s.config.Share.Secret {
+		return errs.ErrNoPermission.WrapMsg("secret invalid")
 	}
-	if _, err := s.userRpcClient.GetUserInfo(ctx, req.UserID); err != nil {
+	_, err := s.userRpcClient.GetUserInfo(ctx, userID)
+	return err
+}
+
+// checkGetUserToken checks a token request of an admin for another user.
+func (s *authServer) checkGetUserToken(ctx context.Context, userID string) error {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return err
+	}
+	if authverify.IsManagerUserID(userID, s.config.Share.IMAdminUserID) {
+		return errs.ErrNoPermission.WrapMsg("don't get Admin token")
+	}
+	_, err := s.userRpcClient.GetUserInfo(ctx, userID)
+	return err
+}
+
+// createToken issues a token without a refresh token, it is short-lived when refresh tokens are enabled.
+func (s *authServer) createToken(ctx context.Context, userID string, platformID int) (string, int64, error) {
+	token, err := s.authDatabase.CreateToken(ctx, userID, platformID)
+	if err != nil {
+		return "", 0, err
+	}
+	tokenPolicy := &s.config.RpcConfig.TokenPolicy
+	if s.refreshEnabled() {
+		return token, tokenPolicy.Refresh.AccessExpire * 60, nil
+	}
+	return token, tokenPolicy.Expire * 24 * 60 * 60, nil
+}
+
+func (s *authServer) UserToken(ctx context.Context, req *pbauth.UserTokenReq) (*pbauth.UserTokenResp, error) {
+	if err := s.checkUserToken(ctx, req.Secret, req.UserID); err != nil {
 		return nil, err
 	}
-	token, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
+	token, expireTimeSeconds, err := s.createToken(ctx, req.UserID, int(req.PlatformID))
 	if err != nil {
 		return nil, err
 	}
 	prommetrics.UserLoginCounter.Inc()
-	resp.Token = token
-	resp.ExpireTimeSeconds = s.config.RpcConfig.TokenPolicy.Expire * 24 * 60 * 60
-	return &resp, nil
+	return &pbauth.UserTokenResp{Token: token, ExpireTimeSeconds: expireTimeSeconds}, nil
 }
 
 func (s *authServer) GetUserToken(ctx context.Context, req *pbauth.GetUserTokenReq) (*pbauth.GetUserTokenResp, error) {
-	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+	if err := s.checkGetUserToken(ctx, req.UserID); err != nil {
 		return nil, err
 	}
-	resp := pbauth.GetUserTokenResp{}
-
-	if authverify.IsManagerUserID(req.UserID, s.config.Share.IMAdminUserID) {
-		return nil, errs.ErrNoPermission.WrapMsg("don't get Admin token")
-	}
-	if _, err := s.userRpcClient.GetUserInfo(ctx, req.UserID); err != nil {
-		return nil, err
-	}
-	token, err := s.authDatabase.CreateToken(ctx, req.UserID, int(req.PlatformID))
+	token, expireTimeSeconds, err := s.createToken(ctx, req.UserID, int(req.PlatformID))
 	if err != nil {
 		return nil, err
 	}
-	resp.Token = token
-	resp.ExpireTimeSeconds = s.config.RpcConfig.TokenPolicy.Expire * 24 * 60 * 60
-	return &resp, nil
+	return &pbauth.GetUserTokenResp{Token: token, ExpireTimeSeconds: expireTimeSeconds}, nil
 }
 
 func (s *authServer) parseToken(ctx context.Context, tokensString string) (claims *tokenverify.Claims, err error) {
-	claims, err = tokenverify.GetClaimFromToken(tokensString, authverify.Secret(s.config.Share.Secret))
+	claims, err = tokenverify.GetClaimFromToken(tokensString, s.keySet.Keyfunc)
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
@@ -188,7 +222,7 @@ func (s *authServer) forceKickOff(ctx context.Context, userID string, platformID
 			return err
 		}
 	}
-	return nil
+	return s.kickRefreshTokens(ctx, userID, int(platformID), "")
 }
 
 func (s *authServer) InvalidateToken(ctx context.Context, req *pbauth.InvalidateTokenReq) (*pbauth.InvalidateTokenResp, error) {
@@ -213,5 +247,9 @@ func (s *authServer) InvalidateToken(ctx context.Context, req *pbauth.Invalidate
 	if err != nil {
 		return nil, err
 	}
+	// The refresh tokens of the other sessions must not be able to bring them back.
+	if err := s.kickRefreshTokens(ctx, req.UserID, int(req.PlatformID), s.tokenFamily(req.GetPreservedToken())); err != nil {
+		return nil, err
+	}
 	return &pbauth.InvalidateTokenResp{}, nil
 }
@@ -0,0 +1,198 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/session"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/tokenverify"
+	"github.com/redis/go-redis/v9"
+)
+
+// sessionServer serves the token pair flow, it shares the state of the auth service.
+type sessionServer struct {
+	*authServer
+}
+
+// createSession issues a token pair when refresh tokens are enabled and a single token otherwise.
+func (s *sessionServer) createSession(ctx context.Context, userID string, platformID int, family string) (*session.RefreshTokenResp, error) {
+	if !s.refreshEnabled() {
+		token, expireTimeSeconds, err := s.createToken(ctx, userID, platformID)
+		if err != nil {
+			return nil, err
+		}
+		return &session.RefreshTokenResp{Token: token, ExpireTimeSeconds: expireTimeSeconds}, nil
+	}
+	tokenPolicy := &s.config.RpcConfig.TokenPolicy
+	token, refreshToken, err := s.authDatabase.CreateTokenPair(ctx, userID, platformID, family)
+	if err != nil {
+		return nil, err
+	}
+	return &session.RefreshTokenResp{
+		Token:                    token,
+		ExpireTimeSeconds:        tokenPolicy.Refresh.AccessExpire * 60,
+		RefreshToken:             refreshToken,
+		RefreshExpireTimeSeconds: tokenPolicy.Refresh.Expire * 24 * 60 * 60,
+	}, nil
+}
+
+func (s *sessionServer) UserToken(ctx context.Context, req *session.UserTokenReq) (*session.UserTokenResp, error) {
+	if err := s.checkUserToken(ctx, req.Secret, req.UserID); err != nil {
+		return nil, err
+	}
+	resp, err := s.createSession(ctx, req.UserID, int(req.PlatformID), "")
+	if err != nil {
+		return nil, err
+	}
+	prommetrics.UserLoginCounter.Inc()
+	return &session.UserTokenResp{
+		Token:                    resp.Token,
+		ExpireTimeSeconds:        resp.ExpireTimeSeconds,
+		RefreshToken:             resp.RefreshToken,
+		RefreshExpireTimeSeconds: resp.RefreshExpireTimeSeconds,
+	}, nil
+}
+
+func (s *sessionServer) GetUserToken(ctx context.Context, req *session.GetUserTokenReq) (*session.GetUserTokenResp, error) {
+	if err := s.checkGetUserToken(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	resp, err := s.createSession(ctx, req.UserID, int(req.PlatformID), "")
+	if err != nil {
+		return nil, err
+	}
+	return &session.GetUserTokenResp{
+		Token:                    resp.Token,
+		ExpireTimeSeconds:        resp.ExpireTimeSeconds,
+		RefreshToken:             resp.RefreshToken,
+		RefreshExpireTimeSeconds: resp.RefreshExpireTimeSeconds,
+	}, nil
+}
+
+// RefreshToken rotates the refresh token. A refresh token can only be used once,
+// using it again means it leaked, so the whole chain it belongs to is revoked.
+func (s *sessionServer) RefreshToken(ctx context.Context, req *session.RefreshTokenReq) (*session.RefreshTokenResp, error) {
+	if !s.refreshEnabled() {
+		return nil, errs.ErrArgs.WrapMsg("refresh token is disabled")
+	}
+	claims, err := tokenverify.GetClaimFromToken(req.RefreshToken, s.keySet.Keyfunc)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	family := controller.TokenFamily(claims)
+	if claims.Subject != controller.RefreshTokenSubject || family == "" {
+		return nil, errs.Wrap(errs.ErrTokenUnknown)
+	}
+	ok, err := s.authDatabase.UseRefreshToken(ctx, claims.UserID, claims.PlatformID, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		m, err := s.authDatabase.GetRefreshTokensWithoutError(ctx, claims.UserID, claims.PlatformID)
+		if err != nil {
+			return nil, err
+		}
+		v, exist := m[req.RefreshToken]
+		switch {
+		case !exist:
+			return nil, servererrs.ErrTokenNotExist.Wrap()
+		case v == constant.KickedToken:
+			return nil, servererrs.ErrTokenKicked.Wrap()
+		case v == constant.InValidToken:
+			log.ZWarn(ctx, "refresh token reused, revoke its chain", nil, "userID", claims.UserID,
+				"platformID", claims.PlatformID, "family", family)
+			if err := s.kickTokenFamily(ctx, claims.UserID, claims.PlatformID, family); err != nil {
+				return nil, err
+			}
+			return nil, servererrs.ErrTokenKicked.Wrap()
+		default:
+			return nil, errs.Wrap(errs.ErrTokenUnknown)
+		}
+	}
+	if _, err := s.userRpcClient.GetUserInfo(ctx, claims.UserID); err != nil {
+		return nil, err
+	}
+	return s.createSession(ctx, claims.UserID, claims.PlatformID, family)
+}
+
+// tokenFamily returns the chain family of token, or "" when it does not belong to one.
+func (s *authServer) tokenFamily(token string) string {
+	if token == "" {
+		return ""
+	}
+	claims, err := tokenverify.GetClaimFromToken(token, s.keySet.Keyfunc)
+	if err != nil {
+		return ""
+	}
+	return controller.TokenFamily(claims)
+}
+
+// kickRefreshTokens kicks the refresh tokens of the user on the platform, except those of the chain keepFamily.
+func (s *authServer) kickRefreshTokens(ctx context.Context, userID string, platformID int, keepFamily string) error {
+	m, err := s.authDatabase.GetRefreshTokensWithoutError(ctx, userID, platformID)
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	kicked := make(map[string]int)
+	for k := range m {
+		if keepFamily == "" || s.tokenFamily(k) != keepFamily {
+			kicked[k] = constant.KickedToken
+		}
+	}
+	if len(kicked) == 0 {
+		return nil
+	}
+	return s.authDatabase.SetRefreshTokenMapByUidPid(ctx, userID, platformID, kicked)
+}
+
+// kickTokenFamily kicks the tokens and the refresh tokens of the chain family.
+func (s *authServer) kickTokenFamily(ctx context.Context, userID string, platformID int, family string) error {
+	m, err := s.authDatabase.GetTokensWithoutError(ctx, userID, platformID)
+	if err != nil {
+		return err
+	}
+	kicked := make(map[string]int)
+	for k := range m {
+		if s.tokenFamily(k) == family {
+			kicked[k] = constant.KickedToken
+		}
+	}
+	if len(kicked) != 0 {
+		if err := s.authDatabase.SetTokenMapByUidPid(ctx, userID, platformID, kicked); err != nil {
+			return err
+		}
+	}
+	m, err = s.authDatabase.GetRefreshTokensWithoutError(ctx, userID, platformID)
+	if err != nil {
+		return err
+	}
+	kicked = make(map[string]int)
+	for k := range m {
+		if s.tokenFamily(k) == family {
+			kicked[k] = constant.KickedToken
+		}
+	}
+	if len(kicked) == 0 {
+		return nil
+	}
+	return s.authDatabase.SetRefreshTokenMapByUidPid(ctx, userID, platformID, kicked)
+}
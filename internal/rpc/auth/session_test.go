@@ -0,0 +1,281 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pbauth "github.com/KyleYe/open-im-protocol/auth"
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-protocol/user"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/session"
+	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
+	"github.com/KyleYe/open-im-tools/tokenverify"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// memoryTokenCache keeps the token hashes of redis in memory, without expiration.
+type memoryTokenCache struct {
+	tokens  map[string]int
+	refresh map[string]int
+}
+
+func newMemoryTokenCache() *memoryTokenCache {
+	return &memoryTokenCache{tokens: make(map[string]int), refresh: make(map[string]int)}
+}
+
+func (c *memoryTokenCache) SetTokenFlag(_ context.Context, _ string, _ int, token string, flag int) error {
+	c.tokens[token] = flag
+	return nil
+}
+
+func (c *memoryTokenCache) SetTokenFlagEx(ctx context.Context, userID string, platformID int, token string, flag int) error {
+	return c.SetTokenFlag(ctx, userID, platformID, token, flag)
+}
+
+func (c *memoryTokenCache) GetTokensWithoutError(context.Context, string, int) (map[string]int, error) {
+	return copyFlags(c.tokens), nil
+}
+
+func (c *memoryTokenCache) SetTokenMapByUidPid(_ context.Context, _ string, _ int, m map[string]int) error {
+	for k, v := range m {
+		c.tokens[k] = v
+	}
+	return nil
+}
+
+func (c *memoryTokenCache) DeleteTokenByUidPid(_ context.Context, _ string, _ int, fields []string) error {
+	for _, field := range fields {
+		delete(c.tokens, field)
+	}
+	return nil
+}
+
+func (c *memoryTokenCache) SetRefreshTokenFlagEx(_ context.Context, _ string, _ int, token string, flag int) error {
+	c.refresh[token] = flag
+	return nil
+}
+
+func (c *memoryTokenCache) CompareAndSetRefreshTokenFlag(_ context.Context, _ string, _ int, token string, oldFlag int, newFlag int) (bool, error) {
+	if v, ok := c.refresh[token]; !ok || v != oldFlag {
+		return false, nil
+	}
+	c.refresh[token] = newFlag
+	return true, nil
+}
+
+func (c *memoryTokenCache) GetRefreshTokensWithoutError(context.Context, string, int) (map[string]int, error) {
+	return copyFlags(c.refresh), nil
+}
+
+func (c *memoryTokenCache) SetRefreshTokenMapByUidPid(_ context.Context, _ string, _ int, m map[string]int) error {
+	for k, v := range m {
+		c.refresh[k] = v
+	}
+	return nil
+}
+
+func (c *memoryTokenCache) DeleteRefreshTokenByUidPid(_ context.Context, _ string, _ int, fields []string) error {
+	for _, field := range fields {
+		delete(c.refresh, field)
+	}
+	return nil
+}
+
+func copyFlags(m map[string]int) map[string]int {
+	res := make(map[string]int, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+// existingUsers answers GetDesignateUsers as if every user existed.
+type existingUsers struct {
+	user.UserClient
+}
+
+func (existingUsers) GetDesignateUsers(_ context.Context, req *user.GetDesignateUsersReq, _ ...grpc.CallOption) (*user.GetDesignateUsersResp, error) {
+	resp := &user.GetDesignateUsersResp{}
+	for _, userID := range req.UserIDs {
+		resp.UsersInfo = append(resp.UsersInfo, &sdkws.UserInfo{UserID: userID})
+	}
+	return resp, nil
+}
+
+func newTestSessionServer(t *testing.T) (*sessionServer, *memoryTokenCache) {
+	keySet, err := authverify.NewKeySet(&config.TokenSigning{}, "secret", true)
+	assert.NoError(t, err)
+	conf := &Config{Share: config.Share{Secret: "secret"}}
+	conf.RpcConfig.TokenPolicy.Expire = 90
+	conf.RpcConfig.TokenPolicy.Refresh.Expire = 30
+	conf.RpcConfig.TokenPolicy.Refresh.AccessExpire = 60
+	tokens := newMemoryTokenCache()
+	s := &authServer{
+		authDatabase:  controller.NewAuthDatabase(tokens, keySet, 90, 30, 60),
+		keySet:        keySet,
+		userRpcClient: &rpcclient.UserRpcClient{Client: existingUsers{}},
+		config:        conf,
+	}
+	return &sessionServer{authServer: s}, tokens
+}
+
+func login(t *testing.T, s *sessionServer) *session.UserTokenResp {
+	resp, err := s.UserToken(context.Background(), &session.UserTokenReq{Secret: "secret", UserID: "u1", PlatformID: constant.IOSPlatformID})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.RefreshToken)
+	return resp
+}
+
+func refresh(s *sessionServer, refreshToken string) (*session.RefreshTokenResp, error) {
+	return s.RefreshToken(context.Background(), &session.RefreshTokenReq{RefreshToken: refreshToken})
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	s, tokens := newTestSessionServer(t)
+	first := login(t, s)
+
+	second, err := refresh(s, first.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+	assert.Equal(t, s.tokenFamily(first.RefreshToken), s.tokenFamily(second.RefreshToken))
+	assert.Equal(t, s.tokenFamily(first.Token), s.tokenFamily(second.Token))
+	assert.Equal(t, constant.InValidToken, tokens.refresh[first.RefreshToken])
+	assert.Equal(t, constant.NormalToken, tokens.refresh[second.RefreshToken])
+	assert.Equal(t, constant.NormalToken, tokens.tokens[second.Token])
+
+	// The access token of a pair expires after accessExpire minutes, not after the days of a single token.
+	claims, err := tokenverify.GetClaimFromToken(second.Token, s.keySet.Keyfunc)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+
+	// An access token is not a refresh token.
+	_, err = refresh(s, second.Token)
+	assert.Error(t, err)
+
+	third, err := refresh(s, second.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, second.RefreshToken, third.RefreshToken)
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	s, tokens := newTestSessionServer(t)
+	first := login(t, s)
+	other := login(t, s)
+	second, err := refresh(s, first.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = refresh(s, first.RefreshToken)
+	assert.True(t, servererrs.ErrTokenKicked.Is(err))
+	assert.Equal(t, constant.KickedToken, tokens.refresh[second.RefreshToken])
+	assert.Equal(t, constant.KickedToken, tokens.tokens[second.Token])
+	_, err = refresh(s, second.RefreshToken)
+	assert.True(t, servererrs.ErrTokenKicked.Is(err))
+
+	// The sessions of other logins are left alone.
+	assert.Equal(t, constant.NormalToken, tokens.tokens[other.Token])
+	_, err = refresh(s, other.RefreshToken)
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRevoked(t *testing.T) {
+	s, tokens := newTestSessionServer(t)
+	revoked := login(t, s)
+	kept := login(t, s)
+
+	_, err := s.InvalidateToken(context.Background(), &pbauth.InvalidateTokenReq{
+		UserID: "u1", PlatformID: constant.IOSPlatformID, PreservedToken: kept.Token,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, constant.KickedToken, tokens.tokens[revoked.Token])
+	_, err = refresh(s, revoked.RefreshToken)
+	assert.True(t, servererrs.ErrTokenKicked.Is(err))
+	_, err = refresh(s, kept.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = refresh(s, "not a token")
+	assert.Error(t, err)
+	unknown, err := s.keySet.Sign(&tokenverify.Claims{
+		UserID: "u1", PlatformID: constant.IOSPlatformID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   controller.RefreshTokenSubject,
+			ID:        "family.1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	assert.NoError(t, err)
+	_, err = refresh(s, unknown)
+	assert.True(t, servererrs.ErrTokenNotExist.Is(err))
+}
+
+func TestRefreshTokenExpired(t *testing.T) {
+	s, tokens := newTestSessionServer(t)
+	first := login(t, s)
+	family := s.tokenFamily(first.RefreshToken)
+	expired, err := s.keySet.Sign(&tokenverify.Claims{
+		UserID: "u1", PlatformID: constant.IOSPlatformID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   controller.RefreshTokenSubject,
+			ID:        family + ".expired",
+			IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+	})
+	assert.NoError(t, err)
+	tokens.refresh[expired] = constant.NormalToken
+
+	_, err = refresh(s, expired)
+	assert.Error(t, err)
+	// The flag of an expired refresh token is left as is, its family is not revoked.
+	assert.Equal(t, constant.NormalToken, tokens.refresh[expired])
+	assert.Equal(t, constant.NormalToken, tokens.refresh[first.RefreshToken])
+
+	// Expired refresh tokens are dropped when the next pair is created.
+	_, err = refresh(s, first.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotContains(t, tokens.refresh, expired)
+}
+
+func TestRefreshTokenDisabled(t *testing.T) {
+	s, _ := newTestSessionServer(t)
+	s.config.RpcConfig.TokenPolicy.Refresh.Expire = 0
+	resp, err := s.UserToken(context.Background(), &session.UserTokenReq{Secret: "secret", UserID: "u1", PlatformID: constant.IOSPlatformID})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	assert.Empty(t, resp.RefreshToken)
+	_, err = refresh(s, resp.Token)
+	assert.Error(t, err)
+}
+
+func TestLegacyUserTokenShortWithRefresh(t *testing.T) {
+	s, tokens := newTestSessionServer(t)
+	resp, err := s.authServer.UserToken(context.Background(), &pbauth.UserTokenReq{Secret: "secret", UserID: "u1", PlatformID: constant.IOSPlatformID})
+	assert.NoError(t, err)
+	// Without a refresh token to renew it, the token still expires as the access token of a pair.
+	assert.Equal(t, int64(60*60), resp.ExpireTimeSeconds)
+	claims, err := tokenverify.GetClaimFromToken(resp.Token, s.keySet.Keyfunc)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt.Time, time.Minute)
+	assert.Equal(t, constant.NormalToken, tokens.tokens[resp.Token])
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	dbModel "github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-server/v3/pkg/localcache"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 	"github.com/KyleYe/open-im-tools/db/redisutil"
 
 	"github.com/KyleYe/open-im-protocol/constant"
@@ -79,14 +80,17 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	msgRpcClient := rpcclient.NewMessageRpcClient(client, config.Share.RpcRegisterName.Msg)
 	userRpcClient := rpcclient.NewUserRpcClient(client, config.Share.RpcRegisterName.User, config.Share.IMAdminUserID)
 	localcache.InitLocalCache(&config.LocalCacheConfig)
-	pbconversation.RegisterConversationServer(server, &conversationServer{
+	srv := &conversationServer{
 		msgRpcClient:                   &msgRpcClient,
 		user:                           &userRpcClient,
 		conversationNotificationSender: NewConversationNotificationSender(&config.NotificationConfig, &msgRpcClient),
 		groupRpcClient:                 &groupRpcClient,
 		conversationDatabase: controller.NewConversationDatabase(conversationDB,
 			redis.NewConversationRedis(rdb, &config.LocalCacheConfig, redis.GetRocksCacheOptions(), conversationDB), mgocli.GetTx()),
-	})
+		config: config,
+	}
+	pbconversation.RegisterConversationServer(server, srv)
+	versionlog.RegisterVersionLogServer(server, srv)
 	return nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/KyleYe/open-im-protocol/conversation"
 	"github.com/KyleYe/open-im-server/v3/internal/rpc/incrversion"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 	"github.com/KyleYe/open-im-server/v3/pkg/util/hashutil"
 )
 
@@ -54,3 +55,7 @@ func (c *conversationServer) GetIncrementalConversation(ctx context.Context, req
 	}
 	return opt.Build()
 }
+
+func (c *conversationServer) CompactVersionLog(ctx context.Context, req *versionlog.CompactVersionLogReq) (*versionlog.CompactVersionLogResp, error) {
+	return incrversion.Compact(ctx, c.config.Share.IMAdminUserID, req, c.conversationDatabase.CompactConversationUserVersion)
+}
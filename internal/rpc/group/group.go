@@ -41,6 +41,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
 	"github.com/KyleYe/open-im-server/v3/pkg/msgprocessor"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient/grouphash"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient/notification"
@@ -118,6 +119,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	gs.config = config
 	gs.webhookClient = webhook.NewWebhookClient(config.WebhooksConfig.URL)
 	pbgroup.RegisterGroupServer(server, &gs)
+	versionlog.RegisterVersionLogServer(server, &gs)
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 	"github.com/KyleYe/open-im-server/v3/pkg/util/hashutil"
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
@@ -301,3 +302,7 @@ func (s *groupServer) GetIncrementalJoinGroup(ctx context.Context, req *pbgroup.
 	}
 	return opt.Build()
 }
+
+func (s *groupServer) CompactVersionLog(ctx context.Context, req *versionlog.CompactVersionLogReq) (*versionlog.CompactVersionLogResp, error) {
+	return incrversion.Compact(ctx, s.config.Share.IMAdminUserID, req, s.db.CompactGroupIncrVersion)
+}
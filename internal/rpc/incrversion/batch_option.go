@@ -152,7 +152,7 @@ func (o *BatchOption[A, B]) Build() (*B, error) {
 		switch tag {
 		case tagQuery:
 			vLog := versions[o.TargetKeys[i]]
-			fullMap[o.TargetKeys[i]] = vLog.ID.Hex() != o.VersionIDs[i] || uint64(vLog.Version) < o.VersionNumbers[i] || len(vLog.Logs) != vLog.LogLen
+			fullMap[o.TargetKeys[i]] = vLog.ID.Hex() != o.VersionIDs[i] || uint64(vLog.Version) < o.VersionNumbers[i] || len(vLog.Logs) != vLog.LogLen || vLog.Compacted(o.VersionNumbers[i])
 		case tagFull:
 			fullMap[o.TargetKeys[i]] = true
 		case tagEqual:
@@ -0,0 +1,40 @@
+package incrversion
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+)
+
+// Compact removes the version logs unchanged since the deadline of the request and records the compaction metrics.
+func Compact(ctx context.Context, imAdminUserID []string, req *versionlog.CompactVersionLogReq,
+	compact func(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error)) (*versionlog.CompactVersionLogResp, error) {
+	if err := authverify.CheckAdmin(ctx, imAdminUserID); err != nil {
+		return nil, err
+	}
+	if req.Deadline > time.Now().UnixMilli() {
+		return nil, errs.ErrArgs.WrapMsg("request millisecond deadline error")
+	}
+	start := time.Now()
+	compactions, err := compact(ctx, time.UnixMilli(req.Deadline))
+	if err != nil {
+		return nil, err
+	}
+	resp := &versionlog.CompactVersionLogResp{Compactions: make([]*versionlog.VersionLogCompaction, 0, len(compactions))}
+	for _, c := range compactions {
+		prommetrics.VersionLogCompacted(c.Collection, c.Docs, c.Logs)
+		log.ZInfo(ctx, "compact version log", "collection", c.Collection, "docs", c.Docs, "logs", c.Logs, "cost", time.Since(start))
+		resp.Compactions = append(resp.Compactions, &versionlog.VersionLogCompaction{
+			Collection: c.Collection,
+			Docs:       c.Docs,
+			Logs:       c.Logs,
+		})
+	}
+	return resp, nil
+}
@@ -117,7 +117,7 @@ func (o *Option[A, B]) Build() (*B, error) {
 	var full bool
 	switch tag {
 	case tagQuery:
-		full = version.ID.Hex() != o.VersionID || uint64(version.Version) < o.VersionNumber || len(version.Logs) != version.LogLen
+		full = version.ID.Hex() != o.VersionID || uint64(version.Version) < o.VersionNumber || len(version.Logs) != version.LogLen || version.Compacted(o.VersionNumber)
 	case tagFull:
 		full = true
 	case tagEqual:
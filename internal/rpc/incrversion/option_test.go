@@ -0,0 +1,47 @@
+package incrversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestOptionCompacted(t *testing.T) {
+	id := primitive.NewObjectID()
+	vl := &model.VersionLog{
+		ID:      id,
+		DID:     "user",
+		Version: 10,
+		Deleted: 6,
+		Logs:    []model.VersionLogElem{{EID: "friend", State: model.VersionStateInsert, Version: 9}},
+		LogLen:  1,
+	}
+	build := func(version uint64) bool {
+		var full bool
+		opt := &Option[string, struct{}]{
+			Ctx:           context.Background(),
+			VersionKey:    vl.DID,
+			VersionID:     id.Hex(),
+			VersionNumber: version,
+			Version: func(ctx context.Context, dId string, version uint, limit int) (*model.VersionLog, error) {
+				return vl, nil
+			},
+			Find: func(ctx context.Context, ids []string) ([]string, error) {
+				return ids, nil
+			},
+			Resp: func(version *model.VersionLog, deleteIds []string, insertList, updateList []string, f bool) *struct{} {
+				full = f
+				return &struct{}{}
+			},
+		}
+		_, err := opt.Build()
+		assert.NoError(t, err)
+		return full
+	}
+	assert.True(t, build(5), "logs newer than the version were compacted")
+	assert.False(t, build(6))
+	assert.False(t, build(8))
+}
@@ -0,0 +1,143 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/convert"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/encrypt"
+	"github.com/KyleYe/open-im-tools/utils/idutil"
+)
+
+func (m *msgServer) CreateBroadcast(ctx context.Context, req *broadcast.CreateBroadcastReq) (*broadcast.CreateBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	switch req.MsgData.SessionType {
+	case constant.SingleChatType, constant.NotificationChatType:
+	default:
+		return nil, errs.ErrArgs.WrapMsg("a broadcast is sent in single chats or notifications", "sessionType", req.MsgData.SessionType)
+	}
+	job, err := convert.BroadcastJobPb2DB(req)
+	if err != nil {
+		return nil, err
+	}
+	if job.Rate == 0 {
+		job.Rate = int32(m.config.RpcConfig.Broadcast.Rate)
+	}
+	if maxRate := int32(m.config.RpcConfig.Broadcast.MaxRate); maxRate > 0 && job.Rate > maxRate {
+		job.Rate = maxRate
+	}
+	if job.Rate <= 0 {
+		return nil, errs.ErrArgs.WrapMsg("broadcast rate is not configured")
+	}
+	now := time.Now()
+	job.JobID = encrypt.Md5(mcontext.GetOperationID(ctx) + "-" + idutil.OperationIDGenerator())
+	job.OpUserID = mcontext.GetOpUserID(ctx)
+	job.Status = model.BroadcastStatusPending
+	job.CreateTime = now
+	job.UpdateTime = now
+	if err := m.broadcastDatabase.CreateBroadcast(ctx, job); err != nil {
+		return nil, err
+	}
+	log.ZInfo(ctx, "broadcast created", "jobID", job.JobID, "targetType", job.TargetType, "total", job.Total, "rate", job.Rate)
+	return &broadcast.CreateBroadcastResp{JobID: job.JobID}, nil
+}
+
+func (m *msgServer) GetBroadcast(ctx context.Context, req *broadcast.GetBroadcastReq) (*broadcast.GetBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	job, err := m.takeBroadcast(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := convert.BroadcastJobDB2Pb(job)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast.GetBroadcastResp{Job: pb}, nil
+}
+
+func (m *msgServer) GetBroadcasts(ctx context.Context, req *broadcast.GetBroadcastsReq) (*broadcast.GetBroadcastsResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	total, jobs, err := m.broadcastDatabase.PageBroadcasts(ctx, req.Status, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	pbs, err := convert.BroadcastJobsDB2Pb(jobs)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast.GetBroadcastsResp{Total: total, Jobs: pbs}, nil
+}
+
+func (m *msgServer) GetBroadcastResults(ctx context.Context, req *broadcast.GetBroadcastResultsReq) (*broadcast.GetBroadcastResultsResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if _, err := m.takeBroadcast(ctx, req.JobID); err != nil {
+		return nil, err
+	}
+	total, results, err := m.broadcastDatabase.PageBroadcastResults(ctx, req.JobID, req.OnlyFailed, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast.GetBroadcastResultsResp{Total: total, Results: convert.BroadcastResultsDB2Pb(results)}, nil
+}
+
+func (m *msgServer) CancelBroadcast(ctx context.Context, req *broadcast.CancelBroadcastReq) (*broadcast.CancelBroadcastResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	job, err := m.takeBroadcast(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == model.BroadcastStatusCanceled {
+		return &broadcast.CancelBroadcastResp{}, nil
+	}
+	canceled, err := m.broadcastDatabase.CancelBroadcast(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	if !canceled {
+		return nil, errs.ErrArgs.WrapMsg("broadcast job is already finished", "jobID", req.JobID)
+	}
+	log.ZInfo(ctx, "broadcast canceled", "jobID", req.JobID)
+	return &broadcast.CancelBroadcastResp{}, nil
+}
+
+func (m *msgServer) takeBroadcast(ctx context.Context, jobID string) (*model.BroadcastJob, error) {
+	job, err := m.broadcastDatabase.TakeBroadcast(ctx, jobID)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, errs.ErrRecordNotFound.WrapMsg("broadcast job not found", "jobID", jobID)
+		}
+		return nil, err
+	}
+	return job, nil
+}
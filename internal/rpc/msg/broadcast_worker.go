@@ -0,0 +1,238 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"github.com/KyleYe/open-im-tools/utils/encrypt"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+)
+
+// broadcastWorker runs the broadcast jobs one at a time. A job is leased to the worker while it runs,
+// the lease is renewed with every saved batch and a job whose lease expired is taken over by another worker.
+type broadcastWorker struct {
+	db         controller.BroadcastDatabase
+	recipients broadcast.BroadcastRecipientClient
+	send       func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error)
+	config     *config.MsgBroadcast
+	workerID   string
+}
+
+func newBroadcastWorker(db controller.BroadcastDatabase, recipients broadcast.BroadcastRecipientClient,
+	send func(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error), config *config.MsgBroadcast) *broadcastWorker {
+	hostname, _ := os.Hostname()
+	return &broadcastWorker{
+		db:         db,
+		recipients: recipients,
+		send:       send,
+		config:     config,
+		workerID:   fmt.Sprintf("%s_%d_%d", hostname, os.Getpid(), time.Now().UnixNano()),
+	}
+}
+
+func (w *broadcastWorker) lease() time.Duration {
+	return time.Duration(w.config.Lease) * time.Second
+}
+
+// batchSize keeps a batch within half of the lease at the rate of the job,
+// and within one page of recipients so that a short page means the end.
+func (w *broadcastWorker) batchSize(rate int32) int {
+	size := int(float64(rate) * w.lease().Seconds() / 2)
+	if size > w.config.BatchSize {
+		size = w.config.BatchSize
+	}
+	if size > broadcast.MaxRecipientsLimit {
+		size = broadcast.MaxRecipientsLimit
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// Run looks for a job every interval until ctx is done, jobs are run back to back while there are any.
+func (w *broadcastWorker) Run(ctx context.Context) {
+	interval := time.Duration(w.config.Interval) * time.Second
+	for {
+		if !w.runOnce(ctx) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}
+}
+
+// runOnce runs one job, false when there was none or it failed.
+func (w *broadcastWorker) runOnce(ctx context.Context) bool {
+	ctx = mcontext.SetOperationID(ctx, fmt.Sprintf("broadcast_%s_%d", w.workerID, time.Now().UnixMilli()))
+	job, err := w.db.ClaimBroadcast(ctx, w.workerID, time.Now().Add(w.lease()))
+	if err != nil {
+		log.ZError(ctx, "claim broadcast job failed", err, "workerID", w.workerID)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+	log.ZInfo(ctx, "broadcast job claimed", "jobID", job.JobID, "cursor", job.Cursor, "success", job.Success, "failed", job.Failed)
+	if err := w.runJob(ctx, job); err != nil {
+		log.ZError(ctx, "broadcast job interrupted, it is resumed when the lease expires", err, "jobID", job.JobID)
+		return false
+	}
+	return true
+}
+
+func (w *broadcastWorker) runJob(ctx context.Context, job *model.BroadcastJob) error {
+	var msgData sdkws.MsgData
+	if err := proto.Unmarshal(job.MsgData, &msgData); err != nil {
+		return errs.WrapMsg(err, "unmarshal broadcast msgData")
+	}
+	ctx = mcontext.SetOpUserID(ctx, job.OpUserID)
+	limiter := rate.NewLimiter(rate.Limit(job.Rate), 1)
+	size := w.batchSize(job.Rate)
+	cursor := job.Cursor
+	for {
+		recvIDs, err := w.nextRecipients(ctx, job, cursor, size)
+		if err != nil {
+			return err
+		}
+		success, failed, err := w.sendBatch(ctx, limiter, job, &msgData, recvIDs)
+		if err != nil {
+			return err
+		}
+		if len(recvIDs) > 0 {
+			cursor = recvIDs[len(recvIDs)-1]
+		}
+		done := len(recvIDs) < size
+		leased, err := w.db.ProgressBroadcast(ctx, job.JobID, w.workerID, cursor, success, failed, time.Now().Add(w.lease()), done)
+		if err != nil {
+			return err
+		}
+		if !leased {
+			log.ZInfo(ctx, "broadcast job canceled or taken over", "jobID", job.JobID, "cursor", cursor)
+			return nil
+		}
+		if done {
+			log.ZInfo(ctx, "broadcast job completed", "jobID", job.JobID)
+			return nil
+		}
+	}
+}
+
+// nextRecipients returns up to size recipients after cursor in ascending order.
+func (w *broadcastWorker) nextRecipients(ctx context.Context, job *model.BroadcastJob, cursor string, size int) ([]string, error) {
+	if job.TargetType == broadcast.TargetUserIDs {
+		i := sort.Search(len(job.UserIDs), func(i int) bool { return job.UserIDs[i] > cursor })
+		return job.UserIDs[i:min(i+size, len(job.UserIDs))], nil
+	}
+	req := &broadcast.GetBroadcastRecipientsReq{AfterUserID: cursor, Limit: int32(size)}
+	if job.TargetType == broadcast.TargetCreateTime {
+		if !job.CreateTimeBegin.IsZero() {
+			req.CreateTimeBegin = job.CreateTimeBegin.UnixMilli()
+		}
+		if !job.CreateTimeEnd.IsZero() {
+			req.CreateTimeEnd = job.CreateTimeEnd.UnixMilli()
+		}
+	}
+	resp, err := w.recipients.GetBroadcastRecipients(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.UserIDs, nil
+}
+
+// sendBatch sends the message to the recipients and saves every result. The recipients with a saved result
+// were handled before the job was interrupted, they are counted again without sending twice.
+func (w *broadcastWorker) sendBatch(ctx context.Context, limiter *rate.Limiter, job *model.BroadcastJob,
+	msgData *sdkws.MsgData, recvIDs []string) (success int64, failed int64, err error) {
+	if len(recvIDs) == 0 {
+		return 0, 0, nil
+	}
+	saved, err := w.db.FindBroadcastResults(ctx, job.JobID, recvIDs)
+	if err != nil {
+		return 0, 0, err
+	}
+	savedMap := datautil.SliceToMap(saved, func(result *model.BroadcastResult) string {
+		return result.RecvID
+	})
+	for _, recvID := range recvIDs {
+		result, ok := savedMap[recvID]
+		if !ok {
+			if err := limiter.Wait(ctx); err != nil {
+				return 0, 0, errs.Wrap(err)
+			}
+			result = w.sendOne(ctx, job.JobID, msgData, recvID)
+			if err := w.db.SetBroadcastResult(ctx, result); err != nil {
+				return 0, 0, err
+			}
+		}
+		if result.Failed() {
+			failed++
+		} else {
+			success++
+		}
+	}
+	return success, failed, nil
+}
+
+func (w *broadcastWorker) sendOne(ctx context.Context, jobID string, msgData *sdkws.MsgData, recvID string) *model.BroadcastResult {
+	data := proto.Clone(msgData).(*sdkws.MsgData)
+	data.RecvID = recvID
+	data.ClientMsgID = broadcastClientMsgID(jobID, recvID)
+	result := &model.BroadcastResult{
+		JobID:       jobID,
+		RecvID:      recvID,
+		ClientMsgID: data.ClientMsgID,
+		CreateTime:  time.Now(),
+	}
+	resp, err := w.send(ctx, &pbmsg.SendMsgReq{MsgData: data})
+	if err != nil {
+		log.ZWarn(ctx, "broadcast send failed", err, "jobID", jobID, "recvID", recvID)
+		result.ErrCode, result.ErrMsg = errCodeMsg(err)
+		return result
+	}
+	if resp == nil {
+		// The recipient turned off receiving messages, nothing was sent.
+		result.ErrCode, result.ErrMsg = servererrs.MsgNotReceived, servererrs.ErrMsgNotReceived.Msg()
+		return result
+	}
+	result.ServerMsgID = resp.ServerMsgID
+	result.SendTime = resp.SendTime
+	return result
+}
+
+// broadcastClientMsgID is the same for every attempt to send a job to a recipient, so a retry within the
+// idempotency window is not delivered twice.
+func broadcastClientMsgID(jobID string, recvID string) string {
+	return encrypt.Md5(jobID + "-" + recvID)
+}
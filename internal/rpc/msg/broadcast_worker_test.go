@@ -0,0 +1,151 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeBroadcastDatabase struct {
+	controller.BroadcastDatabase
+	job      *model.BroadcastJob
+	results  map[string]*model.BroadcastResult
+	progress int
+	// cancelAfter cancels the job after that many saved batches
+	cancelAfter int
+}
+
+func (f *fakeBroadcastDatabase) ProgressBroadcast(_ context.Context, _ string, workerID string, cursor string, success int64, failed int64, _ time.Time, done bool) (bool, error) {
+	if f.job.Status != model.BroadcastStatusRunning || f.job.WorkerID != workerID {
+		return false, nil
+	}
+	f.job.Cursor = cursor
+	f.job.Success += success
+	f.job.Failed += failed
+	if done {
+		f.job.Status = model.BroadcastStatusCompleted
+	}
+	f.progress++
+	if f.progress == f.cancelAfter {
+		f.job.Status = model.BroadcastStatusCanceled
+	}
+	return true, nil
+}
+
+func (f *fakeBroadcastDatabase) SetBroadcastResult(_ context.Context, result *model.BroadcastResult) error {
+	f.results[result.RecvID] = result
+	return nil
+}
+
+func (f *fakeBroadcastDatabase) FindBroadcastResults(_ context.Context, _ string, recvIDs []string) ([]*model.BroadcastResult, error) {
+	var results []*model.BroadcastResult
+	for _, recvID := range recvIDs {
+		if result, ok := f.results[recvID]; ok {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func newTestBroadcast(t *testing.T, userIDs ...string) (*fakeBroadcastDatabase, *broadcastWorker, *[]string) {
+	msgData, err := proto.Marshal(&sdkws.MsgData{SendID: "admin", SessionType: 1, ContentType: 101, Content: []byte("hi")})
+	assert.NoError(t, err)
+	db := &fakeBroadcastDatabase{
+		job: &model.BroadcastJob{
+			JobID:      "job",
+			MsgData:    msgData,
+			TargetType: broadcast.TargetUserIDs,
+			UserIDs:    userIDs,
+			Rate:       1000,
+			Status:     model.BroadcastStatusRunning,
+		},
+		results: make(map[string]*model.BroadcastResult),
+	}
+	var sent []string
+	send := func(_ context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+		assert.Equal(t, broadcastClientMsgID("job", req.MsgData.RecvID), req.MsgData.ClientMsgID)
+		sent = append(sent, req.MsgData.RecvID)
+		switch req.MsgData.RecvID {
+		case "d":
+			return nil, errs.ErrNoPermission.WrapMsg("blocked")
+		case "m":
+			// m does not receive messages
+			return nil, nil
+		}
+		return &pbmsg.SendMsgResp{ServerMsgID: "s_" + req.MsgData.RecvID, ClientMsgID: req.MsgData.ClientMsgID, SendTime: 1}, nil
+	}
+	worker := newBroadcastWorker(db, nil, send, &config.MsgBroadcast{BatchSize: 2, Lease: 60})
+	db.job.WorkerID = worker.workerID
+	return db, worker, &sent
+}
+
+func TestBroadcastWorkerResume(t *testing.T) {
+	db, worker, sent := newTestBroadcast(t, "a", "b", "c", "d", "e")
+	// b was sent before the job was interrupted
+	db.results["b"] = &model.BroadcastResult{JobID: "job", RecvID: "b", ErrCode: errs.NoPermissionError}
+
+	assert.NoError(t, worker.runJob(context.Background(), db.job))
+	assert.Equal(t, []string{"a", "c", "d", "e"}, *sent)
+	assert.Equal(t, int32(model.BroadcastStatusCompleted), db.job.Status)
+	assert.Equal(t, "e", db.job.Cursor)
+	assert.Equal(t, int64(3), db.job.Success)
+	assert.Equal(t, int64(2), db.job.Failed)
+	assert.Equal(t, "s_a", db.results["a"].ServerMsgID)
+	assert.Equal(t, int32(errs.NoPermissionError), db.results["d"].ErrCode)
+}
+
+func TestBroadcastWorkerNotReceived(t *testing.T) {
+	db, worker, sent := newTestBroadcast(t, "a", "m")
+
+	assert.NoError(t, worker.runJob(context.Background(), db.job))
+	assert.Equal(t, []string{"a", "m"}, *sent)
+	assert.Equal(t, int64(1), db.job.Success)
+	assert.Equal(t, int64(1), db.job.Failed)
+	assert.Equal(t, int32(servererrs.MsgNotReceived), db.results["m"].ErrCode)
+	assert.Empty(t, db.results["m"].ServerMsgID)
+}
+
+func TestBroadcastWorkerCancel(t *testing.T) {
+	db, worker, sent := newTestBroadcast(t, "a", "b", "c", "d", "e")
+	db.cancelAfter = 1
+
+	assert.NoError(t, worker.runJob(context.Background(), db.job))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, *sent)
+	assert.Equal(t, int32(model.BroadcastStatusCanceled), db.job.Status)
+	assert.Equal(t, "b", db.job.Cursor)
+}
+
+func TestBroadcastBatchSize(t *testing.T) {
+	worker := &broadcastWorker{config: &config.MsgBroadcast{BatchSize: 100, Lease: 60}}
+	assert.Equal(t, 100, worker.batchSize(500))
+	assert.Equal(t, 30, worker.batchSize(1))
+	worker.config.BatchSize = 5000
+	assert.Equal(t, broadcast.MaxRecipientsLimit, worker.batchSize(500))
+	worker.config.Lease = 0
+	assert.Equal(t, 1, worker.batchSize(1))
+}
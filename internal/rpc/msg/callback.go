@@ -24,6 +24,8 @@ import (
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	cbapi "github.com/KyleYe/open-im-server/v3/pkg/callbackstruct"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
 	"github.com/KyleYe/open-im-tools/mcontext"
 	"github.com/KyleYe/open-im-tools/utils/datautil"
 	"google.golang.org/protobuf/proto"
@@ -174,6 +176,25 @@ func (m *msgServer) webhookAfterSingleMsgRead(ctx context.Context, after *config
 
 }
 
+func (m *msgServer) webhookBeforeRevokeMsg(ctx context.Context, before *config.BeforeConfig, req *pbchat.RevokeMsgReq, msg *sdkws.MsgData) error {
+	return webhook.WithCondition(ctx, before, func(ctx context.Context) error {
+		cbReq := &cbapi.CallbackBeforeRevokeMsgReq{
+			CallbackCommand: cbapi.CallbackBeforeRevokeMsgCommand,
+			ConversationID:  req.ConversationID,
+			Seq:             req.Seq,
+			UserID:          req.UserID,
+			SendID:          msg.SendID,
+			GroupID:         msg.GroupID,
+			SessionType:     msg.SessionType,
+			ClientMsgID:     msg.ClientMsgID,
+			ServerMsgID:     msg.ServerMsgID,
+			SendTime:        msg.SendTime,
+		}
+		resp := &cbapi.CallbackBeforeRevokeMsgResp{}
+		return m.webhookClient.SyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, resp, before)
+	})
+}
+
 func (m *msgServer) webhookAfterRevokeMsg(ctx context.Context, after *config.AfterConfig, req *pbchat.RevokeMsgReq) {
 	callbackReq := &cbapi.CallbackAfterRevokeMsgReq{
 		CallbackCommand: cbapi.CallbackAfterRevokeMsgCommand,
@@ -183,3 +204,94 @@ func (m *msgServer) webhookAfterRevokeMsg(ctx context.Context, after *config.Aft
 	}
 	m.webhookClient.AsyncPost(ctx, callbackReq.GetCallbackCommand(), callbackReq, &cbapi.CallbackAfterRevokeMsgResp{}, after)
 }
+
+func (m *msgServer) webhookBeforeMsgEdit(ctx context.Context, before *config.BeforeConfig, req *msgedit.EditMsgReq, msg *sdkws.MsgData) error {
+	return webhook.WithCondition(ctx, before, func(ctx context.Context) error {
+		cbReq := &cbapi.CallbackBeforeMsgEditReq{
+			CallbackCommand: cbapi.CallbackBeforeMsgEditCommand,
+			ConversationID:  req.ConversationID,
+			Seq:             req.Seq,
+			UserID:          req.UserID,
+			SendID:          msg.SendID,
+			GroupID:         msg.GroupID,
+			SessionType:     msg.SessionType,
+			ContentType:     msg.ContentType,
+			ClientMsgID:     msg.ClientMsgID,
+			OldContent:      string(msg.Content),
+			Content:         req.Content,
+		}
+		resp := &cbapi.CallbackBeforeMsgEditResp{}
+		if err := m.webhookClient.SyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, resp, before); err != nil {
+			return err
+		}
+		datautil.NotNilReplace(&req.Content, resp.Content)
+		return nil
+	})
+}
+
+func (m *msgServer) webhookAfterMsgEdit(ctx context.Context, after *config.AfterConfig, req *msgedit.EditMsgReq, msg *sdkws.MsgData, editTime int64) {
+	cbReq := &cbapi.CallbackAfterMsgEditReq{
+		CallbackCommand: cbapi.CallbackAfterMsgEditCommand,
+		ConversationID:  req.ConversationID,
+		Seq:             req.Seq,
+		UserID:          req.UserID,
+		SendID:          msg.SendID,
+		GroupID:         msg.GroupID,
+		SessionType:     msg.SessionType,
+		ContentType:     msg.ContentType,
+		ClientMsgID:     msg.ClientMsgID,
+		OldContent:      string(msg.Content),
+		Content:         req.Content,
+		EditTime:        editTime,
+	}
+	m.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterMsgEditResp{}, after)
+}
+
+func (m *msgServer) webhookAfterScheduleMsg(ctx context.Context, after *config.AfterConfig, scheduled *model.ScheduledMsg, msg *sdkws.MsgData) {
+	cbReq := &cbapi.CallbackAfterScheduleMsgReq{
+		CallbackCommand: cbapi.CallbackAfterScheduleMsgCommand,
+		ScheduleID:      scheduled.ScheduleID,
+		SendID:          msg.SendID,
+		RecvID:          msg.RecvID,
+		GroupID:         msg.GroupID,
+		SessionType:     msg.SessionType,
+		ContentType:     msg.ContentType,
+		ClientMsgID:     msg.ClientMsgID,
+		Content:         string(msg.Content),
+		ScheduleTime:    scheduled.ScheduleTime.UnixMilli(),
+	}
+	m.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterScheduleMsgResp{}, after)
+}
+
+func (m *msgServer) webhookAfterScheduledMsgDeliver(ctx context.Context, after *config.AfterConfig, scheduled *model.ScheduledMsg, msg *sdkws.MsgData) {
+	cbReq := &cbapi.CallbackAfterScheduledMsgDeliverReq{
+		CallbackCommand: cbapi.CallbackAfterScheduledMsgDeliverCommand,
+		ScheduleID:      scheduled.ScheduleID,
+		SendID:          msg.SendID,
+		RecvID:          msg.RecvID,
+		GroupID:         msg.GroupID,
+		SessionType:     msg.SessionType,
+		ContentType:     msg.ContentType,
+		ClientMsgID:     msg.ClientMsgID,
+		ServerMsgID:     scheduled.ServerMsgID,
+		ScheduleTime:    scheduled.ScheduleTime.UnixMilli(),
+		DeliverTime:     scheduled.DeliverTime.UnixMilli(),
+		ErrCode:         scheduled.ErrCode,
+		ErrMsg:          scheduled.ErrMsg,
+	}
+	m.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterScheduledMsgDeliverResp{}, after)
+}
+
+func (m *msgServer) webhookAfterCancelScheduledMsg(ctx context.Context, after *config.AfterConfig, scheduled *model.ScheduledMsg, msg *sdkws.MsgData) {
+	cbReq := &cbapi.CallbackAfterCancelScheduledMsgReq{
+		CallbackCommand: cbapi.CallbackAfterCancelScheduledMsgCommand,
+		ScheduleID:      scheduled.ScheduleID,
+		UserID:          mcontext.GetOpUserID(ctx),
+		SendID:          msg.SendID,
+		RecvID:          msg.RecvID,
+		GroupID:         msg.GroupID,
+		ClientMsgID:     msg.ClientMsgID,
+		ScheduleTime:    scheduled.ScheduleTime.UnixMilli(),
+	}
+	m.webhookClient.AsyncPost(ctx, cbReq.GetCallbackCommand(), cbReq, &cbapi.CallbackAfterCancelScheduledMsgResp{}, after)
+}
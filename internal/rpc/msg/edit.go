@@ -0,0 +1,141 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"google.golang.org/protobuf/proto"
+)
+
+// EditMsg replaces the content of a text message. Who can edit a message follows the revoke policy,
+// the edit window is configured separately.
+func (m *msgServer) EditMsg(ctx context.Context, req *msgedit.EditMsgReq) (*msgedit.EditMsgResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, req.UserID, req.ConversationID, []int64{req.Seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.WrapMsg("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, servererrs.ErrMsgAlreadyRevoke.WrapMsg("msg already revoke")
+	}
+	if _, ok := textContentField[msgs[0].ContentType]; !ok {
+		return nil, errs.ErrArgs.WrapMsg("msg content type can not be edited", "contentType", msgs[0].ContentType)
+	}
+	now := time.Now().UnixMilli()
+	var isAdminEdit bool
+	if authverify.IsAppManagerUid(ctx, m.config.Share.IMAdminUserID) {
+		isAdminEdit = true
+	} else {
+		switch msgs[0].SessionType {
+		case constant.SingleChatType:
+			if err := authverify.CheckAccessV3(ctx, msgs[0].SendID, m.config.Share.IMAdminUserID); err != nil {
+				return nil, err
+			}
+		case constant.ReadGroupChatType:
+			members, err := m.GroupLocalCache.GetGroupMemberInfoMap(ctx, msgs[0].GroupID, datautil.Distinct([]string{req.UserID, msgs[0].SendID}))
+			if err != nil {
+				return nil, err
+			}
+			var role int32
+			if member := members[req.UserID]; member != nil {
+				role = member.RoleLevel
+			}
+			senderRole := int32(constant.GroupOrdinaryUsers)
+			if member := members[msgs[0].SendID]; member != nil {
+				senderRole = member.RoleLevel
+			}
+			// The revoke window does not apply, the edit window is checked below.
+			if _, err := groupRevokeMaxAge(&m.config.RpcConfig.Revoke, role, senderRole, req.UserID == msgs[0].SendID); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errs.ErrInternalServer.WrapMsg("msg sessionType not supported")
+		}
+		if maxAge := m.config.RpcConfig.Edit.MaxAge; maxAge > 0 && now-msgs[0].SendTime > maxAge*1000 {
+			return nil, servererrs.ErrMsgEditExpired.WrapMsg("msg is too old to edit", "sendTime", msgs[0].SendTime, "maxAge", maxAge)
+		}
+	}
+	if err := m.webhookBeforeMsgEdit(ctx, &m.config.WebhooksConfig.BeforeMsgEdit, req, msgs[0]); err != nil {
+		return nil, err
+	}
+	// The interceptors check the content the webhook may have replaced.
+	if err := m.interceptEdit(ctx, req, msgs[0]); err != nil {
+		return nil, err
+	}
+	ok, err := m.MsgDatabase.EditMsg(ctx, req.ConversationID, req.Seq, string(msgs[0].Content), req.Content, &model.MsgEditModel{
+		Content: string(msgs[0].Content),
+		UserID:  req.UserID,
+		Time:    now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errs.ErrRecordNotFound.WrapMsg("msg is not stored yet or was modified, try again")
+	}
+	tips := msgedit.MsgEditTips{
+		EditorUserID:   mcontext.GetOpUserID(ctx),
+		ClientMsgID:    msgs[0].ClientMsgID,
+		EditTime:       now,
+		SessionType:    msgs[0].SessionType,
+		Seq:            req.Seq,
+		ConversationID: req.ConversationID,
+		ContentType:    msgs[0].ContentType,
+		Content:        req.Content,
+		IsAdminEdit:    isAdminEdit,
+	}
+	var recvID string
+	if msgs[0].SessionType == constant.ReadGroupChatType {
+		recvID = msgs[0].GroupID
+	} else {
+		recvID = msgs[0].RecvID
+	}
+	m.notificationSender.NotificationWithSessionType(ctx, req.UserID, recvID, msgedit.MsgEditNotification, msgs[0].SessionType, &tips)
+	m.webhookAfterMsgEdit(ctx, &m.config.WebhooksConfig.AfterMsgEdit, req, msgs[0], now)
+	return &msgedit.EditMsgResp{EditTime: now}, nil
+}
+
+// interceptEdit runs the interceptor chain on the message with its new content, a dropped message is rejected.
+func (m *msgServer) interceptEdit(ctx context.Context, req *msgedit.EditMsgReq, msgData *sdkws.MsgData) error {
+	edited := proto.Clone(msgData).(*sdkws.MsgData)
+	edited.Content = []byte(req.Content)
+	sendMsgReq := &pbmsg.SendMsgReq{MsgData: edited}
+	ok, err := m.intercept(ctx, sendMsgReq)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return servererrs.ErrMsgRejected.WrapMsg("msg edit dropped by interceptor")
+	}
+	req.Content = string(sendMsgReq.MsgData.Content)
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
+	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func (f *fakeMsgDatabase) GetMsgBySeqs(_ context.Context, _ string, _ string, seqs []int64) (int64, int64, []*sdkws.MsgData, error) {
+	var msgs []*sdkws.MsgData
+	for _, seq := range seqs {
+		if msg, ok := f.stored[seq]; ok {
+			msgs = append(msgs, proto.Clone(msg).(*sdkws.MsgData))
+		}
+	}
+	return 0, 0, msgs, nil
+}
+
+// EditMsg replaces the content only when it is still oldContent, as the mongo update does.
+func (f *fakeMsgDatabase) EditMsg(_ context.Context, _ string, seq int64, oldContent string, content string, history *model.MsgEditModel) (bool, error) {
+	msg, ok := f.stored[seq]
+	if !ok || string(msg.Content) != oldContent {
+		return false, nil
+	}
+	msg.Content = []byte(content)
+	f.edits[seq] = append(f.edits[seq], history)
+	return true, nil
+}
+
+// newEditMsgServer returns a server whose edit notifications are sent to notified.
+func newEditMsgServer(db *fakeMsgDatabase, maxAge int64) (*msgServer, chan *pbmsg.SendMsgReq) {
+	notified := make(chan *pbmsg.SendMsgReq, 8)
+	m := &msgServer{MsgDatabase: db, config: &Config{}}
+	m.config.Share.IMAdminUserID = []string{"imAdmin"}
+	m.config.RpcConfig.Edit.MaxAge = maxAge
+	m.notificationSender = rpcclient.NewNotificationSender(&config.Notification{},
+		rpcclient.WithLocalSendMsg(func(_ context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+			notified <- req
+			return &pbmsg.SendMsgResp{}, nil
+		}))
+	return m, notified
+}
+
+func storeTextMsg(db *fakeMsgDatabase, seq int64, sendTime time.Time) {
+	db.stored[seq] = &sdkws.MsgData{
+		SendID:      "u1",
+		RecvID:      "u2",
+		ClientMsgID: "c1",
+		Seq:         seq,
+		SessionType: constant.SingleChatType,
+		ContentType: constant.Text,
+		Content:     []byte(`{"content":"hello"}`),
+		SendTime:    sendTime.UnixMilli(),
+	}
+}
+
+func editReq(userID string, seq int64, content string) *msgedit.EditMsgReq {
+	return &msgedit.EditMsgReq{UserID: userID, ConversationID: "si_u1_u2", Seq: seq, Content: content}
+}
+
+func TestEditMsgRecordsHistory(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m, notified := newEditMsgServer(db, 60)
+	storeTextMsg(db, 1, time.Now())
+	ctx := mcontext.WithOpUserIDContext(context.Background(), "u1")
+
+	resp, err := m.EditMsg(ctx, editReq("u1", 1, `{"content":"hello again"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"content":"hello again"}`, string(db.stored[1].Content))
+	resp2, err := m.EditMsg(ctx, editReq("u1", 1, `{"content":"bye"}`))
+	assert.NoError(t, err)
+
+	// Each edit keeps the content it replaced.
+	if assert.Len(t, db.edits[1], 2) {
+		assert.Equal(t, &model.MsgEditModel{Content: `{"content":"hello"}`, UserID: "u1", Time: resp.EditTime}, db.edits[1][0])
+		assert.Equal(t, &model.MsgEditModel{Content: `{"content":"hello again"}`, UserID: "u1", Time: resp2.EditTime}, db.edits[1][1])
+	}
+	select {
+	case req := <-notified:
+		assert.Equal(t, int32(msgedit.MsgEditNotification), req.MsgData.ContentType)
+		assert.Equal(t, "u2", req.MsgData.RecvID)
+	case <-time.After(time.Second):
+		t.Fatal("no edit notification")
+	}
+}
+
+func TestEditMsgRejectsNonSender(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m, _ := newEditMsgServer(db, 60)
+	storeTextMsg(db, 1, time.Now())
+
+	_, err := m.EditMsg(mcontext.WithOpUserIDContext(context.Background(), "u2"), editReq("u2", 1, `{"content":"hacked"}`))
+	assert.True(t, servererrs.ErrNoPermission.Is(err))
+	// Acting for another user is rejected before the message is read.
+	_, err = m.EditMsg(mcontext.WithOpUserIDContext(context.Background(), "u2"), editReq("u1", 1, `{"content":"hacked"}`))
+	assert.True(t, servererrs.ErrNoPermission.Is(err))
+	assert.Equal(t, `{"content":"hello"}`, string(db.stored[1].Content))
+	assert.Empty(t, db.edits)
+}
+
+func TestEditMsgRejectsOutsideWindow(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m, _ := newEditMsgServer(db, 60)
+	storeTextMsg(db, 1, time.Now().Add(-2*time.Minute))
+
+	_, err := m.EditMsg(mcontext.WithOpUserIDContext(context.Background(), "u1"), editReq("u1", 1, `{"content":"late"}`))
+	assert.True(t, servererrs.ErrMsgEditExpired.Is(err))
+	assert.Empty(t, db.edits)
+
+	// The window does not apply to the app managers.
+	_, err = m.EditMsg(mcontext.WithOpUserIDContext(context.Background(), "imAdmin"), editReq("imAdmin", 1, `{"content":"late"}`))
+	assert.NoError(t, err)
+	assert.Len(t, db.edits[1], 1)
+
+	// No window when maxAge is 0.
+	m.config.RpcConfig.Edit.MaxAge = 0
+	_, err = m.EditMsg(mcontext.WithOpUserIDContext(context.Background(), "u1"), editReq("u1", 1, `{"content":"later"}`))
+	assert.NoError(t, err)
+}
+
+func TestEditMsgIntercepted(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m, _ := newEditMsgServer(db, 60)
+	storeTextMsg(db, 1, time.Now())
+	var dropped bool
+	m.addInterceptorHandler(func(_ context.Context, _ *Config, req *pbmsg.SendMsgReq) (*sdkws.MsgData, error) {
+		if dropped {
+			return nil, nil
+		}
+		req.MsgData.Content = []byte(`{"content":"***"}`)
+		return req.MsgData, nil
+	})
+	ctx := mcontext.WithOpUserIDContext(context.Background(), "u1")
+
+	// The content rewritten by the interceptors is the one stored.
+	_, err := m.EditMsg(ctx, editReq("u1", 1, `{"content":"bad"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"content":"***"}`, string(db.stored[1].Content))
+
+	dropped = true
+	_, err = m.EditMsg(ctx, editReq("u1", 1, `{"content":"worse"}`))
+	assert.True(t, servererrs.ErrMsgRejected.Is(err))
+	assert.Len(t, db.edits[1], 1)
+}
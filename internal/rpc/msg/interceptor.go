@@ -0,0 +1,172 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+)
+
+// textContentField is the JSON field holding the user typed text of each text message content type.
+var textContentField = map[int32]string{
+	constant.Text:         "content",
+	constant.AtText:       "text",
+	constant.Quote:        "text",
+	constant.AdvancedText: "text",
+}
+
+// intercept runs the interceptor chain. Each handler may return a rewritten MsgData,
+// an error to reject the message, or nil to drop it, in which case false is returned.
+func (m *msgServer) intercept(ctx context.Context, req *msg.SendMsgReq) (bool, error) {
+	for _, handler := range m.Handlers {
+		msgData, err := handler(ctx, m.config, req)
+		if err != nil {
+			return false, err
+		}
+		if msgData == nil {
+			log.ZInfo(ctx, "msg dropped by interceptor", "clientMsgID", req.MsgData.ClientMsgID, "sendID", req.MsgData.SendID)
+			return false, nil
+		}
+		req.MsgData = msgData
+	}
+	return true, nil
+}
+
+// interceptSend runs the interceptor chain for a send path. A dropped message is answered
+// as if it were sent, with a non-nil response the caller returns as is.
+func (m *msgServer) interceptSend(ctx context.Context, req *msg.SendMsgReq) (*msg.SendMsgResp, error) {
+	ok, err := m.intercept(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+	return &msg.SendMsgResp{
+		ServerMsgID: req.MsgData.ServerMsgID,
+		ClientMsgID: req.MsgData.ClientMsgID,
+		SendTime:    req.MsgData.SendTime,
+	}, nil
+}
+
+// builtinInterceptors returns the built-in interceptors enabled in the configuration.
+func builtinInterceptors(conf *Config) []MessageInterceptorFunc {
+	var handlers []MessageInterceptorFunc
+	interceptor := &conf.RpcConfig.Interceptor
+	if len(interceptor.ContentType.SessionTypes) > 0 {
+		handlers = append(handlers, contentTypeInterceptor)
+	}
+	if len(interceptor.ContentLength.SessionTypes) > 0 && interceptor.ContentLength.Max > 0 {
+		handlers = append(handlers, contentLengthInterceptor)
+	}
+	if len(interceptor.SensitiveWord.SessionTypes) > 0 {
+		if handler := newSensitiveWordInterceptor(interceptor.SensitiveWord.Words); handler != nil {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers
+}
+
+// intercepted reports whether a built-in interceptor configured for sessionTypes applies to the message.
+func intercepted(msgData *sdkws.MsgData, sessionTypes []int32) bool {
+	if msgData.ContentType >= constant.NotificationBegin && msgData.ContentType <= constant.NotificationEnd {
+		return false
+	}
+	return datautil.Contain(msgData.SessionType, sessionTypes...)
+}
+
+func contentTypeInterceptor(_ context.Context, globalConfig *Config, req *msg.SendMsgReq) (*sdkws.MsgData, error) {
+	conf := &globalConfig.RpcConfig.Interceptor.ContentType
+	if !intercepted(req.MsgData, conf.SessionTypes) {
+		return req.MsgData, nil
+	}
+	if !datautil.Contain(req.MsgData.ContentType, conf.Allow...) {
+		return nil, servererrs.ErrMsgRejected.WrapMsg("content type is not allowed", "contentType", req.MsgData.ContentType)
+	}
+	return req.MsgData, nil
+}
+
+func contentLengthInterceptor(_ context.Context, globalConfig *Config, req *msg.SendMsgReq) (*sdkws.MsgData, error) {
+	conf := &globalConfig.RpcConfig.Interceptor.ContentLength
+	if !intercepted(req.MsgData, conf.SessionTypes) {
+		return req.MsgData, nil
+	}
+	if len(req.MsgData.Content) > conf.Max {
+		return nil, servererrs.ErrMsgRejected.WrapMsg("content is too long", "length", len(req.MsgData.Content), "max", conf.Max)
+	}
+	return req.MsgData, nil
+}
+
+// newSensitiveWordInterceptor returns nil when there is no word to look for.
+func newSensitiveWordInterceptor(words []string) MessageInterceptorFunc {
+	quoted := make([]string, 0, len(words))
+	for _, word := range words {
+		if word != "" {
+			quoted = append(quoted, regexp.QuoteMeta(word))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	pattern := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+	return func(ctx context.Context, globalConfig *Config, req *msg.SendMsgReq) (*sdkws.MsgData, error) {
+		conf := &globalConfig.RpcConfig.Interceptor.SensitiveWord
+		if !intercepted(req.MsgData, conf.SessionTypes) {
+			return req.MsgData, nil
+		}
+		field, ok := textContentField[req.MsgData.ContentType]
+		if !ok {
+			return req.MsgData, nil
+		}
+		var content map[string]json.RawMessage
+		if err := json.Unmarshal(req.MsgData.Content, &content); err != nil {
+			return nil, servererrs.ErrArgs.WrapMsg("content is not valid json", "contentType", req.MsgData.ContentType)
+		}
+		var text string
+		if raw, ok := content[field]; !ok || json.Unmarshal(raw, &text) != nil {
+			return req.MsgData, nil
+		}
+		if !pattern.MatchString(text) {
+			return req.MsgData, nil
+		}
+		if conf.Reject {
+			return nil, servererrs.ErrMsgRejected.WrapMsg("content contains sensitive words")
+		}
+		masked, err := json.Marshal(pattern.ReplaceAllStringFunc(text, func(s string) string {
+			return strings.Repeat("*", len([]rune(s)))
+		}))
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		content[field] = masked
+		data, err := json.Marshal(content)
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+		log.ZDebug(ctx, "sensitive words masked", "clientMsgID", req.MsgData.ClientMsgID)
+		req.MsgData.Content = data
+		return req.MsgData, nil
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSensitiveWordInterceptor(t *testing.T) {
+	conf := &Config{}
+	conf.RpcConfig.Interceptor.SensitiveWord.SessionTypes = []int32{constant.SingleChatType}
+	handler := newSensitiveWordInterceptor([]string{"bad", ""})
+
+	req := &msg.SendMsgReq{MsgData: &sdkws.MsgData{
+		SessionType: constant.SingleChatType,
+		ContentType: constant.Text,
+		Content:     []byte(`{"content":"a BAD word"}`),
+	}}
+	msgData, err := handler(context.Background(), conf, req)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"content":"a *** word"}`, string(msgData.Content))
+
+	req.MsgData.SessionType = constant.ReadGroupChatType
+	req.MsgData.Content = []byte(`{"content":"bad"}`)
+	msgData, err = handler(context.Background(), conf, req)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"content":"bad"}`, string(msgData.Content))
+
+	conf.RpcConfig.Interceptor.SensitiveWord.Reject = true
+	req.MsgData.SessionType = constant.SingleChatType
+	_, err = handler(context.Background(), conf, req)
+	assert.Error(t, err)
+
+	assert.Nil(t, newSensitiveWordInterceptor([]string{""}))
+}
+
+func TestInterceptSend(t *testing.T) {
+	var dropped bool
+	m := &msgServer{config: &Config{}}
+	m.addInterceptorHandler(func(_ context.Context, _ *Config, req *msg.SendMsgReq) (*sdkws.MsgData, error) {
+		if dropped {
+			return nil, nil
+		}
+		return req.MsgData, nil
+	})
+	req := &msg.SendMsgReq{MsgData: &sdkws.MsgData{ServerMsgID: "s1", ClientMsgID: "c1", SendTime: 1}}
+
+	resp, err := m.interceptSend(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	dropped = true
+	resp, err = m.interceptSend(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &msg.SendMsgResp{ServerMsgID: "s1", ClientMsgID: "c1", SendTime: 1}, resp)
+
+	m.addInterceptorHandler(contentTypeInterceptor)
+	dropped = false
+	m.config.RpcConfig.Interceptor.ContentType.SessionTypes = []int32{constant.SingleChatType}
+	req.MsgData.SessionType = constant.SingleChatType
+	req.MsgData.ContentType = constant.Picture
+	_, err = m.interceptSend(context.Background(), req)
+	assert.Error(t, err)
+}
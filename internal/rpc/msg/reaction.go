@@ -0,0 +1,207 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/reaction"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mcontext"
+)
+
+// attachedInfoReactionsKey is the key of the reactions in the attachedInfo of pulled messages.
+const attachedInfoReactionsKey = "reactions"
+
+func (m *msgServer) AddReaction(ctx context.Context, req *reaction.AddReactionReq) (*reaction.AddReactionResp, error) {
+	msgData, err := m.getReactionMsg(ctx, req.UserID, req.ConversationID, req.Seq)
+	if err != nil {
+		return nil, err
+	}
+	added, err := m.MsgDatabase.AddMsgReaction(ctx, req.ConversationID, req.Seq,
+		&model.MsgReactionModel{Emoji: req.Emoji, UserID: req.UserID}, m.config.RpcConfig.Reaction.MaxEmojis)
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := m.reactionsChanged(ctx, req.UserID, req.ConversationID, req.Seq, req.Emoji, true, added, msgData)
+	if err != nil {
+		return nil, err
+	}
+	return &reaction.AddReactionResp{Reactions: reactions}, nil
+}
+
+func (m *msgServer) RemoveReaction(ctx context.Context, req *reaction.RemoveReactionReq) (*reaction.RemoveReactionResp, error) {
+	msgData, err := m.getReactionMsg(ctx, req.UserID, req.ConversationID, req.Seq)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := m.MsgDatabase.RemoveMsgReaction(ctx, req.ConversationID, req.Seq, &model.MsgReactionModel{Emoji: req.Emoji, UserID: req.UserID})
+	if err != nil {
+		return nil, err
+	}
+	reactions, err := m.reactionsChanged(ctx, req.UserID, req.ConversationID, req.Seq, req.Emoji, false, removed, msgData)
+	if err != nil {
+		return nil, err
+	}
+	return &reaction.RemoveReactionResp{Reactions: reactions}, nil
+}
+
+// getReactionMsg returns the message to react to, the user must be able to see it.
+func (m *msgServer) getReactionMsg(ctx context.Context, userID string, conversationID string, seq int64) (*sdkws.MsgData, error) {
+	if err := authverify.CheckAccessV3(ctx, userID, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	_, _, msgs, err := m.MsgDatabase.GetMsgBySeqs(ctx, userID, conversationID, []int64{seq})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 || msgs[0] == nil || msgs[0].Status == constant.MsgDeleted {
+		return nil, errs.ErrRecordNotFound.WrapMsg("msg not found")
+	}
+	if msgs[0].ContentType == constant.MsgRevokeNotification {
+		return nil, servererrs.ErrMsgAlreadyRevoke.WrapMsg("msg already revoke")
+	}
+	if msgs[0].ContentType >= constant.NotificationBegin {
+		return nil, errs.ErrArgs.WrapMsg("can not react to a notification", "contentType", msgs[0].ContentType)
+	}
+	switch msgs[0].SessionType {
+	case constant.SingleChatType:
+		if userID != msgs[0].SendID && userID != msgs[0].RecvID {
+			return nil, servererrs.ErrNoPermission.WrapMsg("user is not in the conversation")
+		}
+	case constant.ReadGroupChatType:
+		members, err := m.GroupLocalCache.GetGroupMemberInfoMap(ctx, msgs[0].GroupID, []string{userID})
+		if err != nil {
+			return nil, err
+		}
+		if members[userID] == nil {
+			return nil, servererrs.ErrNotInGroupYet.WrapMsg("user is not in the group", "groupID", msgs[0].GroupID)
+		}
+	default:
+		return nil, errs.ErrArgs.WrapMsg("msg sessionType not supported")
+	}
+	return msgs[0], nil
+}
+
+// reactionsChanged returns the aggregated reactions of the message and notifies the conversation when they changed.
+func (m *msgServer) reactionsChanged(ctx context.Context, userID string, conversationID string, seq int64, emoji string, isAdd bool, changed bool, msgData *sdkws.MsgData) ([]*reaction.ReactionCount, error) {
+	seqReactions, err := m.MsgDatabase.GetMsgReactions(ctx, conversationID, []int64{seq})
+	if err != nil {
+		return nil, err
+	}
+	reactions := countReactions(seqReactions[seq], m.config.RpcConfig.Reaction.MaxReactorIDs)
+	if !changed {
+		return reactions, nil
+	}
+	tips := reaction.MsgReactionTips{
+		OperatorUserID: mcontext.GetOpUserID(ctx),
+		ClientMsgID:    msgData.ClientMsgID,
+		SessionType:    msgData.SessionType,
+		Seq:            seq,
+		ConversationID: conversationID,
+		Emoji:          emoji,
+		IsAdd:          isAdd,
+		Reactions:      reactions,
+	}
+	var recvID string
+	switch {
+	case msgData.SessionType == constant.ReadGroupChatType:
+		recvID = msgData.GroupID
+	case userID == msgData.SendID:
+		recvID = msgData.RecvID
+	default:
+		recvID = msgData.SendID
+	}
+	m.notificationSender.NotificationWithSessionType(ctx, userID, recvID, reaction.MsgReactionNotification, msgData.SessionType, &tips)
+	return reactions, nil
+}
+
+// attachReactions sets the aggregated reactions of the messages as the reactions key of their attachedInfo.
+func (m *msgServer) attachReactions(ctx context.Context, conversationID string, msgs []*sdkws.MsgData) {
+	seqs := make([]int64, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg != nil && msg.Seq > 0 {
+			seqs = append(seqs, msg.Seq)
+		}
+	}
+	if len(seqs) == 0 {
+		return
+	}
+	seqReactions, err := m.MsgDatabase.GetMsgReactions(ctx, conversationID, seqs)
+	if err != nil {
+		log.ZWarn(ctx, "GetMsgReactions error", err, "conversationID", conversationID)
+		return
+	}
+	for _, msg := range msgs {
+		if msg == nil || len(seqReactions[msg.Seq]) == 0 {
+			continue
+		}
+		attachedInfo, err := setAttachedInfoReactions(msg.AttachedInfo, countReactions(seqReactions[msg.Seq], m.config.RpcConfig.Reaction.MaxReactorIDs))
+		if err != nil {
+			log.ZWarn(ctx, "set attachedInfo reactions error", err, "conversationID", conversationID, "seq", msg.Seq)
+			continue
+		}
+		msg.AttachedInfo = attachedInfo
+	}
+}
+
+func setAttachedInfoReactions(attachedInfo string, reactions []*reaction.ReactionCount) (string, error) {
+	info := make(map[string]json.RawMessage)
+	if attachedInfo != "" {
+		if err := json.Unmarshal([]byte(attachedInfo), &info); err != nil {
+			return "", errs.WrapMsg(err, "attachedInfo is not a json object")
+		}
+		if info == nil {
+			info = make(map[string]json.RawMessage)
+		}
+	}
+	data, err := json.Marshal(reactions)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	info[attachedInfoReactionsKey] = data
+	data, err = json.Marshal(info)
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	return string(data), nil
+}
+
+// countReactions aggregates the reactions by emoji in the order the emojis were first used,
+// at most maxReactorIDs userIDs are kept for each emoji when it is positive.
+func countReactions(reactions []*model.MsgReactionModel, maxReactorIDs int) []*reaction.ReactionCount {
+	counts := make([]*reaction.ReactionCount, 0)
+	emojiCount := make(map[string]*reaction.ReactionCount)
+	for _, r := range reactions {
+		count, ok := emojiCount[r.Emoji]
+		if !ok {
+			count = &reaction.ReactionCount{Emoji: r.Emoji, UserIDs: []string{}}
+			emojiCount[r.Emoji] = count
+			counts = append(counts, count)
+		}
+		count.Count++
+		if maxReactorIDs <= 0 || len(count.UserIDs) < maxReactorIDs {
+			count.UserIDs = append(count.UserIDs, r.UserID)
+		}
+	}
+	return counts
+}
@@ -0,0 +1,54 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountReactions(t *testing.T) {
+	reactions := []*model.MsgReactionModel{
+		{Emoji: "👍", UserID: "u1"},
+		{Emoji: "❤️", UserID: "u1"},
+		{Emoji: "👍", UserID: "u2"},
+		{Emoji: "👍", UserID: "u3"},
+	}
+	counts := countReactions(reactions, 2)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, "👍", counts[0].Emoji)
+	assert.Equal(t, int64(3), counts[0].Count)
+	assert.Equal(t, []string{"u1", "u2"}, counts[0].UserIDs)
+	assert.Equal(t, int64(1), counts[1].Count)
+
+	assert.Len(t, countReactions(reactions, 0)[0].UserIDs, 3)
+}
+
+func TestSetAttachedInfoReactions(t *testing.T) {
+	counts := countReactions([]*model.MsgReactionModel{{Emoji: "👍", UserID: "u1"}}, 0)
+
+	info, err := setAttachedInfoReactions("", counts)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"reactions":[{"emoji":"👍","count":1,"userIDs":["u1"]}]}`, info)
+
+	info, err = setAttachedInfoReactions(`{"isPrivateChat":true}`, counts)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"isPrivateChat":true,"reactions":[{"emoji":"👍","count":1,"userIDs":["u1"]}]}`, info)
+
+	_, err = setAttachedInfoReactions("not json", counts)
+	assert.Error(t, err)
+}
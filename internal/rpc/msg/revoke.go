@@ -25,6 +25,7 @@ import (
 	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
@@ -63,10 +64,15 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 	data, _ := json.Marshal(msgs[0])
 	log.ZDebug(ctx, "GetMsgBySeqs", "conversationID", req.ConversationID, "seq", req.Seq, "msg", string(data))
 	var role int32
+	now := time.Now().UnixMilli()
 	if !authverify.IsAppManagerUid(ctx, m.config.Share.IMAdminUserID) {
+		revokeConf := &m.config.RpcConfig.Revoke
 		switch msgs[0].SessionType {
 		case constant.SingleChatType:
-			if err := authverify.CheckAccessV3(ctx, msgs[0].SendID, m.config.Share.IMAdminUserID); err != nil {
+			if mcontext.GetOpUserID(ctx) != msgs[0].SendID {
+				return nil, servererrs.ErrMsgRevokeForbidden.WrapMsg("can only revoke own msg", "sendID", msgs[0].SendID)
+			}
+			if err := checkRevokeAge(msgs[0].SendTime, now, revokeConf.Single.MaxAge); err != nil {
 				return nil, err
 			}
 			role = user.AppMangerLevel
@@ -75,25 +81,27 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 			if err != nil {
 				return nil, err
 			}
-			if req.UserID != msgs[0].SendID {
-				switch members[req.UserID].RoleLevel {
-				case constant.GroupOwner:
-				case constant.GroupAdmin:
-					if members[msgs[0].SendID].RoleLevel != constant.GroupOrdinaryUsers {
-						return nil, errs.ErrNoPermission.WrapMsg("no permission")
-					}
-				default:
-					return nil, errs.ErrNoPermission.WrapMsg("no permission")
-				}
-			}
 			if member := members[req.UserID]; member != nil {
 				role = member.RoleLevel
 			}
+			senderRole := int32(constant.GroupOrdinaryUsers)
+			if member := members[msgs[0].SendID]; member != nil {
+				senderRole = member.RoleLevel
+			}
+			maxAge, err := groupRevokeMaxAge(revokeConf, role, senderRole, req.UserID == msgs[0].SendID)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkRevokeAge(msgs[0].SendTime, now, maxAge); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, errs.ErrInternalServer.WrapMsg("msg sessionType not supported")
 		}
 	}
-	now := time.Now().UnixMilli()
+	if err := m.webhookBeforeRevokeMsg(ctx, &m.config.WebhooksConfig.BeforeRevokeMsg, req, msgs[0]); err != nil {
+		return nil, err
+	}
 	err = m.MsgDatabase.RevokeMsg(ctx, req.ConversationID, req.Seq, &model.RevokeModel{
 		Role:     role,
 		UserID:   req.UserID,
@@ -128,3 +136,32 @@ func (m *msgServer) RevokeMsg(ctx context.Context, req *msg.RevokeMsgReq) (*msg.
 	m.webhookAfterRevokeMsg(ctx, &m.config.WebhooksConfig.AfterRevokeMsg, req)
 	return &msg.RevokeMsgResp{}, nil
 }
+
+// groupRevokeMaxAge checks the revoke policy of group messages and returns the revoke window in seconds.
+// The owner and the admins revoke with the admin window, including their own messages.
+func groupRevokeMaxAge(conf *config.MsgRevoke, revokerRole int32, senderRole int32, self bool) (int64, error) {
+	switch revokerRole {
+	case constant.GroupOwner:
+		return conf.Group.AdminMaxAge, nil
+	case constant.GroupAdmin:
+		if !self && senderRole != constant.GroupOrdinaryUsers {
+			if senderRole == constant.GroupOwner || !conf.Group.AdminRevokeAdmin {
+				return 0, servererrs.ErrMsgRevokeForbidden.WrapMsg("admin can not revoke the msg", "senderRole", senderRole)
+			}
+		}
+		return conf.Group.AdminMaxAge, nil
+	default:
+		if !self {
+			return 0, servererrs.ErrMsgRevokeForbidden.WrapMsg("member can not revoke the msg of others", "revokerRole", revokerRole)
+		}
+		return conf.Group.MaxAge, nil
+	}
+}
+
+// checkRevokeAge sendTime and now are in milliseconds, maxAge is in seconds and 0 means no limit.
+func checkRevokeAge(sendTime int64, now int64, maxAge int64) error {
+	if maxAge > 0 && now-sendTime > maxAge*1000 {
+		return servererrs.ErrMsgRevokeExpired.WrapMsg("msg is too old to revoke", "sendTime", sendTime, "maxAge", maxAge)
+	}
+	return nil
+}
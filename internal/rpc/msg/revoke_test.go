@@ -0,0 +1,58 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupRevokeMaxAge(t *testing.T) {
+	conf := &config.MsgRevoke{}
+	conf.Group.MaxAge = 120
+	conf.Group.AdminMaxAge = 86400
+
+	maxAge, err := groupRevokeMaxAge(conf, constant.GroupOrdinaryUsers, constant.GroupOrdinaryUsers, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), maxAge)
+
+	_, err = groupRevokeMaxAge(conf, constant.GroupOrdinaryUsers, constant.GroupOrdinaryUsers, false)
+	assert.True(t, servererrs.ErrMsgRevokeForbidden.Is(err))
+
+	maxAge, err = groupRevokeMaxAge(conf, constant.GroupAdmin, constant.GroupOrdinaryUsers, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(86400), maxAge)
+
+	_, err = groupRevokeMaxAge(conf, constant.GroupAdmin, constant.GroupAdmin, false)
+	assert.True(t, servererrs.ErrMsgRevokeForbidden.Is(err))
+	conf.Group.AdminRevokeAdmin = true
+	_, err = groupRevokeMaxAge(conf, constant.GroupAdmin, constant.GroupAdmin, false)
+	assert.NoError(t, err)
+	_, err = groupRevokeMaxAge(conf, constant.GroupAdmin, constant.GroupOwner, false)
+	assert.True(t, servererrs.ErrMsgRevokeForbidden.Is(err))
+
+	_, err = groupRevokeMaxAge(conf, constant.GroupOwner, constant.GroupAdmin, false)
+	assert.NoError(t, err)
+}
+
+func TestCheckRevokeAge(t *testing.T) {
+	assert.NoError(t, checkRevokeAge(0, 1000*1000, 0))
+	assert.NoError(t, checkRevokeAge(1000, 61*1000, 60))
+	assert.True(t, servererrs.ErrMsgRevokeExpired.Is(checkRevokeAge(1000, 62*1000, 60)))
+}
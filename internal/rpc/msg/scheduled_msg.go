@@ -0,0 +1,220 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/convert"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/encrypt"
+	"github.com/KyleYe/open-im-tools/utils/idutil"
+	"google.golang.org/protobuf/proto"
+)
+
+func (m *msgServer) ScheduleSendMsg(ctx context.Context, req *scheduledmsg.ScheduleSendMsgReq) (*scheduledmsg.ScheduleSendMsgResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.MsgData.SendID, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	switch req.MsgData.SessionType {
+	case constant.SingleChatType, constant.ReadGroupChatType, constant.NotificationChatType:
+	default:
+		return nil, errs.ErrArgs.WrapMsg("unknown sessionType")
+	}
+	now := time.Now()
+	if err := checkScheduleTime(m.config.RpcConfig.Schedule.MaxDelay, now, req.ScheduleTime); err != nil {
+		return nil, err
+	}
+	if maxPending := m.config.RpcConfig.Schedule.MaxPending; maxPending > 0 {
+		pending, err := m.scheduledMsgDatabase.CountPendingScheduledMsgs(ctx, req.MsgData.SendID)
+		if err != nil {
+			return nil, err
+		}
+		if pending >= maxPending {
+			return nil, errs.ErrArgs.WrapMsg("too many scheduled messages", "sendID", req.MsgData.SendID, "maxPending", maxPending)
+		}
+	}
+	if req.MsgData.ClientMsgID == "" {
+		req.MsgData.ClientMsgID = idutil.GetMsgIDByMD5(req.MsgData.SendID)
+	}
+	// The send time is set when the message is sent.
+	req.MsgData.SendTime = 0
+	data, err := proto.Marshal(req.MsgData)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "marshal scheduled msgData")
+	}
+	msg := &model.ScheduledMsg{
+		ScheduleID:   encrypt.Md5(mcontext.GetOperationID(ctx) + "-" + idutil.OperationIDGenerator()),
+		SendID:       req.MsgData.SendID,
+		MsgData:      data,
+		ScheduleTime: time.UnixMilli(req.ScheduleTime),
+		Status:       model.ScheduledMsgStatusPending,
+		CreateTime:   now,
+		UpdateTime:   now,
+	}
+	if err := m.scheduledMsgDatabase.CreateScheduledMsg(ctx, msg); err != nil {
+		return nil, err
+	}
+	m.webhookAfterScheduleMsg(ctx, &m.config.WebhooksConfig.AfterScheduleMsg, msg, req.MsgData)
+	return &scheduledmsg.ScheduleSendMsgResp{ScheduleID: msg.ScheduleID}, nil
+}
+
+func (m *msgServer) CancelScheduledMsg(ctx context.Context, req *scheduledmsg.CancelScheduledMsgReq) (*scheduledmsg.CancelScheduledMsgResp, error) {
+	msg, err := m.scheduledMsgDatabase.TakeScheduledMsg(ctx, req.ScheduleID)
+	if err != nil {
+		if IsNotFound(err) {
+			return nil, errs.ErrRecordNotFound.WrapMsg("scheduled message not found", "scheduleID", req.ScheduleID)
+		}
+		return nil, err
+	}
+	if err := authverify.CheckAccessV3(ctx, msg.SendID, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if msg.Status == model.ScheduledMsgStatusCanceled {
+		return &scheduledmsg.CancelScheduledMsgResp{}, nil
+	}
+	canceled, err := m.scheduledMsgDatabase.CancelScheduledMsg(ctx, req.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if !canceled {
+		return nil, errs.ErrArgs.WrapMsg("scheduled message is already sent", "scheduleID", req.ScheduleID)
+	}
+	msgData, err := convert.ScheduledMsgData(msg)
+	if err != nil {
+		return nil, err
+	}
+	m.webhookAfterCancelScheduledMsg(ctx, &m.config.WebhooksConfig.AfterCancelScheduledMsg, msg, msgData)
+	return &scheduledmsg.CancelScheduledMsgResp{}, nil
+}
+
+func (m *msgServer) GetScheduledMsgs(ctx context.Context, req *scheduledmsg.GetScheduledMsgsReq) (*scheduledmsg.GetScheduledMsgsResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	total, msgs, err := m.scheduledMsgDatabase.PageScheduledMsgs(ctx, req.UserID, req.Status, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	pbs, err := convert.ScheduledMsgsDB2Pb(msgs)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduledmsg.GetScheduledMsgsResp{Total: total, Msgs: pbs}, nil
+}
+
+func (m *msgServer) DispatchScheduledMsgs(ctx context.Context, req *scheduledmsg.DispatchScheduledMsgsReq) (*scheduledmsg.DispatchScheduledMsgsResp, error) {
+	if err := authverify.CheckAdmin(ctx, m.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	conf := &m.config.RpcConfig.Schedule
+	resp := &scheduledmsg.DispatchScheduledMsgsResp{}
+	for i := 0; conf.DispatchBatch <= 0 || i < conf.DispatchBatch; i++ {
+		now := time.Now()
+		msg, err := m.scheduledMsgDatabase.ClaimDueScheduledMsg(ctx, now, now.Add(time.Duration(conf.Lease)*time.Second))
+		if err != nil {
+			return nil, err
+		}
+		if msg == nil {
+			break
+		}
+		sent, err := m.deliverScheduledMsg(ctx, msg)
+		if err != nil {
+			log.ZError(ctx, "deliver scheduled msg failed, it is claimed again when the lease expires", err, "scheduleID", msg.ScheduleID, "sendID", msg.SendID)
+			continue
+		}
+		if sent {
+			resp.Sent++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// deliverScheduledMsg sends a claimed message as its sender, so the blacklist, mute and group membership are
+// checked at delivery time. A rejected message is saved as failed with the reason. Other errors are returned
+// and leave the message sending, so it is claimed again when the lease expires.
+func (m *msgServer) deliverScheduledMsg(ctx context.Context, msg *model.ScheduledMsg) (bool, error) {
+	msgData, err := convert.ScheduledMsgData(msg)
+	if err != nil {
+		// The saved message can't be read, it never gets sent.
+		errCode, errMsg := errCodeMsg(err)
+		return false, m.scheduledMsgDatabase.FinishScheduledMsg(ctx, msg.ScheduleID, model.ScheduledMsgStatusFailed, "", errCode, errMsg, time.Now())
+	}
+	resp, err := m.SendMsg(mcontext.SetOpUserID(ctx, msg.SendID), &pbmsg.SendMsgReq{MsgData: msgData})
+	outcome, err := scheduledMsgOutcome(resp, err)
+	if err != nil {
+		return false, err
+	}
+	status, serverMsgID, errCode, errMsg := outcome.Status, outcome.ServerMsgID, outcome.ErrCode, outcome.ErrMsg
+	if status == model.ScheduledMsgStatusFailed {
+		log.ZWarn(ctx, "scheduled msg rejected", nil, "scheduleID", msg.ScheduleID, "sendID", msg.SendID, "errCode", errCode, "errMsg", errMsg)
+	}
+	deliverTime := time.Now()
+	if err := m.scheduledMsgDatabase.FinishScheduledMsg(ctx, msg.ScheduleID, status, serverMsgID, errCode, errMsg, deliverTime); err != nil {
+		return false, err
+	}
+	msg.Status, msg.ServerMsgID, msg.ErrCode, msg.ErrMsg, msg.DeliverTime = status, serverMsgID, errCode, errMsg, deliverTime
+	m.webhookAfterScheduledMsgDeliver(ctx, &m.config.WebhooksConfig.AfterScheduledMsgDeliver, msg, msgData)
+	return status == model.ScheduledMsgStatusSent, nil
+}
+
+// scheduledMsgOutcome returns the status, server msg id and error of a sent scheduled message. A send that
+// is not rejected for good returns its error, the message is sent again.
+func scheduledMsgOutcome(resp *pbmsg.SendMsgResp, err error) (*model.ScheduledMsg, error) {
+	if err != nil {
+		errCode, errMsg := errCodeMsg(err)
+		if !isScheduledMsgRejected(errCode) {
+			return nil, err
+		}
+		return &model.ScheduledMsg{Status: model.ScheduledMsgStatusFailed, ErrCode: errCode, ErrMsg: errMsg}, nil
+	}
+	if resp == nil {
+		// The recipient turned off receiving messages, nothing was sent.
+		return &model.ScheduledMsg{Status: model.ScheduledMsgStatusFailed, ErrCode: servererrs.MsgNotReceived, ErrMsg: servererrs.ErrMsgNotReceived.Msg()}, nil
+	}
+	return &model.ScheduledMsg{Status: model.ScheduledMsgStatusSent, ServerMsgID: resp.ServerMsgID}, nil
+}
+
+// isScheduledMsgRejected reports whether a send error rejects the message for good, e.g. the sender is muted,
+// blocked or no longer allowed to send. Internal, database and network errors don't.
+func isScheduledMsgRejected(errCode int32) bool {
+	switch errCode {
+	case errs.ServerInternalError, servererrs.DatabaseError, servererrs.NetworkError, servererrs.MsgSending:
+		return false
+	default:
+		return true
+	}
+}
+
+func checkScheduleTime(maxDelay int64, now time.Time, scheduleTime int64) error {
+	if scheduleTime <= now.UnixMilli() {
+		return errs.ErrArgs.WrapMsg("scheduleTime must be in the future", "scheduleTime", scheduleTime)
+	}
+	if maxDelay > 0 && scheduleTime > now.Add(time.Duration(maxDelay)*time.Second).UnixMilli() {
+		return errs.ErrArgs.WrapMsg("scheduleTime is too far in the future", "scheduleTime", scheduleTime, "maxDelay", maxDelay)
+	}
+	return nil
+}
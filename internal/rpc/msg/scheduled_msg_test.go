@@ -0,0 +1,80 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"testing"
+	"time"
+
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckScheduleTime(t *testing.T) {
+	now := time.UnixMilli(1_700_000_000_000)
+	assert.NoError(t, checkScheduleTime(60, now, now.UnixMilli()+1000))
+	assert.NoError(t, checkScheduleTime(60, now, now.Add(time.Minute).UnixMilli()))
+	assert.NoError(t, checkScheduleTime(0, now, now.Add(24*365*time.Hour).UnixMilli()))
+
+	assert.True(t, errs.ErrArgs.Is(checkScheduleTime(60, now, now.UnixMilli())))
+	assert.True(t, errs.ErrArgs.Is(checkScheduleTime(60, now, now.UnixMilli()-1000)))
+	assert.True(t, errs.ErrArgs.Is(checkScheduleTime(60, now, now.Add(time.Minute).UnixMilli()+1)))
+}
+
+func TestIsScheduledMsgRejected(t *testing.T) {
+	for _, err := range []error{
+		errs.ErrNoPermission.WrapMsg("not allowed"),
+		servererrs.ErrBlockedByPeer.WrapMsg("blocked"),
+		servererrs.ErrMutedInGroup.WrapMsg("muted"),
+		servererrs.ErrNotInGroupYet.WrapMsg("not in group"),
+	} {
+		code, _ := errCodeMsg(err)
+		assert.True(t, isScheduledMsgRejected(code), err)
+	}
+	for _, err := range []error{
+		errs.New("connection refused").Wrap(),
+		errs.ErrInternalServer.WrapMsg("internal"),
+		servererrs.ErrDatabase.WrapMsg("timeout"),
+		servererrs.ErrNetwork.WrapMsg("unavailable"),
+		servererrs.ErrMsgSending.WrapMsg("sending"),
+	} {
+		code, _ := errCodeMsg(err)
+		assert.False(t, isScheduledMsgRejected(code), err)
+	}
+}
+
+func TestScheduledMsgOutcome(t *testing.T) {
+	outcome, err := scheduledMsgOutcome(&pbmsg.SendMsgResp{ServerMsgID: "s1"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &model.ScheduledMsg{Status: model.ScheduledMsgStatusSent, ServerMsgID: "s1"}, outcome)
+
+	// The recipient does not receive messages, SendMsg answers without a response.
+	outcome, err = scheduledMsgOutcome(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(model.ScheduledMsgStatusFailed), outcome.Status)
+	assert.Equal(t, int32(servererrs.MsgNotReceived), outcome.ErrCode)
+	assert.Empty(t, outcome.ServerMsgID)
+
+	outcome, err = scheduledMsgOutcome(nil, servererrs.ErrBlockedByPeer.WrapMsg("blocked"))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(model.ScheduledMsgStatusFailed), outcome.Status)
+	assert.Equal(t, int32(servererrs.BlockedByPeer), outcome.ErrCode)
+
+	_, err = scheduledMsgOutcome(nil, servererrs.ErrNetwork.WrapMsg("unavailable"))
+	assert.True(t, servererrs.ErrNetwork.Is(err))
+}
@@ -16,6 +16,7 @@ package msg
 
 import (
 	"context"
+	"time"
 
 	"github.com/KyleYe/open-im-protocol/constant"
 	pbconversation "github.com/KyleYe/open-im-protocol/conversation"
@@ -23,6 +24,7 @@ import (
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-protocol/wrapperspb"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/msgprocessor"
 	"github.com/KyleYe/open-im-server/v3/pkg/util/conversationutil"
 	"github.com/KyleYe/open-im-tools/errs"
@@ -32,7 +34,48 @@ import (
 	"github.com/KyleYe/open-im-tools/utils/stringutil"
 )
 
+// sendMsgInFlightExpire bounds the reservation of a send in progress, so that a send whose response was never
+// stored, because the process died in between, does not block the retries for the whole window.
+const sendMsgInFlightExpire = 30 * time.Second
+
 func (m *msgServer) SendMsg(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+	if req.MsgData != nil && req.MsgData.ClientMsgID != "" && m.config.RpcConfig.Idempotency.Window > 0 {
+		return m.sendMsgIdempotent(ctx, req)
+	}
+	return m.sendMsg(ctx, req)
+}
+
+// sendMsgIdempotent sends the message once per (sendID, clientMsgID) within the idempotency window,
+// retries get the response of the first send instead of a second copy.
+func (m *msgServer) sendMsgIdempotent(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
+	sendID, clientMsgID := req.MsgData.SendID, req.MsgData.ClientMsgID
+	expire := time.Duration(m.config.RpcConfig.Idempotency.Window) * time.Second
+	reserved, resp, err := m.MsgDatabase.ReserveSendMsg(ctx, sendID, clientMsgID, min(expire, sendMsgInFlightExpire))
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		if resp == nil {
+			return nil, servererrs.ErrMsgSending.WrapMsg("message is being sent", "sendID", sendID, "clientMsgID", clientMsgID)
+		}
+		log.ZInfo(ctx, "duplicate msg, return the first send resp", "sendID", sendID, "clientMsgID", clientMsgID, "serverMsgID", resp.ServerMsgID)
+		return resp, nil
+	}
+	resp, err = m.sendMsg(ctx, req)
+	if err != nil || resp == nil {
+		// Nothing was sent, let the retry through.
+		if err := m.MsgDatabase.DeleteSendMsgReservation(ctx, sendID, clientMsgID); err != nil {
+			log.ZWarn(ctx, "DeleteSendMsgReservation", err, "sendID", sendID, "clientMsgID", clientMsgID)
+		}
+		return resp, err
+	}
+	if err := m.MsgDatabase.SetSendMsgResp(ctx, sendID, clientMsgID, resp, expire); err != nil {
+		log.ZWarn(ctx, "SetSendMsgResp", err, "sendID", sendID, "clientMsgID", clientMsgID)
+	}
+	return resp, nil
+}
+
+func (m *msgServer) sendMsg(ctx context.Context, req *pbmsg.SendMsgReq) (*pbmsg.SendMsgResp, error) {
 	if req.MsgData != nil {
 		m.encapsulateMsgData(req.MsgData)
 		switch req.MsgData.SessionType {
@@ -54,13 +97,19 @@ func (m *msgServer) sendMsgGroupChat(ctx context.Context, req *pbmsg.SendMsgReq)
 		prommetrics.GroupChatMsgProcessFailedCounter.Inc()
 		return nil, err
 	}
-
 	if err = m.webhookBeforeSendGroupMsg(ctx, &m.config.WebhooksConfig.BeforeSendGroupMsg, req); err != nil {
 		return nil, err
 	}
 	if err := m.webhookBeforeMsgModify(ctx, &m.config.WebhooksConfig.BeforeMsgModify, req); err != nil {
 		return nil, err
 	}
+	// The interceptors check the content the webhooks may have modified.
+	if resp, err := m.interceptSend(ctx, req); err != nil {
+		prommetrics.GroupChatMsgProcessFailedCounter.Inc()
+		return nil, err
+	} else if resp != nil {
+		return resp, nil
+	}
 	err = m.MsgDatabase.MsgToMQ(ctx, conversationutil.GenConversationUniqueKeyForGroup(req.MsgData.GroupID), req.MsgData)
 	if err != nil {
 		return nil, err
@@ -120,6 +169,9 @@ func (m *msgServer) setConversationAtInfo(nctx context.Context, msg *sdkws.MsgDa
 }
 
 func (m *msgServer) sendMsgNotification(ctx context.Context, req *pbmsg.SendMsgReq) (resp *pbmsg.SendMsgResp, err error) {
+	if resp, err := m.interceptSend(ctx, req); err != nil || resp != nil {
+		return resp, err
+	}
 	if err := m.MsgDatabase.MsgToMQ(ctx, conversationutil.GenConversationUniqueKeyForSingle(req.MsgData.SendID, req.MsgData.RecvID), req.MsgData); err != nil {
 		return nil, err
 	}
@@ -159,6 +211,13 @@ func (m *msgServer) sendMsgSingleChat(ctx context.Context, req *pbmsg.SendMsgReq
 		if err := m.webhookBeforeMsgModify(ctx, &m.config.WebhooksConfig.BeforeMsgModify, req); err != nil {
 			return nil, err
 		}
+		// The interceptors check the content the webhooks may have modified.
+		if resp, err := m.interceptSend(ctx, req); err != nil {
+			prommetrics.SingleChatMsgProcessFailedCounter.Inc()
+			return nil, err
+		} else if resp != nil {
+			return resp, nil
+		}
 
 		if err := m.MsgDatabase.MsgToMQ(ctx, conversationutil.GenConversationUniqueKeyForSingle(req.MsgData.SendID, req.MsgData.RecvID), req.MsgData); err != nil {
 			prommetrics.SingleChatMsgProcessFailedCounter.Inc()
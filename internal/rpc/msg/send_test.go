@@ -0,0 +1,184 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeMsgDatabase keeps the messages handed to the MQ, the stored messages and the idempotency reservations
+// in memory, the methods the tests do not reach panic.
+type fakeMsgDatabase struct {
+	controller.CommonMsgDatabase
+	inserted     []*sdkws.MsgData
+	insertErr    error
+	reservations map[string]*pbmsg.SendMsgResp
+	expires      map[string]time.Duration
+	stored       map[int64]*sdkws.MsgData
+	edits        map[int64][]*model.MsgEditModel
+}
+
+func newFakeMsgDatabase() *fakeMsgDatabase {
+	return &fakeMsgDatabase{
+		reservations: make(map[string]*pbmsg.SendMsgResp),
+		expires:      make(map[string]time.Duration),
+		stored:       make(map[int64]*sdkws.MsgData),
+		edits:        make(map[int64][]*model.MsgEditModel),
+	}
+}
+
+func (f *fakeMsgDatabase) MsgToMQ(_ context.Context, _ string, msg *sdkws.MsgData) error {
+	if f.insertErr != nil {
+		return f.insertErr
+	}
+	f.inserted = append(f.inserted, proto.Clone(msg).(*sdkws.MsgData))
+	return nil
+}
+
+func (f *fakeMsgDatabase) ReserveSendMsg(_ context.Context, sendID string, clientMsgID string, expire time.Duration) (bool, *pbmsg.SendMsgResp, error) {
+	key := sendID + ":" + clientMsgID
+	if resp, ok := f.reservations[key]; ok {
+		return false, resp, nil
+	}
+	f.reservations[key] = nil
+	f.expires[key] = expire
+	return true, nil, nil
+}
+
+func (f *fakeMsgDatabase) SetSendMsgResp(_ context.Context, sendID string, clientMsgID string, resp *pbmsg.SendMsgResp, expire time.Duration) error {
+	f.reservations[sendID+":"+clientMsgID] = resp
+	f.expires[sendID+":"+clientMsgID] = expire
+	return nil
+}
+
+func (f *fakeMsgDatabase) DeleteSendMsgReservation(_ context.Context, sendID string, clientMsgID string) error {
+	delete(f.reservations, sendID+":"+clientMsgID)
+	return nil
+}
+
+func newIdempotentMsgServer(db controller.CommonMsgDatabase) *msgServer {
+	m := &msgServer{MsgDatabase: db, config: &Config{}}
+	m.config.RpcConfig.Idempotency.Window = 60
+	return m
+}
+
+func notificationReq(clientMsgID string) *pbmsg.SendMsgReq {
+	return &pbmsg.SendMsgReq{MsgData: &sdkws.MsgData{
+		SendID:      "u1",
+		RecvID:      "u2",
+		ClientMsgID: clientMsgID,
+		SessionType: constant.NotificationChatType,
+		ContentType: constant.Custom,
+	}}
+}
+
+func TestSendMsgIdempotentDuplicate(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m := newIdempotentMsgServer(db)
+
+	first, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.ServerMsgID)
+	assert.Len(t, db.inserted, 1)
+
+	// The resent message gets the response of the first send and is not inserted again.
+	resent, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.NoError(t, err)
+	assert.Equal(t, first.ServerMsgID, resent.ServerMsgID)
+	assert.Equal(t, first.SendTime, resent.SendTime)
+	assert.Equal(t, "c1", resent.ClientMsgID)
+	assert.Len(t, db.inserted, 1)
+
+	other, err := m.SendMsg(context.Background(), notificationReq("c2"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.ServerMsgID, other.ServerMsgID)
+	assert.Len(t, db.inserted, 2)
+}
+
+func TestSendMsgIdempotentInProgress(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m := newIdempotentMsgServer(db)
+	db.reservations["u1:c1"] = nil
+
+	_, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.True(t, servererrs.ErrMsgSending.Is(err))
+	assert.Empty(t, db.inserted)
+}
+
+func TestSendMsgIdempotentExpire(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m := newIdempotentMsgServer(db)
+	db.insertErr = errors.New("kafka down")
+
+	// A send that did not complete holds the reservation only for the in-flight expiration.
+	_, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.Error(t, err)
+	assert.Equal(t, sendMsgInFlightExpire, db.expires["u1:c1"])
+
+	// The stored response is kept for the whole window.
+	db.insertErr = nil
+	_, err = m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, db.expires["u1:c1"])
+
+	// A window shorter than the in-flight expiration bounds the reservation too.
+	m.config.RpcConfig.Idempotency.Window = 10
+	db.insertErr = errors.New("kafka down")
+	_, err = m.SendMsg(context.Background(), notificationReq("c2"))
+	assert.Error(t, err)
+	assert.Equal(t, 10*time.Second, db.expires["u1:c2"])
+}
+
+func TestSendMsgIdempotentFailure(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m := newIdempotentMsgServer(db)
+	db.insertErr = errors.New("kafka down")
+
+	_, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.Error(t, err)
+	assert.NotContains(t, db.reservations, "u1:c1")
+
+	// Nothing was sent, the retry goes through.
+	db.insertErr = nil
+	resp, err := m.SendMsg(context.Background(), notificationReq("c1"))
+	assert.NoError(t, err)
+	assert.Equal(t, resp, db.reservations["u1:c1"])
+	assert.Len(t, db.inserted, 1)
+}
+
+func TestSendMsgWithoutIdempotency(t *testing.T) {
+	db := newFakeMsgDatabase()
+	m := newIdempotentMsgServer(db)
+	m.config.RpcConfig.Idempotency.Window = 0
+
+	for i := 0; i < 2; i++ {
+		_, err := m.SendMsg(context.Background(), notificationReq("c1"))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, db.inserted, 2)
+	assert.Empty(t, db.reservations)
+}
@@ -29,6 +29,10 @@ import (
 	"github.com/KyleYe/open-im-protocol/conversation"
 	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/reaction"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpccache"
 	"github.com/KyleYe/open-im-server/v3/pkg/rpcclient"
 	"github.com/KyleYe/open-im-tools/discovery"
@@ -54,6 +58,8 @@ type (
 		msgNotificationSender  *MsgNotificationSender           // RPC client for sending msg notifications.
 		config                 *Config                          // Global configuration settings.
 		webhookClient          *webhook.Client
+		broadcastDatabase      controller.BroadcastDatabase
+		scheduledMsgDatabase   controller.ScheduledMsgDatabase
 	}
 
 	Config struct {
@@ -106,6 +112,14 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	if err != nil {
 		return err
 	}
+	broadcastDB, err := mgo.NewBroadcastMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
+	scheduledMsgDB, err := mgo.NewScheduledMsgMongo(mgocli.GetDB())
+	if err != nil {
+		return err
+	}
 	s := &msgServer{
 		Conversation:           &conversationClient,
 		MsgDatabase:            msgDatabase,
@@ -116,12 +130,24 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		FriendLocalCache:       rpccache.NewFriendLocalCache(friendRpcClient, &config.LocalCacheConfig, rdb),
 		config:                 config,
 		webhookClient:          webhook.NewWebhookClient(config.WebhooksConfig.URL),
+		broadcastDatabase:      controller.NewBroadcastDatabase(broadcastDB),
+		scheduledMsgDatabase:   controller.NewScheduledMsgDatabase(scheduledMsgDB),
 	}
 
+	s.addInterceptorHandler(builtinInterceptors(config)...)
 	s.notificationSender = rpcclient.NewNotificationSender(&config.NotificationConfig, rpcclient.WithLocalSendMsg(s.SendMsg))
 	s.msgNotificationSender = NewMsgNotificationSender(config, rpcclient.WithLocalSendMsg(s.SendMsg))
 
 	msg.RegisterMsgServer(server, s)
+	msgedit.RegisterMsgEditServer(server, s)
+	reaction.RegisterReactionServer(server, s)
+	broadcast.RegisterBroadcastServer(server, s)
+	scheduledmsg.RegisterScheduledMsgServer(server, s)
+
+	if config.RpcConfig.Broadcast.Enable {
+		worker := newBroadcastWorker(s.broadcastDatabase, userRpcClient.BroadcastClient, s.SendMsg, &config.RpcConfig.Broadcast)
+		go worker.Run(ctx)
+	}
 
 	return nil
 }
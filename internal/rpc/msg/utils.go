@@ -16,6 +16,7 @@ package msg
 
 import (
 	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/mw/specialerror"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -28,3 +29,11 @@ func IsNotFound(err error) bool {
 		return false
 	}
 }
+
+// errCodeMsg returns the code and message a client gets for err.
+func errCodeMsg(err error) (int32, string) {
+	if codeErr := specialerror.ErrCode(errs.Unwrap(err)); codeErr != nil {
+		return int32(codeErr.Code()), codeErr.Msg()
+	}
+	return errs.ServerInternalError, err.Error()
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
 	"github.com/KyleYe/open-im-server/v3/pkg/localcache"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/location"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 	"github.com/KyleYe/open-im-tools/db/redisutil"
 
 	"github.com/KyleYe/open-im-protocol/constant"
@@ -105,7 +107,7 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 	localcache.InitLocalCache(&config.LocalCacheConfig)
 
 	// Register Friend server with refactored MongoDB and Redis integrations
-	relation.RegisterFriendServer(server, &friendServer{
+	srv := &friendServer{
 		db: controller.NewFriendDatabase(
 			friendMongoDB,
 			friendRequestMongoDB,
@@ -123,7 +125,9 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		config:                config,
 		webhookClient:         webhook.NewWebhookClient(config.WebhooksConfig.URL),
 		queue:                 memamq.NewMemoryQueue(128, 1024*8),
-	})
+	}
+	relation.RegisterFriendServer(server, srv)
+	versionlog.RegisterVersionLogServer(server, srv)
 	return nil
 }
 
@@ -505,28 +509,26 @@ func (s *friendServer) GetNearbyFriends(
 	if err := s.userRpcClient.Access(ctx, req.UserID); err != nil {
 		return nil, err
 	}
-	_, friends, err := s.db.PageOwnerFriends(ctx, req.UserID, req.Pagination)
+	nearby, err := s.userRpcClient.LocationClient.GetNearbyUsers(ctx, &location.GetNearbyUsersReq{
+		Pagination: req.Pagination,
+		UserID:     req.UserID,
+		Latitude:   req.Latitude,
+		Longitude:  req.Longitude,
+		Scope:      location.NearbyScope_Friends,
+	})
 	if err != nil {
 		return nil, err
 	}
-	var n_friends []*model.Friend
-	for i := 0; i < len(friends); i++ {
-		_, nf, _ := s.db.PageOwnerFriends(ctx, friends[i].FriendUserID, &sdkws.RequestPagination{PageNumber: 1, ShowNumber: 100})
-		for j := 0; j < len(nf); j++ {
-			if nf[j].FriendUserID == req.UserID || nf[j].OwnerUserID == req.UserID {
-				continue
-			} else {
-				n_friends = append(n_friends, nf[j])
-			}
-		}
-	}
-	resp = &pbfriend.GetNearbyFriendsResp{}
-	// resp.FriendsInfo, err = convert.NearbyFriendsDB2Pb(ctx, req.Latitude, req.Longitude, friends, s.userRpcClient.GetUsersInfoMap)
-	resp.FriendsInfo, err = convert.NearbyFriendsDB2Pb(ctx, req.Latitude, req.Longitude, n_friends, s.userRpcClient.GetUsersInfoMap)
+	users := datautil.Slice(nearby.Users, func(e *location.NearbyUser) *sdkws.UserInfo {
+		return e.User
+	})
+	friends, err := s.db.FindFriendsWithError(ctx, req.UserID, datautil.Slice(users, func(e *sdkws.UserInfo) string {
+		return e.UserID
+	}))
 	if err != nil {
 		return nil, err
 	}
-	// resp.Total = int32(total)
-	resp.Total = int32(len(resp.FriendsInfo))
+	resp = &pbfriend.GetNearbyFriendsResp{Total: nearby.Total}
+	resp.FriendsInfo = convert.NearbyFriendsDB2Pb(friends, users)
 	return resp, nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/internal/rpc/incrversion"
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 )
 
 func (s *friendServer) NotificationUserInfoUpdate(ctx context.Context, req *relation.NotificationUserInfoUpdateReq) (*relation.NotificationUserInfoUpdateResp, error) {
@@ -103,3 +104,7 @@ func (s *friendServer) GetIncrementalFriends(ctx context.Context, req *relation.
 	}
 	return opt.Build()
 }
+
+func (s *friendServer) CompactVersionLog(ctx context.Context, req *versionlog.CompactVersionLogReq) (*versionlog.CompactVersionLogResp, error) {
+	return incrversion.Compact(ctx, s.config.Share.IMAdminUserID, req, s.db.CompactFriendIncrVersion)
+}
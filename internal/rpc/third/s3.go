@@ -23,7 +23,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"go.mongodb.org/mongo-driver/mongo"
 
@@ -288,7 +287,6 @@ func (t *thirdServer) apiAddress(prefix, name string) string {
 }
 
 func (t *thirdServer) DeleteOutdatedData(ctx context.Context, req *third.DeleteOutdatedDataReq) (*third.DeleteOutdatedDataResp, error) {
-	var conf config.Third
 	expireTime := time.UnixMilli(req.ExpireTime)
 	findPagination := &sdkws.RequestPagination{
 		PageNumber: 1,
@@ -310,13 +308,15 @@ func (t *thirdServer) DeleteOutdatedData(ctx context.Context, req *third.DeleteO
 			if err != nil && errs.Unwrap(err) != mongo.ErrNoDocuments {
 				return nil, errs.Wrap(err)
 			}
-			if int(count) < 1 && t.minio != nil {
-				thumbnailKey, err := t.getMinioImageThumbnailKey(ctx, key)
-				if err != nil {
-					return nil, errs.Wrap(err)
+			if int(count) < 1 {
+				if t.minio != nil {
+					thumbnailKey, err := t.getMinioImageThumbnailKey(ctx, key)
+					if err != nil {
+						return nil, errs.Wrap(err)
+					}
+					t.s3dataBase.DeleteObject(ctx, thumbnailKey)
 				}
-				t.s3dataBase.DeleteObject(ctx, thumbnailKey)
-				t.s3dataBase.DelS3Key(ctx, conf.Object.Enable, needDelObjectKeys...)
+				t.s3dataBase.DelS3Key(ctx, t.config.RpcConfig.Object.Enable, key)
 				t.s3dataBase.DeleteObject(ctx, key)
 			}
 		}
@@ -19,10 +19,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/redis"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-server/v3/pkg/localcache"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/apns"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/webpush"
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/aws"
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/local"
 
 	"github.com/KyleYe/open-im-protocol/third"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/controller"
@@ -91,6 +97,10 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		o, err = oss.NewOSS(*config.RpcConfig.Object.Oss.Build())
 	case "kodo":
 		o, err = kodo.NewKodo(*config.RpcConfig.Object.Kodo.Build())
+	case "aws":
+		o, err = aws.NewAws(*config.RpcConfig.Object.Aws.Build())
+	case "local":
+		o, err = local.NewLocal(*config.RpcConfig.Object.Local.Build(config.Share.Secret))
 	default:
 		err = fmt.Errorf("invalid object enable: %s", enable)
 	}
@@ -98,14 +108,17 @@ func Start(ctx context.Context, config *Config, client discovery.SvcDiscoveryReg
 		return err
 	}
 	localcache.InitLocalCache(&config.LocalCacheConfig)
-	third.RegisterThirdServer(server, &thirdServer{
+	srv := &thirdServer{
 		thirdDatabase: controller.NewThirdDatabase(redis.NewThirdCache(rdb), logdb),
 		userRpcClient: rpcclient.NewUserRpcClient(client, config.Share.RpcRegisterName.User, config.Share.IMAdminUserID),
 		s3dataBase:    controller.NewS3Database(rdb, o, s3db),
 		defaultExpire: time.Hour * 24 * 7,
 		config:        config,
 		minio:         minioCli,
-	})
+	}
+	third.RegisterThirdServer(server, srv)
+	apns.RegisterApnsServer(server, srv)
+	webpush.RegisterWebPushServer(server, srv)
 	return nil
 }
 
@@ -121,6 +134,42 @@ func (t *thirdServer) FcmUpdateToken(ctx context.Context, req *third.FcmUpdateTo
 	return &third.FcmUpdateTokenResp{}, nil
 }
 
+func (t *thirdServer) ApnsUpdateToken(ctx context.Context, req *apns.ApnsUpdateTokenReq) (*apns.ApnsUpdateTokenResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.Account, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	err := t.thirdDatabase.ApnsUpdateToken(ctx, req.Account, int(req.PlatformID), req.DeviceToken, req.VoipToken, req.ExpireTime)
+	if err != nil {
+		return nil, err
+	}
+	return &apns.ApnsUpdateTokenResp{}, nil
+}
+
+func (t *thirdServer) RegisterWebPushSubscription(ctx context.Context, req *webpush.RegisterWebPushSubscriptionReq) (*webpush.RegisterWebPushSubscriptionResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	err := t.thirdDatabase.SetWebPushSubscription(ctx, req.UserID, &model.WebPushSubscription{
+		Endpoint: req.Subscription.Endpoint,
+		P256dh:   req.Subscription.P256Dh,
+		Auth:     req.Subscription.Auth,
+	}, t.config.RpcConfig.WebPush.MaxSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	return &webpush.RegisterWebPushSubscriptionResp{}, nil
+}
+
+func (t *thirdServer) UnregisterWebPushSubscription(ctx context.Context, req *webpush.UnregisterWebPushSubscriptionReq) (*webpush.UnregisterWebPushSubscriptionResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, t.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if err := t.thirdDatabase.DelWebPushSubscription(ctx, req.UserID, req.Endpoint); err != nil {
+		return nil, err
+	}
+	return &webpush.UnregisterWebPushSubscriptionResp{}, nil
+}
+
 func (t *thirdServer) SetAppBadge(ctx context.Context, req *third.SetAppBadgeReq) (resp *third.SetAppBadgeResp, err error) {
 	err = t.thirdDatabase.SetAppBadge(ctx, req.UserID, int(req.AppUnreadCount))
 	if err != nil {
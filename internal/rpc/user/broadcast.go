@@ -0,0 +1,47 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+)
+
+func (s *userServer) GetBroadcastRecipients(ctx context.Context, req *broadcast.GetBroadcastRecipientsReq) (*broadcast.GetBroadcastRecipientsResp, error) {
+	if err := authverify.CheckAdmin(ctx, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	var start, end *time.Time
+	if req.CreateTimeBegin > 0 {
+		t := time.UnixMilli(req.CreateTimeBegin)
+		start = &t
+	}
+	if req.CreateTimeEnd > 0 {
+		t := time.UnixMilli(req.CreateTimeEnd)
+		end = &t
+	}
+	limit := int(req.Limit)
+	if limit > broadcast.MaxRecipientsLimit {
+		limit = broadcast.MaxRecipientsLimit
+	}
+	userIDs, err := s.db.ScanUserID(ctx, req.AfterUserID, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcast.GetBroadcastRecipientsResp{UserIDs: userIDs}, nil
+}
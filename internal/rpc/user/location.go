@@ -0,0 +1,138 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/convert"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/location"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"golang.org/x/sync/errgroup"
+)
+
+// friendsOfFriendsConcurrency bounds the friend list lookups issued for a friends-of-friends search.
+const friendsOfFriendsConcurrency = 16
+
+func (s *userServer) UpdateLocation(ctx context.Context, req *location.UpdateLocationReq) (*location.UpdateLocationResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	data := map[string]any{
+		"location":      model.NewGeoPoint(req.Latitude, req.Longitude),
+		"location_time": time.Now(),
+	}
+	if req.Discoverable != nil {
+		data["discovery_opt_out"] = !req.Discoverable.Value
+	}
+	if err := s.db.UpdateByMap(ctx, req.UserID, data); err != nil {
+		return nil, err
+	}
+	return &location.UpdateLocationResp{}, nil
+}
+
+func (s *userServer) ClearLocation(ctx context.Context, req *location.ClearLocationReq) (*location.ClearLocationResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.FindWithError(ctx, []string{req.UserID}); err != nil {
+		return nil, err
+	}
+	if err := s.db.UpdateByMap(ctx, req.UserID, map[string]any{"location": nil}); err != nil {
+		return nil, err
+	}
+	return &location.ClearLocationResp{}, nil
+}
+
+func (s *userServer) GetNearbyUsers(ctx context.Context, req *location.GetNearbyUsersReq) (*location.GetNearbyUsersResp, error) {
+	if err := authverify.CheckAccessV3(ctx, req.UserID, s.config.Share.IMAdminUserID); err != nil {
+		return nil, err
+	}
+	radius := req.Radius
+	if radius == 0 {
+		radius = s.config.RpcConfig.Nearby.DefaultRadius
+	}
+	if maxRadius := s.config.RpcConfig.Nearby.MaxRadius; maxRadius > 0 && radius > maxRadius {
+		return nil, servererrs.ErrArgs.WrapMsg("radius exceeds the maximum", "radius", radius, "maxRadius", maxRadius)
+	}
+	userIDs, err := s.nearbyCandidates(ctx, req.UserID, req.Scope)
+	if err != nil {
+		return nil, err
+	}
+	total, users, err := s.db.FindNearby(ctx, req.Latitude, req.Longitude, radius, userIDs, req.UserID, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &location.GetNearbyUsersResp{
+		Total: int32(total),
+		Users: datautil.Slice(users, func(e *model.NearbyUser) *location.NearbyUser {
+			return &location.NearbyUser{User: convert.UserDB2Pb(&e.User), Distance: e.Distance}
+		}),
+	}, nil
+}
+
+// nearbyCandidates returns the user IDs a nearby search is limited to, nil means no limit.
+func (s *userServer) nearbyCandidates(ctx context.Context, userID string, scope location.NearbyScope) ([]string, error) {
+	if scope == location.NearbyScope_All {
+		return nil, nil
+	}
+	friendIDs, err := s.friendRpcClient.GetFriendIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if scope == location.NearbyScope_Friends || len(friendIDs) == 0 {
+		return append([]string{}, friendIDs...), nil
+	}
+	// The friend lists of friends are not accessible to the caller, read them as the app manager.
+	if len(s.config.Share.IMAdminUserID) == 0 {
+		return nil, errs.New("imAdminUserID is not configured").Wrap()
+	}
+	var (
+		mu      sync.Mutex
+		userIDs = datautil.SliceSet(friendIDs)
+	)
+	g, ctx := errgroup.WithContext(mcontext.WithOpUserIDContext(ctx, s.config.Share.IMAdminUserID[0]))
+	g.SetLimit(friendsOfFriendsConcurrency)
+	for _, friendID := range friendIDs {
+		friendID := friendID
+		g.Go(func() error {
+			ids, err := s.friendRpcClient.GetFriendIDs(ctx, friendID)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				userIDs[id] = struct{}{}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	delete(userIDs, userID)
+	return datautil.Keys(userIDs), nil
+}
@@ -33,6 +33,8 @@ import (
 	tablerelation "github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/webhook"
 	"github.com/KyleYe/open-im-server/v3/pkg/localcache"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/location"
 	"github.com/KyleYe/open-im-tools/db/redisutil"
 
 	"github.com/KyleYe/open-im-protocol/constant"
@@ -112,6 +114,8 @@ func Start(ctx context.Context, config *Config, client registry.SvcDiscoveryRegi
 		webhookClient:            webhook.NewWebhookClient(config.WebhooksConfig.URL),
 	}
 	pbuser.RegisterUserServer(server, u)
+	location.RegisterLocationServer(server, u)
+	broadcast.RegisterBroadcastRecipientServer(server, u)
 	return u.db.InitOnce(context.Background(), users)
 }
 
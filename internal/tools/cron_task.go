@@ -24,12 +24,13 @@ import (
 	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	kdisc "github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/rpctls"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/versionlog"
 
 	"github.com/KyleYe/open-im-protocol/third"
 	"github.com/KyleYe/open-im-tools/mcontext"
 	"github.com/KyleYe/open-im-tools/mw"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
@@ -51,7 +52,11 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 	if err != nil {
 		return errs.WrapMsg(err, "failed to register discovery service")
 	}
-	client.AddOption(mw.GrpcClient(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := rpctls.New(&config.Share.TLS)
+	if err != nil {
+		return err
+	}
+	client.AddOption(mw.GrpcClient(), creds.DialOption())
 	ctx = mcontext.SetOpUserID(ctx, config.Share.IMAdminUserID[0])
 
 	msgConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Msg)
@@ -70,6 +75,16 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 	}
 
 	msgClient := msg.NewMsgClient(msgConn)
+	friendConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Friend)
+	if err != nil {
+		return err
+	}
+
+	groupConn, err := client.GetConn(ctx, config.Share.RpcRegisterName.Group)
+	if err != nil {
+		return err
+	}
+
 	conversationClient := pbconversation.NewConversationClient(conversationConn)
 	thirdClient := third.NewThirdClient(thirdConn)
 
@@ -130,6 +145,53 @@ func Start(ctx context.Context, config *CronTaskConfig) error {
 		return errs.Wrap(err)
 	}
 
+	// scheduled compact the incremental sync version logs unchanged since the retain days.
+	if config.CronTask.RetainVersionLogs > 0 {
+		versionLogClients := map[string]versionlog.VersionLogClient{
+			config.Share.RpcRegisterName.Friend:       versionlog.NewVersionLogClient(friendConn),
+			config.Share.RpcRegisterName.Group:        versionlog.NewVersionLogClient(groupConn),
+			config.Share.RpcRegisterName.Conversation: versionlog.NewVersionLogClient(conversationConn),
+		}
+		compactVersionLogFunc := func() {
+			now := time.Now()
+			deadline := now.Add(-time.Hour * 24 * time.Duration(config.CronTask.RetainVersionLogs))
+			ctx := mcontext.SetOperationID(ctx, fmt.Sprintf("cron_%d_%d", os.Getpid(), deadline.UnixMilli()))
+			log.ZInfo(ctx, "compact version logs", "deadline", deadline, "timestamp", deadline.UnixMilli())
+			for name, versionLogClient := range versionLogClients {
+				resp, err := versionLogClient.CompactVersionLog(ctx, &versionlog.CompactVersionLogReq{Deadline: deadline.UnixMilli()})
+				if err != nil {
+					log.ZError(ctx, "cron compact version logs failed", err, "service", name, "deadline", deadline, "cont", time.Since(now))
+					continue
+				}
+				log.ZInfo(ctx, "cron compact version logs success", "service", name, "compactions", resp.Compactions, "cont", time.Since(now))
+			}
+		}
+		if _, err := crontab.AddFunc(config.CronTask.CronExecuteTime, compactVersionLogFunc); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
+	// send the scheduled messages whose time has come, a run still sending skips the next ones.
+	if config.CronTask.DispatchScheduledMsgTime != "" {
+		scheduledMsgClient := scheduledmsg.NewScheduledMsgClient(msgConn)
+		dispatchScheduledMsgFunc := func() {
+			now := time.Now()
+			ctx := mcontext.SetOperationID(ctx, fmt.Sprintf("cron_%d_%d", os.Getpid(), now.UnixMilli()))
+			resp, err := scheduledMsgClient.DispatchScheduledMsgs(ctx, &scheduledmsg.DispatchScheduledMsgsReq{})
+			if err != nil {
+				log.ZError(ctx, "cron dispatch scheduled msgs failed", err, "cont", time.Since(now))
+				return
+			}
+			if resp.Sent > 0 || resp.Failed > 0 {
+				log.ZInfo(ctx, "cron dispatch scheduled msgs success", "sent", resp.Sent, "failed", resp.Failed, "cont", time.Since(now))
+			}
+		}
+		job := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(dispatchScheduledMsgFunc))
+		if _, err := crontab.AddJob(config.CronTask.DispatchScheduledMsgTime, job); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+
 	log.ZInfo(ctx, "start cron task", "CronExecuteTime", config.CronTask.CronExecuteTime)
 	crontab.Start()
 	<-ctx.Done()
@@ -26,6 +26,10 @@ type SendMsg struct {
 	// GroupID is the identifier for the group, required if SessionType is 2 or 3.
 	GroupID string `json:"groupID" binding:"required_if=SessionType 2|required_if=SessionType 3"`
 
+	// ClientMsgID optionally identifies the message, retries with the same ClientMsgID are only sent once.
+	// A new one is generated when it is empty, batch sends always generate one per receiver.
+	ClientMsgID string `json:"clientMsgID"`
+
 	// SenderNickname is the nickname of the sender.
 	SenderNickname string `json:"senderNickname"`
 
@@ -67,6 +71,14 @@ type SendMsgReq struct {
 	SendMsg
 }
 
+// ScheduleSendMsgReq defines the structure for sending a message later.
+type ScheduleSendMsgReq struct {
+	SendMsgReq
+
+	// ScheduleTime is the millisecond timestamp the message is sent at.
+	ScheduleTime int64 `json:"scheduleTime" binding:"required"`
+}
+
 type GetConversationListReq struct {
 	// userID uniquely identifies the user.
 	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty" binding:"required"`
@@ -111,6 +123,24 @@ type BatchSendMsgResp struct {
 	FailedIDs []string `json:"failedUserIDs"`
 }
 
+// CreateBroadcastReq defines the structure for sending a message to many recipients in the background.
+type CreateBroadcastReq struct {
+	SendMsg
+
+	// TargetType selects the recipients: 1 the RecvIDs, 2 every user, 3 the users created in [CreateTimeBegin, CreateTimeEnd).
+	TargetType int32 `json:"targetType" binding:"required"`
+
+	// RecvIDs is a slice of receiver identifiers, required when TargetType is 1.
+	RecvIDs []string `json:"recvIDs"`
+
+	// CreateTimeBegin and CreateTimeEnd are millisecond timestamps, 0 leaves the side of the range open.
+	CreateTimeBegin int64 `json:"createTimeBegin"`
+	CreateTimeEnd   int64 `json:"createTimeEnd"`
+
+	// Rate is the number of messages sent per second, 0 uses the configured rate.
+	Rate int32 `json:"rate"`
+}
+
 // SingleReturnResult encapsulates the result of a single message send attempt.
 type SingleReturnResult struct {
 	// ServerMsgID is the message identifier on the server-side.
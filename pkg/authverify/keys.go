@@ -0,0 +1,222 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authverify
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+type tokenKey struct {
+	id      string
+	method  jwt.SigningMethod
+	public  crypto.PublicKey
+	private crypto.PrivateKey
+}
+
+// KeySet signs tokens with the configured signing key and verifies them with the key named by their kid header.
+// Tokens without a kid are signed and verified with HS256 and the shared secret, once a signing key is configured
+// they are only accepted while the legacy secret is allowed.
+type KeySet struct {
+	secret  string
+	signing *tokenKey
+	keys    map[string]*tokenKey
+	list    []*tokenKey
+	// legacy reports whether tokens without a kid are accepted at now
+	legacy func(now time.Time) bool
+}
+
+// NewKeySet loads the keys of conf. The private keys are only read when loadPrivate is set,
+// so that services which only verify tokens do not need them.
+func NewKeySet(conf *config.TokenSigning, secret string, loadPrivate bool) (*KeySet, error) {
+	ks := &KeySet{secret: secret, keys: make(map[string]*tokenKey), legacy: legacySecret(conf)}
+	for _, c := range conf.Keys {
+		if c.KeyID == "" {
+			return nil, errs.New("token key kid is empty").Wrap()
+		}
+		if _, ok := ks.keys[c.KeyID]; ok {
+			return nil, errs.New("duplicate token key", "kid", c.KeyID).Wrap()
+		}
+		key, err := loadTokenKey(&c, loadPrivate && c.KeyID == conf.SigningKeyID)
+		if err != nil {
+			return nil, err
+		}
+		ks.keys[c.KeyID] = key
+		ks.list = append(ks.list, key)
+	}
+	if conf.SigningKeyID != "" && loadPrivate {
+		ks.signing = ks.keys[conf.SigningKeyID]
+		if ks.signing == nil {
+			return nil, errs.New("signing key not found", "kid", conf.SigningKeyID).Wrap()
+		}
+	}
+	return ks, nil
+}
+
+func legacySecret(conf *config.TokenSigning) func(time.Time) bool {
+	switch {
+	case conf.SigningKeyID == "":
+		// The secret is the only signing key.
+		return func(time.Time) bool { return true }
+	case !conf.LegacySecret:
+		return func(time.Time) bool { return false }
+	case conf.LegacySecretUntil > 0:
+		until := time.Unix(conf.LegacySecretUntil, 0)
+		return func(now time.Time) bool { return now.Before(until) }
+	default:
+		return func(time.Time) bool { return true }
+	}
+}
+
+func loadTokenKey(conf *config.TokenKey, loadPrivate bool) (*tokenKey, error) {
+	key := &tokenKey{id: conf.KeyID}
+	var (
+		parsePublic  func([]byte) (crypto.PublicKey, error)
+		parsePrivate func([]byte) (crypto.PrivateKey, error)
+		publicOf     func(crypto.PrivateKey) crypto.PublicKey
+	)
+	switch conf.Algorithm {
+	case AlgorithmRS256:
+		key.method = jwt.SigningMethodRS256
+		parsePublic = func(b []byte) (crypto.PublicKey, error) { return jwt.ParseRSAPublicKeyFromPEM(b) }
+		parsePrivate = func(b []byte) (crypto.PrivateKey, error) { return jwt.ParseRSAPrivateKeyFromPEM(b) }
+		publicOf = func(k crypto.PrivateKey) crypto.PublicKey { return &k.(*rsa.PrivateKey).PublicKey }
+	case AlgorithmEdDSA:
+		key.method = jwt.SigningMethodEdDSA
+		parsePublic = jwt.ParseEdPublicKeyFromPEM
+		parsePrivate = jwt.ParseEdPrivateKeyFromPEM
+		publicOf = func(k crypto.PrivateKey) crypto.PublicKey { return k.(ed25519.PrivateKey).Public() }
+	default:
+		return nil, errs.New("unsupported token key algorithm", "kid", conf.KeyID, "algorithm", conf.Algorithm).Wrap()
+	}
+	if loadPrivate {
+		if conf.PrivateKey == "" {
+			return nil, errs.New("signing key has no private key", "kid", conf.KeyID).Wrap()
+		}
+		data, err := os.ReadFile(conf.PrivateKey)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "read private key failed", "kid", conf.KeyID, "path", conf.PrivateKey)
+		}
+		if key.private, err = parsePrivate(data); err != nil {
+			return nil, errs.WrapMsg(err, "parse private key failed", "kid", conf.KeyID)
+		}
+		key.public = publicOf(key.private)
+	}
+	if conf.PublicKey != "" {
+		data, err := os.ReadFile(conf.PublicKey)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "read public key failed", "kid", conf.KeyID, "path", conf.PublicKey)
+		}
+		if key.public, err = parsePublic(data); err != nil {
+			return nil, errs.WrapMsg(err, "parse public key failed", "kid", conf.KeyID)
+		}
+	}
+	if key.public == nil {
+		return nil, errs.New("token key has no public key", "kid", conf.KeyID).Wrap()
+	}
+	return key, nil
+}
+
+// Sign signs the claims with the signing key, or with the shared secret when there is none.
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if k.signing == nil {
+		tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(k.secret))
+		if err != nil {
+			return "", errs.WrapMsg(err, "token.SignedString")
+		}
+		return tokenString, nil
+	}
+	token := jwt.NewWithClaims(k.signing.method, claims)
+	token.Header["kid"] = k.signing.id
+	tokenString, err := token.SignedString(k.signing.private)
+	if err != nil {
+		return "", errs.WrapMsg(err, "token.SignedString", "kid", k.signing.id)
+	}
+	return tokenString, nil
+}
+
+// Keyfunc selects the verification key by the kid header of the token, it is used as a jwt.Keyfunc.
+func (k *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if !k.legacy(time.Now()) {
+			return nil, errs.New("token without kid is no longer accepted")
+		}
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, errs.New("token without kid must be HS256", "alg", token.Method.Alg())
+		}
+		return []byte(k.secret), nil
+	}
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, errs.New("unknown token kid", "kid", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errs.New("token alg does not match its key", "kid", kid, "alg", token.Method.Alg())
+	}
+	return key.public, nil
+}
+
+// JWK is a public key in the JSON Web Key format, RFC 7517.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public verification keys.
+func (k *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{Keys: make([]JWK, 0, len(k.list))}
+	for _, key := range k.list {
+		jwk := JWK{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
@@ -0,0 +1,112 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authverify
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/tokenverify"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600))
+}
+
+func TestKeySet(t *testing.T) {
+	dir := t.TempDir()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	assert.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "rsa.key"), "PRIVATE KEY", der)
+	der, err = x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	assert.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "rsa.pub"), "PUBLIC KEY", der)
+
+	edPublic, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(edPublic)
+	assert.NoError(t, err)
+	writePEM(t, filepath.Join(dir, "ed.pub"), "PUBLIC KEY", der)
+
+	conf := &config.TokenSigning{
+		SigningKeyID: "rsa",
+		Keys: []config.TokenKey{
+			{KeyID: "rsa", Algorithm: AlgorithmRS256, PublicKey: filepath.Join(dir, "rsa.pub"), PrivateKey: filepath.Join(dir, "rsa.key")},
+			{KeyID: "ed", Algorithm: AlgorithmEdDSA, PublicKey: filepath.Join(dir, "ed.pub")},
+		},
+	}
+	signer, err := NewKeySet(conf, "secret", true)
+	assert.NoError(t, err)
+	verifier, err := NewKeySet(conf, "secret", false)
+	assert.NoError(t, err)
+
+	token, err := signer.Sign(tokenverify.BuildClaims("u1", 1, 1))
+	assert.NoError(t, err)
+	claims, err := tokenverify.GetClaimFromToken(token, verifier.Keyfunc)
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", claims.UserID)
+
+	// Tokens signed before the switch carry no kid, they are rejected unless the legacy secret is allowed.
+	legacy, err := NewKeySet(&config.TokenSigning{}, "secret", true)
+	assert.NoError(t, err)
+	legacyToken, err := legacy.Sign(tokenverify.BuildClaims("u2", 1, 1))
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(legacyToken, legacy.Keyfunc)
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(legacyToken, verifier.Keyfunc)
+	assert.Error(t, err)
+
+	conf.LegacySecret = true
+	verifier, err = NewKeySet(conf, "secret", false)
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(legacyToken, verifier.Keyfunc)
+	assert.NoError(t, err)
+
+	other, err := NewKeySet(&config.TokenSigning{}, "other", true)
+	assert.NoError(t, err)
+	token, err = other.Sign(tokenverify.BuildClaims("u3", 1, 1))
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(token, verifier.Keyfunc)
+	assert.Error(t, err)
+
+	conf.LegacySecretUntil = time.Now().Add(time.Hour).Unix()
+	verifier, err = NewKeySet(conf, "secret", false)
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(legacyToken, verifier.Keyfunc)
+	assert.NoError(t, err)
+
+	conf.LegacySecretUntil = time.Now().Add(-time.Second).Unix()
+	verifier, err = NewKeySet(conf, "secret", false)
+	assert.NoError(t, err)
+	_, err = tokenverify.GetClaimFromToken(legacyToken, verifier.Keyfunc)
+	assert.Error(t, err)
+
+	jwks := verifier.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+}
@@ -20,6 +20,10 @@ const (
 	CallbackAfterSetGroupInfoCommand        = "callbackAfterSetGroupInfoCommand"
 	CallbackBeforeSetGroupInfoCommand       = "callbackBeforeSetGroupInfoCommand"
 	CallbackAfterRevokeMsgCommand           = "callbackBeforeAfterMsgCommand"
+	CallbackBeforeRevokeMsgCommand          = "callbackBeforeRevokeMsgCommand"
+	CallbackBeforeMsgEditCommand            = "callbackBeforeMsgEditCommand"
+	CallbackAfterMsgEditCommand             = "callbackAfterMsgEditCommand"
+	CallbackAfterPushTokenInvalidCommand    = "callbackAfterPushTokenInvalidCommand"
 	CallbackBeforeAddBlackCommand           = "callbackBeforeAddBlackCommand"
 	CallbackAfterAddFriendCommand           = "callbackAfterAddFriendCommand"
 	CallbackBeforeAddFriendAgreeCommand     = "callbackBeforeAddFriendAgreeCommand"
@@ -27,6 +31,9 @@ const (
 	CallbackBeforeImportFriendsCommand      = "callbackBeforeImportFriendsCommand"
 	CallbackAfterImportFriendsCommand       = "callbackAfterImportFriendsCommand"
 	CallbackAfterRemoveBlackCommand         = "callbackAfterRemoveBlackCommand"
+	CallbackAfterScheduleMsgCommand         = "callbackAfterScheduleMsgCommand"
+	CallbackAfterScheduledMsgDeliverCommand = "callbackAfterScheduledMsgDeliverCommand"
+	CallbackAfterCancelScheduledMsgCommand  = "callbackAfterCancelScheduledMsgCommand"
 	CallbackAfterQuitGroupCommand           = "callbackAfterQuitGroupCommand"
 	CallbackAfterKickGroupCommand           = "callbackAfterKickGroupCommand"
 	CallbackAfterDisMissGroupCommand        = "callbackAfterDisMissGroupCommand"
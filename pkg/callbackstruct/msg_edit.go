@@ -0,0 +1,54 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callbackstruct
+
+type CallbackBeforeMsgEditReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ConversationID  string `json:"conversationID"`
+	Seq             int64  `json:"seq"`
+	UserID          string `json:"userID"`
+	SendID          string `json:"sendID"`
+	GroupID         string `json:"groupID"`
+	SessionType     int32  `json:"sessionType"`
+	ContentType     int32  `json:"contentType"`
+	ClientMsgID     string `json:"clientMsgID"`
+	OldContent      string `json:"oldContent"`
+	Content         string `json:"content"`
+}
+
+type CallbackBeforeMsgEditResp struct {
+	CommonCallbackResp
+	// Replaces the new content when set
+	Content *string `json:"content"`
+}
+
+type CallbackAfterMsgEditReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ConversationID  string `json:"conversationID"`
+	Seq             int64  `json:"seq"`
+	UserID          string `json:"userID"`
+	SendID          string `json:"sendID"`
+	GroupID         string `json:"groupID"`
+	SessionType     int32  `json:"sessionType"`
+	ContentType     int32  `json:"contentType"`
+	ClientMsgID     string `json:"clientMsgID"`
+	OldContent      string `json:"oldContent"`
+	Content         string `json:"content"`
+	EditTime        int64  `json:"editTime"`
+}
+
+type CallbackAfterMsgEditResp struct {
+	CommonCallbackResp
+}
@@ -51,3 +51,19 @@ type CallbackBeforeSuperGroupOnlinePushResp struct {
 	UserIDs         []string                `json:"userIDList"`
 	OfflinePushInfo *common.OfflinePushInfo `json:"offlinePushInfo"`
 }
+
+type CallbackAfterPushTokenInvalidReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	UserID          string `json:"userID"`
+	PlatformID      int    `json:"platformID"`
+	Platform        string `json:"platform"`
+	// Provider is the offline push provider that rejected the token, e.g. fcm, apns or webpush
+	Provider string `json:"provider"`
+	// Token is the removed device token, the endpoint of a web push subscription
+	Token  string `json:"token"`
+	Reason string `json:"reason"`
+}
+
+type CallbackAfterPushTokenInvalidResp struct {
+	CommonCallbackResp
+}
@@ -24,3 +24,20 @@ type CallbackAfterRevokeMsgReq struct {
 type CallbackAfterRevokeMsgResp struct {
 	CommonCallbackResp
 }
+
+type CallbackBeforeRevokeMsgReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ConversationID  string `json:"conversationID"`
+	Seq             int64  `json:"seq"`
+	UserID          string `json:"userID"`
+	SendID          string `json:"sendID"`
+	GroupID         string `json:"groupID"`
+	SessionType     int32  `json:"sessionType"`
+	ClientMsgID     string `json:"clientMsgID"`
+	ServerMsgID     string `json:"serverMsgID"`
+	SendTime        int64  `json:"sendTime"`
+}
+
+type CallbackBeforeRevokeMsgResp struct {
+	CommonCallbackResp
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callbackstruct
+
+type CallbackAfterScheduleMsgReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ScheduleID      string `json:"scheduleID"`
+	SendID          string `json:"sendID"`
+	RecvID          string `json:"recvID"`
+	GroupID         string `json:"groupID"`
+	SessionType     int32  `json:"sessionType"`
+	ContentType     int32  `json:"contentType"`
+	ClientMsgID     string `json:"clientMsgID"`
+	Content         string `json:"content"`
+	ScheduleTime    int64  `json:"scheduleTime"`
+}
+
+type CallbackAfterScheduleMsgResp struct {
+	CommonCallbackResp
+}
+
+type CallbackAfterScheduledMsgDeliverReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ScheduleID      string `json:"scheduleID"`
+	SendID          string `json:"sendID"`
+	RecvID          string `json:"recvID"`
+	GroupID         string `json:"groupID"`
+	SessionType     int32  `json:"sessionType"`
+	ContentType     int32  `json:"contentType"`
+	ClientMsgID     string `json:"clientMsgID"`
+	ServerMsgID     string `json:"serverMsgID"`
+	ScheduleTime    int64  `json:"scheduleTime"`
+	DeliverTime     int64  `json:"deliverTime"`
+	// ErrCode is 0 when the message was sent
+	ErrCode int32  `json:"errCode"`
+	ErrMsg  string `json:"errMsg"`
+}
+
+type CallbackAfterScheduledMsgDeliverResp struct {
+	CommonCallbackResp
+}
+
+type CallbackAfterCancelScheduledMsgReq struct {
+	CallbackCommand `json:"callbackCommand"`
+	ScheduleID      string `json:"scheduleID"`
+	// UserID is who canceled the message, the sender or an app manager
+	UserID       string `json:"userID"`
+	SendID       string `json:"sendID"`
+	RecvID       string `json:"recvID"`
+	GroupID      string `json:"groupID"`
+	ClientMsgID  string `json:"clientMsgID"`
+	ScheduleTime int64  `json:"scheduleTime"`
+}
+
+type CallbackAfterCancelScheduledMsgResp struct {
+	CommonCallbackResp
+}
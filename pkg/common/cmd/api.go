@@ -34,9 +34,10 @@ func NewApiCmd() *ApiCmd {
 	var apiConfig api.Config
 	ret := &ApiCmd{apiConfig: &apiConfig}
 	ret.configMap = map[string]any{
-		OpenIMAPICfgFileName:    &apiConfig.API,
-		ShareFileName:           &apiConfig.Share,
-		DiscoveryConfigFilename: &apiConfig.Discovery,
+		OpenIMAPICfgFileName:      &apiConfig.API,
+		ShareFileName:             &apiConfig.Share,
+		DiscoveryConfigFilename:   &apiConfig.Discovery,
+		OpenIMRPCThirdCfgFileName: &apiConfig.ThirdConfig,
 	}
 	ret.RootCmd = NewRootCmd(program.GetProcessName(), WithConfigMap(ret.configMap))
 	ret.ctx = context.WithValue(context.Background(), "version", version.Version)
@@ -0,0 +1,186 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister/standalone"
+	"github.com/KyleYe/open-im-server/v3/version"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"github.com/KyleYe/open-im-tools/system/program"
+	"github.com/spf13/cobra"
+)
+
+// standaloneReadyTimeout bounds how long a phase may take to register its rpc services.
+const standaloneReadyTimeout = 2 * time.Minute
+
+type standaloneService struct {
+	name      string
+	root      *RootCmd
+	configMap map[string]any
+	runE      func() error
+}
+
+type standalonePhase struct {
+	services []standaloneService
+	// registers returns the rpc services the next phase waits for
+	registers func(names *config.RpcRegisterName) []string
+}
+
+// StandaloneCmd runs every service in one process, the services call each other through in-memory connections.
+// All of them load their configuration from the same config directory.
+type StandaloneCmd struct {
+	*RootCmd
+	ctx    context.Context
+	share  *config.Share
+	phases []standalonePhase
+}
+
+func NewStandaloneCmd() *StandaloneCmd {
+	user := NewUserRpcCmd()
+	friend := NewFriendRpcCmd()
+	group := NewGroupRpcCmd()
+	msg := NewMsgRpcCmd()
+	conversation := NewConversationRpcCmd()
+	third := NewThirdRpcCmd()
+	auth := NewAuthRpcCmd()
+	push := NewPushRpcCmd()
+	msgGateway := NewMsgGatewayCmd()
+	msgTransfer := NewMsgTransferCmd()
+	cronTask := NewCronTaskCmd()
+	api := NewApiCmd()
+
+	ret := &StandaloneCmd{share: &user.userConfig.Share}
+	ret.phases = []standalonePhase{
+		{
+			services: []standaloneService{
+				{name: "openim-rpc-user", root: user.RootCmd, configMap: user.configMap, runE: user.runE},
+				{name: "openim-rpc-friend", root: friend.RootCmd, configMap: friend.configMap, runE: friend.runE},
+				{name: "openim-rpc-group", root: group.RootCmd, configMap: group.configMap, runE: group.runE},
+				{name: "openim-rpc-msg", root: msg.RootCmd, configMap: msg.configMap, runE: msg.runE},
+				{name: "openim-rpc-conversation", root: conversation.RootCmd, configMap: conversation.configMap, runE: conversation.runE},
+				{name: "openim-rpc-third", root: third.RootCmd, configMap: third.configMap, runE: third.runE},
+				{name: "openim-rpc-auth", root: auth.RootCmd, configMap: auth.configMap, runE: auth.runE},
+				{name: "openim-push", root: push.RootCmd, configMap: push.configMap, runE: push.runE},
+			},
+			registers: func(names *config.RpcRegisterName) []string {
+				return []string{names.User, names.Friend, names.Group, names.Msg, names.Conversation, names.Third, names.Auth, names.Push}
+			},
+		},
+		{
+			services: []standaloneService{
+				{name: "openim-msggateway", root: msgGateway.RootCmd, configMap: msgGateway.configMap, runE: msgGateway.runE},
+			},
+			registers: func(names *config.RpcRegisterName) []string {
+				return []string{names.MessageGateway}
+			},
+		},
+		{
+			services: []standaloneService{
+				{name: "openim-msgtransfer", root: msgTransfer.RootCmd, configMap: msgTransfer.configMap, runE: msgTransfer.runE},
+				{name: "openim-crontask", root: cronTask.RootCmd, configMap: cronTask.configMap, runE: cronTask.runE},
+				{name: "openim-api", root: api.RootCmd, configMap: api.configMap, runE: api.runE},
+			},
+		},
+	}
+	ret.RootCmd = NewRootCmd(program.GetProcessName())
+	ret.ctx = context.WithValue(context.Background(), "version", version.Version)
+	ret.Command.RunE = func(cmd *cobra.Command, args []string) error {
+		return ret.runE()
+	}
+	return ret
+}
+
+func (s *StandaloneCmd) Exec() error {
+	return s.Execute()
+}
+
+// loadConfig loads the configuration of every service, the discovery is always in memory.
+// The services also take the config path and index of the standalone command.
+func (s *StandaloneCmd) loadConfig() error {
+	for _, phase := range s.phases {
+		for _, service := range phase.services {
+			service.root.configPath = s.ConfigPath()
+			service.root.index = s.Index()
+			for configFileName, configStruct := range service.configMap {
+				err := config.LoadConfig(filepath.Join(s.ConfigPath(), configFileName),
+					ConfigEnvPrefixMap[configFileName], configStruct)
+				if err != nil {
+					return err
+				}
+				if discovery, ok := configStruct.(*config.Discovery); ok {
+					discovery.Enable = "standalone"
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StandaloneCmd) runE() error {
+	if err := s.loadConfig(); err != nil {
+		return err
+	}
+	var num int
+	for _, phase := range s.phases {
+		num += len(phase.services)
+	}
+	done := make(chan error, num)
+	for _, phase := range s.phases {
+		for _, service := range phase.services {
+			service := service
+			log.CInfo(s.ctx, "standalone service is starting", "service", service.name)
+			go func() {
+				if err := service.runE(); err != nil {
+					done <- errs.WrapMsg(err, "service stopped", "service", service.name)
+					return
+				}
+				done <- nil
+			}()
+		}
+		if phase.registers == nil {
+			continue
+		}
+		if err := s.waitRegistered(done, phase.registers(&s.share.RpcRegisterName)); err != nil {
+			return err
+		}
+	}
+	log.CInfo(s.ctx, "standalone services are started")
+	return <-done
+}
+
+// waitRegistered returns once the rpc services registered, or when a service stopped before.
+func (s *StandaloneCmd) waitRegistered(done <-chan error, serviceNames []string) error {
+	ctx, cancel := context.WithTimeout(s.ctx, standaloneReadyTimeout)
+	defer cancel()
+	registered := make(chan error, 1)
+	go func() {
+		registered <- standalone.WaitRegistered(ctx, serviceNames...)
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			err = errs.New("service stopped before the services registered", "serviceNames", serviceNames).Wrap()
+		}
+		return err
+	case err := <-registered:
+		return err
+	}
+}
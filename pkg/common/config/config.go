@@ -18,6 +18,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/aws"
+	"github.com/KyleYe/open-im-server/v3/pkg/s3/local"
 	"github.com/KyleYe/open-im-tools/db/mongoutil"
 	"github.com/KyleYe/open-im-tools/db/redisutil"
 	"github.com/KyleYe/open-im-tools/mq/kafka"
@@ -73,18 +75,19 @@ type Mongo struct {
 	MaxRetry    int      `mapstructure:"maxRetry"`
 }
 type Kafka struct {
-	Username       string    `mapstructure:"username"`
-	Password       string    `mapstructure:"password"`
-	ProducerAck    string    `mapstructure:"producerAck"`
-	CompressType   string    `mapstructure:"compressType"`
-	Address        []string  `mapstructure:"address"`
-	ToRedisTopic   string    `mapstructure:"toRedisTopic"`
-	ToMongoTopic   string    `mapstructure:"toMongoTopic"`
-	ToPushTopic    string    `mapstructure:"toPushTopic"`
-	ToRedisGroupID string    `mapstructure:"toRedisGroupID"`
-	ToMongoGroupID string    `mapstructure:"toMongoGroupID"`
-	ToPushGroupID  string    `mapstructure:"toPushGroupID"`
-	Tls            TLSConfig `mapstructure:"tls"`
+	Username          string    `mapstructure:"username"`
+	Password          string    `mapstructure:"password"`
+	ProducerAck       string    `mapstructure:"producerAck"`
+	CompressType      string    `mapstructure:"compressType"`
+	Address           []string  `mapstructure:"address"`
+	ToRedisTopic      string    `mapstructure:"toRedisTopic"`
+	ToMongoTopic      string    `mapstructure:"toMongoTopic"`
+	ToPushTopic       string    `mapstructure:"toPushTopic"`
+	ToDeadLetterTopic string    `mapstructure:"toDeadLetterTopic"`
+	ToRedisGroupID    string    `mapstructure:"toRedisGroupID"`
+	ToMongoGroupID    string    `mapstructure:"toMongoGroupID"`
+	ToPushGroupID     string    `mapstructure:"toPushGroupID"`
+	Tls               TLSConfig `mapstructure:"tls"`
 }
 type TLSConfig struct {
 	EnableTLS          bool   `mapstructure:"enableTLS"`
@@ -108,9 +111,11 @@ type API struct {
 }
 
 type CronTask struct {
-	CronExecuteTime   string `mapstructure:"cronExecuteTime"`
-	RetainChatRecords int    `mapstructure:"retainChatRecords"`
-	FileExpireTime    int    `mapstructure:"fileExpireTime"`
+	CronExecuteTime          string `mapstructure:"cronExecuteTime"`
+	RetainChatRecords        int    `mapstructure:"retainChatRecords"`
+	FileExpireTime           int    `mapstructure:"fileExpireTime"`
+	RetainVersionLogs        int    `mapstructure:"retainVersionLogs"`
+	DispatchScheduledMsgTime string `mapstructure:"dispatchScheduledMsgTime"`
 }
 
 type OfflinePushConfig struct {
@@ -194,9 +199,11 @@ type Push struct {
 		ListenIP   string `mapstructure:"listenIP"`
 		Ports      []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus           Prometheus `mapstructure:"prometheus"`
-	MaxConcurrentWorkers int        `mapstructure:"maxConcurrentWorkers"`
-	Enable               string     `mapstructure:"enable"`
+	Prometheus           Prometheus  `mapstructure:"prometheus"`
+	MaxConcurrentWorkers int         `mapstructure:"maxConcurrentWorkers"`
+	MaxBatchPushMsgs     int         `mapstructure:"maxBatchPushMsgs"`
+	Enable               []string    `mapstructure:"enable"`
+	Routes               []PushRoute `mapstructure:"routes"`
 	GeTui                struct {
 		PushUrl      string `mapstructure:"pushUrl"`
 		MasterSecret string `mapstructure:"masterSecret"`
@@ -215,11 +222,42 @@ type Push struct {
 		PushURL      string `mapstructure:"pushURL"`
 		PushIntent   string `mapstructure:"pushIntent"`
 	} `mapstructure:"jpns"`
+	Apns struct {
+		KeyFilePath            string `mapstructure:"keyFilePath"`
+		KeyID                  string `mapstructure:"keyID"`
+		TeamID                 string `mapstructure:"teamID"`
+		BundleID               string `mapstructure:"bundleID"`
+		Endpoint               string `mapstructure:"endpoint"`
+		MutableContent         bool   `mapstructure:"mutableContent"`
+		CollapseByConversation bool   `mapstructure:"collapseByConversation"`
+	} `mapstructure:"apns"`
+	WebPush struct {
+		PublicKey  string `mapstructure:"publicKey"`
+		PrivateKey string `mapstructure:"privateKey"`
+		Subject    string `mapstructure:"subject"`
+		TTL        int    `mapstructure:"ttl"`
+	} `mapstructure:"webPush"`
 	IOSPush struct {
 		PushSound  string `mapstructure:"pushSound"`
 		BadgeCount bool   `mapstructure:"badgeCount"`
 		Production bool   `mapstructure:"production"`
 	} `mapstructure:"iosPush"`
+	Retry PushRetry `mapstructure:"retry"`
+}
+
+// PushRoute selects the offline push provider of the devices of some platforms.
+type PushRoute struct {
+	Platforms []string `mapstructure:"platforms"`
+	Providers []string `mapstructure:"providers"`
+}
+
+type PushRetry struct {
+	Enable           bool `mapstructure:"enable"`
+	MinBackoff       int  `mapstructure:"minBackoff"`
+	MaxBackoff       int  `mapstructure:"maxBackoff"`
+	MaxAge           int  `mapstructure:"maxAge"`
+	BreakerThreshold int  `mapstructure:"breakerThreshold"`
+	BreakerCooldown  int  `mapstructure:"breakerCooldown"`
 }
 
 type Auth struct {
@@ -230,7 +268,11 @@ type Auth struct {
 	} `mapstructure:"rpc"`
 	Prometheus  Prometheus `mapstructure:"prometheus"`
 	TokenPolicy struct {
-		Expire int64 `mapstructure:"expire"`
+		Expire  int64 `mapstructure:"expire"`
+		Refresh struct {
+			Expire       int64 `mapstructure:"expire"`
+			AccessExpire int64 `mapstructure:"accessExpire"`
+		} `mapstructure:"refresh"`
 	} `mapstructure:"tokenPolicy"`
 }
 
@@ -267,8 +309,63 @@ type Msg struct {
 		ListenIP   string `mapstructure:"listenIP"`
 		Ports      []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
-	Prometheus   Prometheus `mapstructure:"prometheus"`
-	FriendVerify bool       `mapstructure:"friendVerify"`
+	Prometheus   Prometheus     `mapstructure:"prometheus"`
+	FriendVerify bool           `mapstructure:"friendVerify"`
+	Interceptor  MsgInterceptor `mapstructure:"interceptor"`
+	Idempotency  struct {
+		Window int `mapstructure:"window"`
+	} `mapstructure:"idempotency"`
+	Revoke MsgRevoke `mapstructure:"revoke"`
+	Edit   struct {
+		MaxAge int64 `mapstructure:"maxAge"`
+	} `mapstructure:"edit"`
+	Reaction struct {
+		MaxEmojis     int `mapstructure:"maxEmojis"`
+		MaxReactorIDs int `mapstructure:"maxReactorIDs"`
+	} `mapstructure:"reaction"`
+	Broadcast MsgBroadcast `mapstructure:"broadcast"`
+	Schedule  struct {
+		MaxDelay      int64 `mapstructure:"maxDelay"`
+		MaxPending    int64 `mapstructure:"maxPending"`
+		Lease         int   `mapstructure:"lease"`
+		DispatchBatch int   `mapstructure:"dispatchBatch"`
+	} `mapstructure:"schedule"`
+}
+
+type MsgBroadcast struct {
+	Enable    bool `mapstructure:"enable"`
+	Rate      int  `mapstructure:"rate"`
+	MaxRate   int  `mapstructure:"maxRate"`
+	BatchSize int  `mapstructure:"batchSize"`
+	Lease     int  `mapstructure:"lease"`
+	Interval  int  `mapstructure:"interval"`
+}
+
+type MsgRevoke struct {
+	Single struct {
+		MaxAge int64 `mapstructure:"maxAge"`
+	} `mapstructure:"single"`
+	Group struct {
+		MaxAge           int64 `mapstructure:"maxAge"`
+		AdminMaxAge      int64 `mapstructure:"adminMaxAge"`
+		AdminRevokeAdmin bool  `mapstructure:"adminRevokeAdmin"`
+	} `mapstructure:"group"`
+}
+
+type MsgInterceptor struct {
+	ContentType struct {
+		SessionTypes []int32 `mapstructure:"sessionTypes"`
+		Allow        []int32 `mapstructure:"allow"`
+	} `mapstructure:"contentType"`
+	ContentLength struct {
+		SessionTypes []int32 `mapstructure:"sessionTypes"`
+		Max          int     `mapstructure:"max"`
+	} `mapstructure:"contentLength"`
+	SensitiveWord struct {
+		SessionTypes []int32  `mapstructure:"sessionTypes"`
+		Words        []string `mapstructure:"words"`
+		Reject       bool     `mapstructure:"reject"`
+	} `mapstructure:"sensitiveWord"`
 }
 
 type Third struct {
@@ -283,15 +380,26 @@ type Third struct {
 		Cos    Cos    `mapstructure:"cos"`
 		Oss    Oss    `mapstructure:"oss"`
 		Kodo   Kodo   `mapstructure:"kodo"`
-		Aws    struct {
-			Endpoint        string `mapstructure:"endpoint"`
-			Region          string `mapstructure:"region"`
-			Bucket          string `mapstructure:"bucket"`
-			AccessKeyID     string `mapstructure:"accessKeyID"`
-			AccessKeySecret string `mapstructure:"accessKeySecret"`
-			PublicRead      bool   `mapstructure:"publicRead"`
-		} `mapstructure:"aws"`
+		Aws    Aws    `mapstructure:"aws"`
+		Local  Local  `mapstructure:"local"`
 	} `mapstructure:"object"`
+	WebPush struct {
+		MaxSubscriptions int `mapstructure:"maxSubscriptions"`
+	} `mapstructure:"webPush"`
+}
+type Local struct {
+	Directory string `mapstructure:"directory"`
+	URL       string `mapstructure:"url"`
+}
+type Aws struct {
+	Endpoint        string `mapstructure:"endpoint"`
+	Region          string `mapstructure:"region"`
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"accessKeyID"`
+	AccessKeySecret string `mapstructure:"accessKeySecret"`
+	SessionToken    string `mapstructure:"sessionToken"`
+	PublicRead      bool   `mapstructure:"publicRead"`
+	PathStyle       bool   `mapstructure:"pathStyle"`
 }
 type Cos struct {
 	BucketURL    string `mapstructure:"bucketURL"`
@@ -327,6 +435,10 @@ type User struct {
 		Ports      []int  `mapstructure:"ports"`
 	} `mapstructure:"rpc"`
 	Prometheus Prometheus `mapstructure:"prometheus"`
+	Nearby     struct {
+		DefaultRadius float64 `mapstructure:"defaultRadius"`
+		MaxRadius     float64 `mapstructure:"maxRadius"`
+	} `mapstructure:"nearby"`
 }
 
 type Redis struct {
@@ -352,8 +464,35 @@ type AfterConfig struct {
 
 type Share struct {
 	Secret          string          `mapstructure:"secret"`
+	TokenSigning    TokenSigning    `mapstructure:"tokenSigning"`
 	RpcRegisterName RpcRegisterName `mapstructure:"rpcRegisterName"`
 	IMAdminUserID   []string        `mapstructure:"imAdminUserID"`
+	TLS             RpcTLS          `mapstructure:"tls"`
+}
+
+type RpcTLS struct {
+	Enable         bool     `mapstructure:"enable"`
+	CACrt          string   `mapstructure:"caCrt"`
+	ServerCrt      string   `mapstructure:"serverCrt"`
+	ServerKey      string   `mapstructure:"serverKey"`
+	ClientCrt      string   `mapstructure:"clientCrt"`
+	ClientKey      string   `mapstructure:"clientKey"`
+	AllowedPeers   []string `mapstructure:"allowedPeers"`
+	ReloadInterval int      `mapstructure:"reloadInterval"`
+}
+
+type TokenSigning struct {
+	SigningKeyID      string     `mapstructure:"signingKeyID"`
+	Keys              []TokenKey `mapstructure:"keys"`
+	LegacySecret      bool       `mapstructure:"legacySecret"`
+	LegacySecretUntil int64      `mapstructure:"legacySecretUntil"`
+}
+
+type TokenKey struct {
+	KeyID      string `mapstructure:"kid"`
+	Algorithm  string `mapstructure:"algorithm"`
+	PublicKey  string `mapstructure:"publicKey"`
+	PrivateKey string `mapstructure:"privateKey"`
 }
 type RpcRegisterName struct {
 	User           string `mapstructure:"user"`
@@ -421,7 +560,11 @@ type Webhooks struct {
 	BeforeInviteUserToGroup  BeforeConfig `mapstructure:"beforeInviteUserToGroup"`
 	AfterSetGroupInfo        AfterConfig  `mapstructure:"afterSetGroupInfo"`
 	BeforeSetGroupInfo       BeforeConfig `mapstructure:"beforeSetGroupInfo"`
+	BeforeRevokeMsg          BeforeConfig `mapstructure:"beforeRevokeMsg"`
 	AfterRevokeMsg           AfterConfig  `mapstructure:"afterRevokeMsg"`
+	BeforeMsgEdit            BeforeConfig `mapstructure:"beforeMsgEdit"`
+	AfterMsgEdit             AfterConfig  `mapstructure:"afterMsgEdit"`
+	AfterPushTokenInvalid    AfterConfig  `mapstructure:"afterPushTokenInvalid"`
 	BeforeAddBlack           BeforeConfig `mapstructure:"beforeAddBlack"`
 	AfterAddFriend           AfterConfig  `mapstructure:"afterAddFriend"`
 	BeforeAddFriendAgree     BeforeConfig `mapstructure:"beforeAddFriendAgree"`
@@ -429,6 +572,9 @@ type Webhooks struct {
 	BeforeImportFriends      BeforeConfig `mapstructure:"beforeImportFriends"`
 	AfterImportFriends       AfterConfig  `mapstructure:"afterImportFriends"`
 	AfterRemoveBlack         AfterConfig  `mapstructure:"afterRemoveBlack"`
+	AfterScheduleMsg         AfterConfig  `mapstructure:"afterScheduleMsg"`
+	AfterScheduledMsgDeliver AfterConfig  `mapstructure:"afterScheduledMsgDeliver"`
+	AfterCancelScheduledMsg  AfterConfig  `mapstructure:"afterCancelScheduledMsg"`
 }
 
 type ZooKeeper struct {
@@ -442,6 +588,7 @@ type Discovery struct {
 	Enable    string    `mapstructure:"enable"`
 	Etcd      Etcd      `mapstructure:"etcd"`
 	ZooKeeper ZooKeeper `mapstructure:"zooKeeper"`
+	Direct    Direct    `mapstructure:"direct"`
 }
 
 type Etcd struct {
@@ -451,6 +598,18 @@ type Etcd struct {
 	Password      string   `mapstructure:"password"`
 }
 
+type Direct struct {
+	User           []string `mapstructure:"user"`
+	Friend         []string `mapstructure:"friend"`
+	Msg            []string `mapstructure:"msg"`
+	Push           []string `mapstructure:"push"`
+	MessageGateway []string `mapstructure:"messageGateway"`
+	Group          []string `mapstructure:"group"`
+	Auth           []string `mapstructure:"auth"`
+	Conversation   []string `mapstructure:"conversation"`
+	Third          []string `mapstructure:"third"`
+}
+
 func (m *Mongo) Build() *mongoutil.Config {
 	return &mongoutil.Config{
 		Uri:         m.URI,
@@ -543,6 +702,27 @@ func (o *Kodo) Build() *kodo.Config {
 	}
 }
 
+func (a *Aws) Build() *aws.Config {
+	return &aws.Config{
+		Endpoint:        a.Endpoint,
+		Region:          a.Region,
+		Bucket:          a.Bucket,
+		AccessKeyID:     a.AccessKeyID,
+		AccessKeySecret: a.AccessKeySecret,
+		SessionToken:    a.SessionToken,
+		PublicRead:      a.PublicRead,
+		PathStyle:       a.PathStyle,
+	}
+}
+
+func (l *Local) Build(secret string) *local.Config {
+	return &local.Config{
+		Directory: l.Directory,
+		URL:       l.URL,
+		Secret:    secret,
+	}
+}
+
 func (l *CacheConfig) Failed() time.Duration {
 	return time.Second * time.Duration(l.FailedExpire)
 }
@@ -0,0 +1,112 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"sort"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"google.golang.org/protobuf/proto"
+)
+
+// BroadcastJobPb2DB converts a new job, the listed userIDs are sorted and deduplicated.
+func BroadcastJobPb2DB(req *broadcast.CreateBroadcastReq) (*model.BroadcastJob, error) {
+	msgData, err := proto.Marshal(req.MsgData)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "marshal broadcast msgData")
+	}
+	job := &model.BroadcastJob{
+		MsgData:    msgData,
+		TargetType: req.Target.Type,
+		Rate:       req.Rate,
+	}
+	if len(req.Target.UserIDs) > 0 {
+		job.UserIDs = datautil.Distinct(req.Target.UserIDs)
+		sort.Strings(job.UserIDs)
+		job.Total = int64(len(job.UserIDs))
+	}
+	if req.Target.CreateTimeBegin > 0 {
+		job.CreateTimeBegin = time.UnixMilli(req.Target.CreateTimeBegin)
+	}
+	if req.Target.CreateTimeEnd > 0 {
+		job.CreateTimeEnd = time.UnixMilli(req.Target.CreateTimeEnd)
+	}
+	return job, nil
+}
+
+func BroadcastJobDB2Pb(job *model.BroadcastJob) (*broadcast.BroadcastJob, error) {
+	var msgData sdkws.MsgData
+	if err := proto.Unmarshal(job.MsgData, &msgData); err != nil {
+		return nil, errs.WrapMsg(err, "unmarshal broadcast msgData", "jobID", job.JobID)
+	}
+	return &broadcast.BroadcastJob{
+		JobID:    job.JobID,
+		OpUserID: job.OpUserID,
+		MsgData:  &msgData,
+		Target: &broadcast.BroadcastTarget{
+			Type:            job.TargetType,
+			UserIDs:         job.UserIDs,
+			CreateTimeBegin: unixMilli(job.CreateTimeBegin),
+			CreateTimeEnd:   unixMilli(job.CreateTimeEnd),
+		},
+		Rate:       job.Rate,
+		Status:     job.Status,
+		Cursor:     job.Cursor,
+		Total:      job.Total,
+		Success:    job.Success,
+		Failed:     job.Failed,
+		CreateTime: unixMilli(job.CreateTime),
+		UpdateTime: unixMilli(job.UpdateTime),
+		FinishTime: unixMilli(job.FinishTime),
+	}, nil
+}
+
+func BroadcastJobsDB2Pb(jobs []*model.BroadcastJob) ([]*broadcast.BroadcastJob, error) {
+	pbs := make([]*broadcast.BroadcastJob, 0, len(jobs))
+	for _, job := range jobs {
+		pb, err := BroadcastJobDB2Pb(job)
+		if err != nil {
+			return nil, err
+		}
+		pbs = append(pbs, pb)
+	}
+	return pbs, nil
+}
+
+func BroadcastResultsDB2Pb(results []*model.BroadcastResult) []*broadcast.BroadcastResult {
+	return datautil.Slice(results, func(result *model.BroadcastResult) *broadcast.BroadcastResult {
+		return &broadcast.BroadcastResult{
+			RecvID:      result.RecvID,
+			ClientMsgID: result.ClientMsgID,
+			ServerMsgID: result.ServerMsgID,
+			SendTime:    result.SendTime,
+			ErrCode:     result.ErrCode,
+			ErrMsg:      result.ErrMsg,
+		}
+	})
+}
+
+// unixMilli keeps the zero time as 0.
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
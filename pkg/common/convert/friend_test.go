@@ -0,0 +1,46 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearbyFriendsDB2Pb(t *testing.T) {
+	now := time.Now()
+	friends := []*model.Friend{
+		{OwnerUserID: "owner", FriendUserID: "far", Remark: "far away", CreateTime: now},
+		{OwnerUserID: "owner", FriendUserID: "near", Remark: "next door", CreateTime: now},
+	}
+	users := []*sdkws.UserInfo{
+		{UserID: "near", Nickname: "Near"},
+		{UserID: "stranger", Nickname: "Stranger"},
+		{UserID: "far", Nickname: "Far"},
+	}
+
+	res := NearbyFriendsDB2Pb(friends, users)
+
+	// The distance order of users is kept and users that are not friends are dropped.
+	assert.Len(t, res, 2)
+	assert.Equal(t, "near", res[0].FriendUser.UserID)
+	assert.Equal(t, "next door", res[0].Remark)
+	assert.Equal(t, "far", res[1].FriendUser.UserID)
+	assert.Equal(t, now.Unix(), res[1].CreateTime)
+}
@@ -0,0 +1,62 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
+	"github.com/KyleYe/open-im-tools/errs"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScheduledMsgData decodes the message of a scheduled message.
+func ScheduledMsgData(msg *model.ScheduledMsg) (*sdkws.MsgData, error) {
+	var msgData sdkws.MsgData
+	if err := proto.Unmarshal(msg.MsgData, &msgData); err != nil {
+		return nil, errs.WrapMsg(err, "unmarshal scheduled msgData", "scheduleID", msg.ScheduleID)
+	}
+	return &msgData, nil
+}
+
+func ScheduledMsgDB2Pb(msg *model.ScheduledMsg) (*scheduledmsg.ScheduledMsgInfo, error) {
+	msgData, err := ScheduledMsgData(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &scheduledmsg.ScheduledMsgInfo{
+		ScheduleID:   msg.ScheduleID,
+		MsgData:      msgData,
+		ScheduleTime: unixMilli(msg.ScheduleTime),
+		Status:       msg.Status,
+		ServerMsgID:  msg.ServerMsgID,
+		ErrCode:      msg.ErrCode,
+		ErrMsg:       msg.ErrMsg,
+		CreateTime:   unixMilli(msg.CreateTime),
+		DeliverTime:  unixMilli(msg.DeliverTime),
+	}, nil
+}
+
+func ScheduledMsgsDB2Pb(msgs []*model.ScheduledMsg) ([]*scheduledmsg.ScheduledMsgInfo, error) {
+	pbs := make([]*scheduledmsg.ScheduledMsgInfo, 0, len(msgs))
+	for _, msg := range msgs {
+		pb, err := ScheduledMsgDB2Pb(msg)
+		if err != nil {
+			return nil, err
+		}
+		pbs = append(pbs, pb)
+	}
+	return pbs, nil
+}
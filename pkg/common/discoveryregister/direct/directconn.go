@@ -14,161 +14,210 @@
 
 package direct
 
-//import (
-//	"context"
-//	"fmt"
-//
-//	config2 "github.com/KyleYe/open-im-server/v3/pkg/common/config"
-//	"github.com/KyleYe/open-im-tools/errs"
-//	"google.golang.org/grpc"
-//	"google.golang.org/grpc/credentials/insecure"
-//)
-//
-//type ServiceAddresses map[string][]int
-//
-//func getServiceAddresses(rpcRegisterName *config2.RpcRegisterName,
-//	rpcPort *config2.RpcPort, longConnSvrPort []int) ServiceAddresses {
-//	return ServiceAddresses{
-//		rpcRegisterName.OpenImUserName:           rpcPort.OpenImUserPort,
-//		rpcRegisterName.OpenImFriendName:         rpcPort.OpenImFriendPort,
-//		rpcRegisterName.OpenImMsgName:            rpcPort.OpenImMessagePort,
-//		rpcRegisterName.OpenImMessageGatewayName: longConnSvrPort,
-//		rpcRegisterName.OpenImGroupName:          rpcPort.OpenImGroupPort,
-//		rpcRegisterName.OpenImAuthName:           rpcPort.OpenImAuthPort,
-//		rpcRegisterName.OpenImPushName:           rpcPort.OpenImPushPort,
-//		rpcRegisterName.OpenImConversationName:   rpcPort.OpenImConversationPort,
-//		rpcRegisterName.OpenImThirdName:          rpcPort.OpenImThirdPort,
-//	}
-//}
-//
-//type ConnDirect struct {
-//	additionalOpts        []grpc.DialOption
-//	currentServiceAddress string
-//	conns                 map[string][]*grpc.ClientConn
-//	resolverDirect        *ResolverDirect
-//	config                *config2.GlobalConfig
-//}
-//
-//func (cd *ConnDirect) GetClientLocalConns() map[string][]*grpc.ClientConn {
-//	return nil
-//}
-//
-//func (cd *ConnDirect) GetUserIdHashGatewayHost(ctx context.Context, userId string) (string, error) {
-//	return "", nil
-//}
-//
-//func (cd *ConnDirect) Register(serviceName, host string, port int, opts ...grpc.DialOption) error {
-//	return nil
-//}
-//
-//func (cd *ConnDirect) UnRegister() error {
-//	return nil
-//}
-//
-//func (cd *ConnDirect) CreateRpcRootNodes(serviceNames []string) error {
-//	return nil
-//}
-//
-//func (cd *ConnDirect) RegisterConf2Registry(key string, conf []byte) error {
-//	return nil
-//}
-//
-//func (cd *ConnDirect) GetConfFromRegistry(key string) ([]byte, error) {
-//	return nil, nil
-//}
-//
-//func (cd *ConnDirect) Close() {
-//
-//}
-//
-//func NewConnDirect(config *config2.GlobalConfig) (*ConnDirect, error) {
-//	return &ConnDirect{
-//		conns:          make(map[string][]*grpc.ClientConn),
-//		resolverDirect: NewResolverDirect(),
-//		config:         config,
-//	}, nil
-//}
-//
-//func (cd *ConnDirect) GetConns(ctx context.Context,
-//	serviceName string, opts ...grpc.DialOption) ([]*grpc.ClientConn, error) {
-//
-//	if conns, exists := cd.conns[serviceName]; exists {
-//		return conns, nil
-//	}
-//	ports := getServiceAddresses(&cd.config.RpcRegisterName,
-//		&cd.config.RpcPort, cd.config.LongConnSvr.OpenImMessageGatewayPort)[serviceName]
-//	var connections []*grpc.ClientConn
-//	for _, port := range ports {
-//		conn, err := cd.dialServiceWithoutResolver(ctx, fmt.Sprintf(cd.config.Rpc.ListenIP+":%d", port), append(cd.additionalOpts, opts...)...)
-//		if err != nil {
-//			return nil, errs.Wrap(fmt.Errorf("connect to port %d failed,serviceName %s, IP %s", port, serviceName, cd.config.Rpc.ListenIP))
-//		}
-//		connections = append(connections, conn)
-//	}
-//
-//	if len(connections) == 0 {
-//		return nil, errs.New("no connections found for service", "serviceName", serviceName).Wrap()
-//	}
-//	return connections, nil
-//}
-//
-//func (cd *ConnDirect) GetConn(ctx context.Context, serviceName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-//	// Get service addresses
-//	addresses := getServiceAddresses(&cd.config.RpcRegisterName,
-//		&cd.config.RpcPort, cd.config.LongConnSvr.OpenImMessageGatewayPort)
-//	address, ok := addresses[serviceName]
-//	if !ok {
-//		return nil, errs.New("unknown service name", "serviceName", serviceName).Wrap()
-//	}
-//	var result string
-//	for _, addr := range address {
-//		if result != "" {
-//			result = result + "," + fmt.Sprintf(cd.config.Rpc.ListenIP+":%d", addr)
-//		} else {
-//			result = fmt.Sprintf(cd.config.Rpc.ListenIP+":%d", addr)
-//		}
-//	}
-//	// Try to dial a new connection
-//	conn, err := cd.dialService(ctx, result, append(cd.additionalOpts, opts...)...)
-//	if err != nil {
-//		return nil, errs.WrapMsg(err, "address", result)
-//	}
-//
-//	// Store the new connection
-//	cd.conns[serviceName] = append(cd.conns[serviceName], conn)
-//	return conn, nil
-//}
-//
-//func (cd *ConnDirect) GetSelfConnTarget() string {
-//	return cd.currentServiceAddress
-//}
-//
-//func (cd *ConnDirect) AddOption(opts ...grpc.DialOption) {
-//	cd.additionalOpts = append(cd.additionalOpts, opts...)
-//}
-//
-//func (cd *ConnDirect) CloseConn(conn *grpc.ClientConn) {
-//	if conn != nil {
-//		conn.Close()
-//	}
-//}
-//
-//func (cd *ConnDirect) dialService(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-//	options := append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-//	conn, err := grpc.DialContext(ctx, cd.resolverDirect.Scheme()+":///"+address, options...)
-//
-//	if err != nil {
-//		return nil, errs.WrapMsg(err, "address", address)
-//	}
-//	return conn, nil
-//}
-//
-//func (cd *ConnDirect) dialServiceWithoutResolver(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-//	options := append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-//	conn, err := grpc.DialContext(ctx, address, options...)
-//
-//	if err != nil {
-//		return nil, errs.Wrap(err)
-//	}
-//	return conn, nil
-//}
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/discovery"
+	"github.com/KyleYe/open-im-tools/errs"
+	"google.golang.org/grpc"
+)
+
+type ServiceAddresses map[string][]string
+
+func getServiceAddresses(rpcRegisterName *config.RpcRegisterName, direct *config.Direct) ServiceAddresses {
+	return ServiceAddresses{
+		rpcRegisterName.User:           direct.User,
+		rpcRegisterName.Friend:         direct.Friend,
+		rpcRegisterName.Msg:            direct.Msg,
+		rpcRegisterName.Push:           direct.Push,
+		rpcRegisterName.MessageGateway: direct.MessageGateway,
+		rpcRegisterName.Group:          direct.Group,
+		rpcRegisterName.Auth:           direct.Auth,
+		rpcRegisterName.Conversation:   direct.Conversation,
+		rpcRegisterName.Third:          direct.Third,
+	}
+}
+
+// ConnDirect is a discovery.SvcDiscoveryRegistry over a static list of addresses per service,
+// so no registry such as etcd or zookeeper has to be running.
+type ConnDirect struct {
+	mu          sync.Mutex
+	addresses   ServiceAddresses
+	dialOptions []grpc.DialOption
+	// conns are the connections per address of GetConns, conn the round robin connection of GetConn
+	conns map[string][]*grpc.ClientConn
+	conn  map[string]*grpc.ClientConn
+	// stale are the connections cached before the last AddOption, callers may still hold them until Close
+	stale             []*grpc.ClientConn
+	rpcRegisterTarget string
+}
+
+func NewConnDirect(rpcRegisterName *config.RpcRegisterName, direct *config.Direct) (discovery.SvcDiscoveryRegistry, error) {
+	addresses := getServiceAddresses(rpcRegisterName, direct)
+	for serviceName, address := range addresses {
+		if len(address) == 0 {
+			return nil, errs.New("no direct address for service", "serviceName", serviceName).Wrap()
+		}
+	}
+	return &ConnDirect{
+		addresses: addresses,
+		conns:     make(map[string][]*grpc.ClientConn),
+		conn:      make(map[string]*grpc.ClientConn),
+	}, nil
+}
+
+func (cd *ConnDirect) getAddress(serviceName string) ([]string, error) {
+	address, ok := cd.addresses[serviceName]
+	if !ok {
+		return nil, errs.New("unknown service name", "serviceName", serviceName).Wrap()
+	}
+	return address, nil
+}
+
+// GetConns returns one connection per address of the service, e.g. to reach every message gateway.
+func (cd *ConnDirect) GetConns(ctx context.Context, serviceName string, opts ...grpc.DialOption) ([]*grpc.ClientConn, error) {
+	address, err := cd.getAddress(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	if conns, ok := cd.conns[serviceName]; ok {
+		return conns, nil
+	}
+	conns := make([]*grpc.ClientConn, 0, len(address))
+	for _, addr := range address {
+		conn, err := grpc.DialContext(ctx, addr, append(cd.dialOptions, opts...)...)
+		if err != nil {
+			for _, c := range conns {
+				_ = c.Close()
+			}
+			return nil, errs.WrapMsg(err, "dial failed", "serviceName", serviceName, "address", addr)
+		}
+		conns = append(conns, conn)
+	}
+	cd.conns[serviceName] = conns
+	return conns, nil
+}
+
+// GetConn returns the connection balancing the calls round robin over all addresses of the service.
+// The connection is shared, a new one is only dialed for the call when opts are given.
+func (cd *ConnDirect) GetConn(ctx context.Context, serviceName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	address, err := cd.getAddress(serviceName)
+	if err != nil {
+		return nil, err
+	}
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	if conn, ok := cd.conn[serviceName]; ok && len(opts) == 0 {
+		return conn, nil
+	}
+	target := fmt.Sprintf("%s:///%s", scheme, strings.Join(address, string(EndpointSepChar)))
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithDefaultServiceConfig(`{"LoadBalancingPolicy": "round_robin"}`),
+	}, cd.dialOptions...)
+	conn, err := grpc.DialContext(ctx, target, append(dialOptions, opts...)...)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "dial failed", "serviceName", serviceName, "target", target)
+	}
+	if len(opts) == 0 {
+		cd.conn[serviceName] = conn
+	}
+	return conn, nil
+}
+
+func (cd *ConnDirect) GetSelfConnTarget() string {
+	return cd.rpcRegisterTarget
+}
+
+// AddOption adds dial options to every connection dialed afterwards,
+// including the transport credentials from rpctls that the services add on start.
+// The cached connections are dialed again, the old ones stay open for their holders until Close.
+func (cd *ConnDirect) AddOption(opts ...grpc.DialOption) {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	for _, conns := range cd.conns {
+		cd.stale = append(cd.stale, conns...)
+	}
+	for _, conn := range cd.conn {
+		cd.stale = append(cd.stale, conn)
+	}
+	cd.conns = make(map[string][]*grpc.ClientConn)
+	cd.conn = make(map[string]*grpc.ClientConn)
+	cd.dialOptions = append(cd.dialOptions, opts...)
+}
+
+// CloseConn closes a connection dialed for the caller, the shared connections are only closed by Close.
+func (cd *ConnDirect) CloseConn(conn *grpc.ClientConn) {
+	if conn == nil {
+		return
+	}
+	cd.mu.Lock()
+	shared := cd.isShared(conn)
+	cd.mu.Unlock()
+	if !shared {
+		_ = conn.Close()
+	}
+}
+
+func (cd *ConnDirect) isShared(conn *grpc.ClientConn) bool {
+	for _, c := range cd.conn {
+		if c == conn {
+			return true
+		}
+	}
+	for _, conns := range cd.conns {
+		for _, c := range conns {
+			if c == conn {
+				return true
+			}
+		}
+	}
+	for _, c := range cd.stale {
+		if c == conn {
+			return true
+		}
+	}
+	return false
+}
+
+// Register only remembers the own address, the addresses of all services are configured statically.
+func (cd *ConnDirect) Register(serviceName, host string, port int, opts ...grpc.DialOption) error {
+	cd.rpcRegisterTarget = fmt.Sprintf("%s:%d", host, port)
+	return nil
+}
+
+func (cd *ConnDirect) UnRegister() error {
+	return nil
+}
+
+func (cd *ConnDirect) Close() {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	cd.closeConns()
+}
+
+func (cd *ConnDirect) GetUserIdHashGatewayHost(ctx context.Context, userId string) (string, error) {
+	return "", nil
+}
+
+func (cd *ConnDirect) closeConns() {
+	for _, conns := range cd.conns {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}
+	for _, conn := range cd.conn {
+		_ = conn.Close()
+	}
+	for _, conn := range cd.stale {
+		_ = conn.Close()
+	}
+	cd.conns = make(map[string][]*grpc.ClientConn)
+	cd.conn = make(map[string]*grpc.ClientConn)
+	cd.stale = nil
+}
@@ -0,0 +1,189 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package direct
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func startServer(t *testing.T, calls *int32, opts ...grpc.ServerOption) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(append(opts, grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		atomic.AddInt32(calls, 1)
+		return handler(ctx, req)
+	}))...)
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+	return listener.Addr().String()
+}
+
+// serverCreds returns TLS credentials with a self-signed certificate.
+func serverCreds(t *testing.T) credentials.TransportCredentials {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}})
+}
+
+func newTestConnDirect(t *testing.T, gateway []string, opts ...grpc.DialOption) *ConnDirect {
+	names := &config.RpcRegisterName{User: "user", Friend: "friend", Msg: "msg", Push: "push", MessageGateway: "messageGateway",
+		Group: "group", Auth: "auth", Conversation: "conversation", Third: "third"}
+	addr := []string{"127.0.0.1:1"}
+	direct := &config.Direct{User: gateway, Friend: addr, Msg: addr, Push: addr, MessageGateway: gateway,
+		Group: addr, Auth: addr, Conversation: addr, Third: addr}
+	disCov, err := NewConnDirect(names, direct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	disCov.AddOption(opts...)
+	t.Cleanup(disCov.Close)
+	return disCov.(*ConnDirect)
+}
+
+func TestNewConnDirectMissingAddress(t *testing.T) {
+	_, err := NewConnDirect(&config.RpcRegisterName{User: "user"}, &config.Direct{})
+	assert.Error(t, err)
+}
+
+func TestGetConnRoundRobin(t *testing.T) {
+	var calls1, calls2 int32
+	cd := newTestConnDirect(t, []string{startServer(t, &calls1), startServer(t, &calls2)})
+
+	conn, err := cd.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	defer cd.CloseConn(conn)
+	client := grpc_health_v1.NewHealthClient(conn)
+	for i := 0; i < 10; i++ {
+		_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(10), calls1+calls2)
+	assert.NotZero(t, calls1)
+	assert.NotZero(t, calls2)
+
+	_, err = cd.GetConn(context.Background(), "unknown")
+	assert.Error(t, err)
+}
+
+func TestGetConnShared(t *testing.T) {
+	var calls int32
+	cd := newTestConnDirect(t, []string{startServer(t, &calls)})
+
+	conn, err := cd.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	again, err := cd.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	assert.Same(t, conn, again)
+	// the shared connection is not closed for a single holder
+	cd.CloseConn(again)
+	assert.NotEqual(t, connectivity.Shutdown, conn.GetState())
+
+	// a connection held while options are added keeps working
+	cd.AddOption(grpc.WithUserAgent("test"))
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	redialed, err := cd.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	assert.NotSame(t, conn, redialed)
+
+	cd.Close()
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+	assert.Equal(t, connectivity.Shutdown, redialed.GetState())
+}
+
+func TestGetConns(t *testing.T) {
+	var calls1, calls2 int32
+	gateway := []string{startServer(t, &calls1), startServer(t, &calls2)}
+	cd := newTestConnDirect(t, gateway)
+
+	conns, err := cd.GetConns(context.Background(), "messageGateway")
+	assert.NoError(t, err)
+	if assert.Len(t, conns, 2) {
+		for i, conn := range conns {
+			assert.Equal(t, gateway[i], conn.Target())
+			_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+			assert.NoError(t, err)
+		}
+	}
+	assert.Equal(t, int32(1), calls1)
+	assert.Equal(t, int32(1), calls2)
+
+	again, err := cd.GetConns(context.Background(), "messageGateway")
+	assert.NoError(t, err)
+	assert.Equal(t, conns, again)
+
+	assert.NoError(t, cd.Register("messageGateway", "127.0.0.1", 10140))
+	assert.Equal(t, "127.0.0.1:10140", cd.GetSelfConnTarget())
+}
+
+func TestDialOptionsCarryTransportCredentials(t *testing.T) {
+	var calls int32
+	addr := startServer(t, &calls, grpc.Creds(serverCreds(t)))
+	tlsCreds := grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true}))
+
+	cd := newTestConnDirect(t, []string{addr}, tlsCreds)
+	conn, err := cd.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	conns, err := cd.GetConns(context.Background(), "messageGateway")
+	assert.NoError(t, err)
+	_, err = grpc_health_v1.NewHealthClient(conns[0]).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls)
+
+	// A plaintext client can not talk to the TLS server.
+	plain := newTestConnDirect(t, []string{addr})
+	conn, err = plain.GetConn(context.Background(), "user")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), calls)
+}
@@ -18,7 +18,9 @@ import (
 	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister/direct"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister/kubernetes"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister/standalone"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/discovery/etcd"
 	"github.com/KyleYe/open-im-tools/discovery/zookeeper"
@@ -46,6 +48,10 @@ func NewDiscoveryRegister(discovery *config.Discovery, share *config.Share) (dis
 			etcd.WithDialTimeout(10*time.Second),
 			etcd.WithMaxCallSendMsgSize(20*1024*1024),
 			etcd.WithUsernameAndPassword(discovery.Etcd.Username, discovery.Etcd.Password))
+	case "direct":
+		return direct.NewConnDirect(&share.RpcRegisterName, &discovery.Direct)
+	case "standalone":
+		return standalone.NewSvcDiscoveryRegistry(), nil
 	default:
 		return nil, errs.New("unsupported discovery type", "type", discovery.Enable).Wrap()
 	}
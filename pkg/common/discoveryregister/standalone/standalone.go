@@ -0,0 +1,240 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standalone
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/KyleYe/open-im-tools/discovery"
+	"github.com/KyleYe/open-im-tools/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1024 * 1024
+
+// registry holds the in-memory listeners of the services running in this process.
+type registry struct {
+	mu        sync.Mutex
+	listeners map[string]*bufconn.Listener
+	ready     map[string]chan struct{}
+}
+
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	return &registry{
+		listeners: make(map[string]*bufconn.Listener),
+		ready:     make(map[string]chan struct{}),
+	}
+}
+
+func (r *registry) readyChan(serviceName string) chan struct{} {
+	ch, ok := r.ready[serviceName]
+	if !ok {
+		ch = make(chan struct{})
+		r.ready[serviceName] = ch
+	}
+	return ch
+}
+
+func (r *registry) listen(serviceName string) (net.Listener, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.listeners[serviceName]; ok {
+		return nil, errs.New("service already listening", "serviceName", serviceName).Wrap()
+	}
+	listener := bufconn.Listen(bufSize)
+	r.listeners[serviceName] = listener
+	return listener, nil
+}
+
+func (r *registry) register(serviceName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.listeners[serviceName]; !ok {
+		return errs.New("service is not listening", "serviceName", serviceName).Wrap()
+	}
+	ch := r.readyChan(serviceName)
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+	return nil
+}
+
+func (r *registry) registered(serviceName string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.readyChan(serviceName):
+		return true
+	default:
+		return false
+	}
+}
+
+// wait blocks until the service registered and returns its listener.
+func (r *registry) wait(ctx context.Context, serviceName string) (*bufconn.Listener, error) {
+	r.mu.Lock()
+	ch := r.readyChan(serviceName)
+	r.mu.Unlock()
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		return nil, errs.WrapMsg(ctx.Err(), "service not registered", "serviceName", serviceName)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.listeners[serviceName], nil
+}
+
+// Listen returns the in-memory listener the rpc server of the service serves on.
+func Listen(serviceName string) (net.Listener, error) {
+	return defaultRegistry.listen(serviceName)
+}
+
+// WaitRegistered blocks until all services registered, the services start in this order.
+func WaitRegistered(ctx context.Context, serviceNames ...string) error {
+	for _, serviceName := range serviceNames {
+		if _, err := defaultRegistry.wait(ctx, serviceName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SvcDiscoveryRegistry connects the services of one process through in-memory connections,
+// a connection to a service that has not registered yet waits until it has.
+type SvcDiscoveryRegistry struct {
+	registry          *registry
+	mu                sync.Mutex
+	dialOptions       []grpc.DialOption
+	conns             map[string]*grpc.ClientConn
+	rpcRegisterTarget string
+}
+
+func NewSvcDiscoveryRegistry() discovery.SvcDiscoveryRegistry {
+	return newSvcDiscoveryRegistry(defaultRegistry)
+}
+
+func newSvcDiscoveryRegistry(r *registry) *SvcDiscoveryRegistry {
+	return &SvcDiscoveryRegistry{
+		registry: r,
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+func target(serviceName string) string {
+	return "passthrough:///" + serviceName
+}
+
+func (s *SvcDiscoveryRegistry) dial(ctx context.Context, serviceName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	s.mu.Lock()
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, serviceName string) (net.Conn, error) {
+			listener, err := s.registry.wait(ctx, serviceName)
+			if err != nil {
+				return nil, err
+			}
+			return listener.DialContext(ctx)
+		}),
+	}, s.dialOptions...)
+	s.mu.Unlock()
+	conn, err := grpc.DialContext(ctx, target(serviceName), append(dialOptions, opts...)...)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "dial failed", "serviceName", serviceName)
+	}
+	return conn, nil
+}
+
+// GetConns returns the connection to the service once it registered, every service runs once in this process.
+func (s *SvcDiscoveryRegistry) GetConns(ctx context.Context, serviceName string, opts ...grpc.DialOption) ([]*grpc.ClientConn, error) {
+	if !s.registry.registered(serviceName) {
+		return nil, nil
+	}
+	s.mu.Lock()
+	conn, ok := s.conns[serviceName]
+	s.mu.Unlock()
+	if ok {
+		return []*grpc.ClientConn{conn}, nil
+	}
+	conn, err := s.dial(ctx, serviceName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.conns[serviceName]; ok {
+		_ = conn.Close()
+		return []*grpc.ClientConn{c}, nil
+	}
+	s.conns[serviceName] = conn
+	return []*grpc.ClientConn{conn}, nil
+}
+
+func (s *SvcDiscoveryRegistry) GetConn(ctx context.Context, serviceName string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return s.dial(ctx, serviceName, opts...)
+}
+
+func (s *SvcDiscoveryRegistry) GetSelfConnTarget() string {
+	return s.rpcRegisterTarget
+}
+
+func (s *SvcDiscoveryRegistry) AddOption(opts ...grpc.DialOption) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConns()
+	s.dialOptions = append(s.dialOptions, opts...)
+}
+
+func (s *SvcDiscoveryRegistry) CloseConn(conn *grpc.ClientConn) {
+	if conn != nil {
+		_ = conn.Close()
+	}
+}
+
+// Register marks the service ready, its server must serve on the listener returned by Listen.
+func (s *SvcDiscoveryRegistry) Register(serviceName, host string, port int, opts ...grpc.DialOption) error {
+	if err := s.registry.register(serviceName); err != nil {
+		return err
+	}
+	s.rpcRegisterTarget = target(serviceName)
+	return nil
+}
+
+func (s *SvcDiscoveryRegistry) UnRegister() error {
+	return nil
+}
+
+func (s *SvcDiscoveryRegistry) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConns()
+}
+
+func (s *SvcDiscoveryRegistry) GetUserIdHashGatewayHost(ctx context.Context, userId string) (string, error) {
+	return "", nil
+}
+
+func (s *SvcDiscoveryRegistry) closeConns() {
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = make(map[string]*grpc.ClientConn)
+}
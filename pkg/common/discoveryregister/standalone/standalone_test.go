@@ -0,0 +1,92 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package standalone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestSvcDiscoveryRegistry(t *testing.T) {
+	r := newRegistry()
+	client := newSvcDiscoveryRegistry(r)
+	client.AddOption(grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer client.Close()
+	ctx := context.Background()
+
+	conns, err := client.GetConns(ctx, "msg")
+	assert.NoError(t, err)
+	assert.Empty(t, conns)
+
+	// The connection is made before the service registered and is used once it has.
+	conn, err := client.GetConn(ctx, "msg")
+	assert.NoError(t, err)
+	defer client.CloseConn(conn)
+	checked := make(chan error, 1)
+	go func() {
+		_, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.WaitForReady(true))
+		checked <- err
+	}()
+
+	listener, err := r.listen("msg")
+	assert.NoError(t, err)
+	_, err = r.listen("msg")
+	assert.Error(t, err)
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(listener) }()
+	defer srv.Stop()
+
+	server := newSvcDiscoveryRegistry(r)
+	assert.Error(t, server.Register("push", "", 0))
+	assert.NoError(t, server.Register("msg", "", 0))
+
+	select {
+	case err := <-checked:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("rpc not served after register")
+	}
+
+	conns, err = client.GetConns(ctx, "msg")
+	assert.NoError(t, err)
+	if assert.Len(t, conns, 1) {
+		assert.Equal(t, server.GetSelfConnTarget(), conns[0].Target())
+		_, err = grpc_health_v1.NewHealthClient(conns[0]).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		assert.NoError(t, err)
+	}
+}
+
+func TestWaitRegistered(t *testing.T) {
+	r := newRegistry()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := r.wait(ctx, "user")
+	assert.Error(t, err)
+
+	_, err = r.listen("user")
+	assert.NoError(t, err)
+	assert.NoError(t, r.register("user"))
+	listener, err := r.wait(context.Background(), "user")
+	assert.NoError(t, err)
+	assert.NotNil(t, listener)
+}
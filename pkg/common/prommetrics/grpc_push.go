@@ -23,4 +23,16 @@ var (
 		Name: "msg_offline_push_failed_total",
 		Help: "The number of msg failed offline pushed",
 	})
+	MsgOfflinePushRetrySuccessCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_offline_push_retry_success_total",
+		Help: "The number of failed offline pushes that succeeded on retry",
+	}, []string{"provider"})
+	MsgOfflinePushRetryAbandonedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_offline_push_retry_abandoned_total",
+		Help: "The number of failed offline pushes abandoned after their maximum age",
+	}, []string{"provider"})
+	MsgOfflinePushInvalidTokenCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "msg_offline_push_invalid_token_total",
+		Help: "The number of device tokens the offline push providers reported invalid",
+	}, []string{"provider"})
 )
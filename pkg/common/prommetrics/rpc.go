@@ -49,11 +49,13 @@ func GetGrpcCusMetrics(registerName string, share *config.Share) []prometheus.Co
 	case share.RpcRegisterName.Msg:
 		return []prometheus.Collector{SingleChatMsgProcessSuccessCounter, SingleChatMsgProcessFailedCounter, GroupChatMsgProcessSuccessCounter, GroupChatMsgProcessFailedCounter}
 	case share.RpcRegisterName.Push:
-		return []prometheus.Collector{MsgOfflinePushFailedCounter}
+		return []prometheus.Collector{MsgOfflinePushFailedCounter, MsgOfflinePushRetrySuccessCounter, MsgOfflinePushRetryAbandonedCounter, MsgOfflinePushInvalidTokenCounter}
 	case share.RpcRegisterName.Auth:
 		return []prometheus.Collector{UserLoginCounter}
 	case share.RpcRegisterName.User:
 		return []prometheus.Collector{UserRegisterCounter}
+	case share.RpcRegisterName.Friend, share.RpcRegisterName.Group, share.RpcRegisterName.Conversation:
+		return []prometheus.Collector{VersionLogCompactedDocCounter, VersionLogCompactedLogCounter}
 	default:
 		return nil
 	}
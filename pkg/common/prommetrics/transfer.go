@@ -40,6 +40,10 @@ var (
 		Name: "seq_set_failed_total",
 		Help: "The number of failed set seq",
 	})
+	MsgDeadLetterCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "msg_dead_letter_total",
+		Help: "The number of records sent to the dead letter topic",
+	})
 )
 
 func TransferInit(prometheusPort int) error {
@@ -51,6 +55,7 @@ func TransferInit(prometheusPort int) error {
 		MsgInsertMongoSuccessCounter,
 		MsgInsertMongoFailedCounter,
 		SeqSetFailedCounter,
+		MsgDeadLetterCounter,
 	)
 	return Init(reg, prometheusPort, commonPath, promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}), cs...)
 }
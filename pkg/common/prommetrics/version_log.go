@@ -0,0 +1,33 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prommetrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	VersionLogCompactedDocCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "version_log_compacted_doc_total",
+		Help: "The number of version log docs whose unchanged logs were compacted",
+	}, []string{"collection"})
+	VersionLogCompactedLogCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "version_log_compacted_log_total",
+		Help: "The number of version logs removed by the compaction",
+	}, []string{"collection"})
+)
+
+func VersionLogCompacted(collection string, docs int64, logs int64) {
+	VersionLogCompactedDocCounter.WithLabelValues(collection).Add(float64(docs))
+	VersionLogCompactedLogCounter.WithLabelValues(collection).Add(float64(logs))
+}
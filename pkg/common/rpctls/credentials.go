@@ -0,0 +1,244 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpctls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Credentials are the mutual TLS credentials of the gRPC servers and clients of a service.
+// The certificates are read from disk again when their files change.
+type Credentials struct {
+	conf     *config.RpcTLS
+	interval time.Duration
+
+	lock      sync.Mutex
+	checkTime time.Time
+	modTimes  map[string]time.Time
+	certs     *certs
+}
+
+type certs struct {
+	pool   *x509.CertPool
+	server *tls.Certificate
+	client *tls.Certificate
+}
+
+// New loads the certificates configured in conf. It returns nil when TLS is disabled,
+// the options of nil Credentials use plaintext connections.
+func New(conf *config.RpcTLS) (*Credentials, error) {
+	if !conf.Enable {
+		return nil, nil
+	}
+	if conf.CACrt == "" || conf.ServerCrt == "" || conf.ServerKey == "" {
+		return nil, errs.New("tls requires caCrt, serverCrt and serverKey").Wrap()
+	}
+	c := &Credentials{
+		conf:     conf,
+		interval: time.Duration(conf.ReloadInterval) * time.Second,
+	}
+	modTimes, err := c.stat()
+	if err != nil {
+		return nil, err
+	}
+	certs, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.checkTime = time.Now()
+	c.modTimes = modTimes
+	c.certs = certs
+	return c, nil
+}
+
+// ServerOptions returns the options that make a gRPC server require a client certificate signed by the CA.
+func (c *Credentials) ServerOptions() []grpc.ServerOption {
+	if c == nil {
+		return nil
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(c.ServerConfig()))}
+}
+
+// DialOption returns the transport credentials of the gRPC clients.
+func (c *Credentials) DialOption() grpc.DialOption {
+	if c == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(c.ClientConfig()))
+}
+
+func (c *Credentials) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAnyClientCert,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.get().server, nil
+		},
+		VerifyPeerCertificate: c.verifyPeer(x509.ExtKeyUsageClientAuth),
+	}
+}
+
+func (c *Credentials) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Peers are dialed by address, the chain and the peer names are checked by VerifyPeerCertificate instead.
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return c.get().client, nil
+		},
+		VerifyPeerCertificate: c.verifyPeer(x509.ExtKeyUsageServerAuth),
+	}
+}
+
+func (c *Credentials) verifyPeer(usage x509.ExtKeyUsage) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errs.New("peer presented no certificate")
+		}
+		peerCerts := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return errs.WrapMsg(err, "parse peer certificate failed")
+			}
+			peerCerts = append(peerCerts, cert)
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range peerCerts[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := peerCerts[0].Verify(x509.VerifyOptions{
+			Roots:         c.get().pool,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{usage},
+		})
+		if err != nil {
+			return errs.WrapMsg(err, "verify peer certificate failed")
+		}
+		return checkPeerName(peerCerts[0], c.conf.AllowedPeers)
+	}
+}
+
+// checkPeerName checks that the certificate carries one of the allowed names as a URI or DNS subject alternative name.
+func checkPeerName(cert *x509.Certificate, allowedPeers []string) error {
+	if len(allowedPeers) == 0 {
+		return nil
+	}
+	for _, name := range allowedPeers {
+		for _, uri := range cert.URIs {
+			if uri.String() == name {
+				return nil
+			}
+		}
+		for _, dns := range cert.DNSNames {
+			if dns == name {
+				return nil
+			}
+		}
+	}
+	return errs.New("peer certificate name is not allowed", "uris", cert.URIs, "dnsNames", cert.DNSNames)
+}
+
+// get returns the current certificates, reloading them when their files changed since the last check.
+func (c *Credentials) get() *certs {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.interval <= 0 || time.Since(c.checkTime) < c.interval {
+		return c.certs
+	}
+	c.checkTime = time.Now()
+	modTimes, err := c.stat()
+	if err != nil {
+		log.ZWarn(context.Background(), "stat tls files failed, keep the loaded certificates", err)
+		return c.certs
+	}
+	if !changed(c.modTimes, modTimes) {
+		return c.certs
+	}
+	certs, err := c.load()
+	if err != nil {
+		log.ZWarn(context.Background(), "reload tls certificates failed, keep the loaded certificates", err)
+		return c.certs
+	}
+	log.ZInfo(context.Background(), "tls certificates reloaded")
+	c.modTimes = modTimes
+	c.certs = certs
+	return c.certs
+}
+
+func (c *Credentials) files() []string {
+	files := []string{c.conf.CACrt, c.conf.ServerCrt, c.conf.ServerKey}
+	if c.conf.ClientCrt != "" {
+		files = append(files, c.conf.ClientCrt, c.conf.ClientKey)
+	}
+	return files
+}
+
+func (c *Credentials) stat() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range c.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "stat tls file failed", "file", file)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func changed(old, cur map[string]time.Time) bool {
+	for file, modTime := range cur {
+		if !old[file].Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Credentials) load() (*certs, error) {
+	caPEM, err := os.ReadFile(c.conf.CACrt)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "read caCrt failed", "file", c.conf.CACrt)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errs.New("caCrt contains no certificate", "file", c.conf.CACrt).Wrap()
+	}
+	server, err := tls.LoadX509KeyPair(c.conf.ServerCrt, c.conf.ServerKey)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "load server certificate failed", "crt", c.conf.ServerCrt, "key", c.conf.ServerKey)
+	}
+	client := &server
+	if c.conf.ClientCrt != "" {
+		cert, err := tls.LoadX509KeyPair(c.conf.ClientCrt, c.conf.ClientKey)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "load client certificate failed", "crt", c.conf.ClientCrt, "key", c.conf.ClientKey)
+		}
+		client = &cert
+	}
+	return &certs{pool: pool, server: &server, client: client}, nil
+}
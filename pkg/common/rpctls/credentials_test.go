@@ -0,0 +1,167 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpctls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "openim test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue writes a certificate for the SPIFFE ID spiffe://openim/<name> and its key to dir.
+func (ca *testCA) issue(t *testing.T, dir string, name string, serial int64) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{{Scheme: "spiffe", Host: "openim", Path: "/" + name}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	crt := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	assert.NoError(t, os.WriteFile(crt, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return crt, keyFile
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	file := filepath.Join(dir, "ca.crt")
+	assert.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0o600))
+	return file
+}
+
+func handshake(t *testing.T, server *Credentials, client *Credentials) error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	errCh := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer conn.Close()
+		errCh <- tls.Server(conn, server.ServerConfig()).Handshake()
+	}()
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	assert.NoError(t, err)
+	defer conn.Close()
+	clientErr := tls.Client(conn, client.ClientConfig()).Handshake()
+	// with TLS 1.3 the client completes its handshake before the server verifies the client certificate
+	serverErr := <-errCh
+	if clientErr != nil {
+		return clientErr
+	}
+	return serverErr
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caCrt := ca.write(t, dir)
+	msgCrt, msgKey := ca.issue(t, dir, "msg", 2)
+	apiCrt, apiKey := ca.issue(t, dir, "api", 3)
+
+	server, err := New(&config.RpcTLS{Enable: true, CACrt: caCrt, ServerCrt: msgCrt, ServerKey: msgKey,
+		AllowedPeers: []string{"spiffe://openim/api"}})
+	assert.NoError(t, err)
+	client, err := New(&config.RpcTLS{Enable: true, CACrt: caCrt, ServerCrt: apiCrt, ServerKey: apiKey,
+		AllowedPeers: []string{"spiffe://openim/msg"}})
+	assert.NoError(t, err)
+	assert.NoError(t, handshake(t, server, client))
+
+	// msg is not an allowed client of the server
+	self, err := New(&config.RpcTLS{Enable: true, CACrt: caCrt, ServerCrt: msgCrt, ServerKey: msgKey})
+	assert.NoError(t, err)
+	assert.Error(t, handshake(t, server, self))
+
+	// certificates signed by another CA are rejected
+	otherDir := t.TempDir()
+	other := newTestCA(t)
+	otherCrt, otherKey := other.issue(t, otherDir, "api", 4)
+	untrusted, err := New(&config.RpcTLS{Enable: true, CACrt: other.write(t, otherDir), ServerCrt: otherCrt, ServerKey: otherKey})
+	assert.NoError(t, err)
+	assert.Error(t, handshake(t, server, untrusted))
+
+	disabled, err := New(&config.RpcTLS{})
+	assert.NoError(t, err)
+	assert.Nil(t, disabled)
+	assert.Nil(t, disabled.ServerOptions())
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caCrt := ca.write(t, dir)
+	crt, key := ca.issue(t, dir, "msg", 2)
+	c, err := New(&config.RpcTLS{Enable: true, CACrt: caCrt, ServerCrt: crt, ServerKey: key, ReloadInterval: 1})
+	assert.NoError(t, err)
+	before := c.get().server
+
+	ca.issue(t, dir, "msg", 5)
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(crt, future, future))
+	c.checkTime = time.Now().Add(-time.Minute)
+	after := c.get().server
+	assert.NotEqual(t, before.Certificate[0], after.Certificate[0])
+	assert.Same(t, after, c.get().server)
+}
@@ -74,6 +74,13 @@ const (
 	MutedInGroup          = 1402 // Member muted in the group
 	MutedGroup            = 1403 // Group is muted
 	MsgAlreadyRevoke      = 1404 // Message already revoked
+	MsgRejected           = 1405 // Message rejected by an interceptor
+	MsgSending            = 1406 // A message with the same clientMsgID is still being sent
+	MsgRevokeExpired      = 1407 // Message is older than the revoke window
+	MsgRevokeForbidden    = 1408 // Revoke policy does not allow revoking the message
+	MsgEditExpired        = 1409 // Message is older than the edit window
+	MsgReactionLimit      = 1410 // Message has the maximum number of distinct reaction emojis
+	MsgNotReceived        = 1411 // Recipient does not receive messages
 
 	// Token error codes.
 	TokenExpiredError     = 1501
@@ -85,10 +92,11 @@ const (
 	TokenNotExistError    = 1507
 
 	// Long connection gateway error codes.
-	ConnOverMaxNumLimit  = 1601
-	ConnArgsErr          = 1602
-	PushMsgErr           = 1603
-	IOSBackgroundPushErr = 1604
+	ConnOverMaxNumLimit      = 1601
+	ConnArgsErr              = 1602
+	PushMsgErr               = 1603
+	IOSBackgroundPushErr     = 1604
+	WebPushSubscriptionLimit = 1605 // User has the maximum number of web push subscriptions
 
 	// S3 error codes.
 	FileUploadedExpiredError = 1701 // Upload expired
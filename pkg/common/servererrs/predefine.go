@@ -54,15 +54,23 @@ var (
 	ErrNotPeersFriend      = errs.NewCodeError(NotPeersFriend, "NotPeersFriend")
 	ErrRelationshipAlready = errs.NewCodeError(RelationshipAlreadyError, "RelationshipAlreadyError")
 
-	ErrMutedInGroup     = errs.NewCodeError(MutedInGroup, "MutedInGroup")
-	ErrMutedGroup       = errs.NewCodeError(MutedGroup, "MutedGroup")
-	ErrMsgAlreadyRevoke = errs.NewCodeError(MsgAlreadyRevoke, "MsgAlreadyRevoke")
+	ErrMutedInGroup       = errs.NewCodeError(MutedInGroup, "MutedInGroup")
+	ErrMutedGroup         = errs.NewCodeError(MutedGroup, "MutedGroup")
+	ErrMsgAlreadyRevoke   = errs.NewCodeError(MsgAlreadyRevoke, "MsgAlreadyRevoke")
+	ErrMsgRejected        = errs.NewCodeError(MsgRejected, "MsgRejected")
+	ErrMsgSending         = errs.NewCodeError(MsgSending, "MsgSending")
+	ErrMsgRevokeExpired   = errs.NewCodeError(MsgRevokeExpired, "MsgRevokeExpired")
+	ErrMsgRevokeForbidden = errs.NewCodeError(MsgRevokeForbidden, "MsgRevokeForbidden")
+	ErrMsgEditExpired     = errs.NewCodeError(MsgEditExpired, "MsgEditExpired")
+	ErrMsgReactionLimit   = errs.NewCodeError(MsgReactionLimit, "MsgReactionLimit")
+	ErrMsgNotReceived     = errs.NewCodeError(MsgNotReceived, "MsgNotReceived")
 
 	ErrConnOverMaxNumLimit = errs.NewCodeError(ConnOverMaxNumLimit, "ConnOverMaxNumLimit")
 
-	ErrConnArgsErr          = errs.NewCodeError(ConnArgsErr, "args err, need token, sendID, platformID")
-	ErrPushMsgErr           = errs.NewCodeError(PushMsgErr, "push msg err")
-	ErrIOSBackgroundPushErr = errs.NewCodeError(IOSBackgroundPushErr, "ios background push err")
+	ErrConnArgsErr              = errs.NewCodeError(ConnArgsErr, "args err, need token, sendID, platformID")
+	ErrPushMsgErr               = errs.NewCodeError(PushMsgErr, "push msg err")
+	ErrIOSBackgroundPushErr     = errs.NewCodeError(IOSBackgroundPushErr, "ios background push err")
+	ErrWebPushSubscriptionLimit = errs.NewCodeError(WebPushSubscriptionLimit, "WebPushSubscriptionLimit")
 
 	ErrFileUploadedExpired = errs.NewCodeError(FileUploadedExpiredError, "FileUploadedExpiredError")
 )
@@ -31,7 +31,9 @@ import (
 	"google.golang.org/grpc/status"
 
 	kdisc "github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister/standalone"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/rpctls"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
@@ -39,7 +41,6 @@ import (
 	"github.com/KyleYe/open-im-tools/system/program"
 	"github.com/KyleYe/open-im-tools/utils/network"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Start rpc server.
@@ -55,22 +56,23 @@ func Start[T any](ctx context.Context, discovery *config.Discovery, prometheusCo
 	log.CInfo(ctx, "RPC server is initializing", "rpcRegisterName", rpcRegisterName, "rpcPort", rpcPort,
 		"prometheusPorts", prometheusConfig.Ports)
 	rpcTcpAddr := net.JoinHostPort(network.GetListenIP(listenIP), strconv.Itoa(rpcPort))
-	listener, err := net.Listen(
-		"tcp",
-		rpcTcpAddr,
-	)
+	listener, err := listen(discovery, rpcRegisterName, rpcTcpAddr)
 	if err != nil {
-		return errs.WrapMsg(err, "listen err", "rpcTcpAddr", rpcTcpAddr)
+		return err
 	}
 
 	defer listener.Close()
+	creds, err := rpctls.New(&share.TLS)
+	if err != nil {
+		return err
+	}
 	client, err := kdisc.NewDiscoveryRegister(discovery, share)
 	if err != nil {
 		return err
 	}
 
 	defer client.Close()
-	client.AddOption(mw.GrpcClient(), grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, "round_robin")))
+	client.AddOption(mw.GrpcClient(), creds.DialOption(), grpc.WithDefaultServiceConfig(fmt.Sprintf(`{"LoadBalancingPolicy": "%s"}`, "round_robin")))
 	registerIP, err = network.GetRpcRegisterIP(registerIP)
 	if err != nil {
 		return err
@@ -92,6 +94,7 @@ func Start[T any](ctx context.Context, discovery *config.Discovery, prometheusCo
 		options = append(options, mw.GrpcServer())
 	}
 
+	options = append(options, creds.ServerOptions()...)
 	srv := grpc.NewServer(options...)
 	once := sync.Once{}
 	defer func() {
@@ -107,7 +110,7 @@ func Start[T any](ctx context.Context, discovery *config.Discovery, prometheusCo
 		rpcRegisterName,
 		registerIP,
 		rpcPort,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		creds.DialOption(),
 	)
 	if err != nil {
 		return err
@@ -171,6 +174,18 @@ func Start[T any](ctx context.Context, discovery *config.Discovery, prometheusCo
 	}
 }
 
+// listen serves in memory when all services run in one process.
+func listen(discovery *config.Discovery, rpcRegisterName, rpcTcpAddr string) (net.Listener, error) {
+	if discovery.Enable == "standalone" {
+		return standalone.Listen(rpcRegisterName)
+	}
+	listener, err := net.Listen("tcp", rpcTcpAddr)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "listen err", "rpcTcpAddr", rpcTcpAddr)
+	}
+	return listener, nil
+}
+
 func gracefulStopWithCtx(ctx context.Context, f func()) error {
 	done := make(chan struct{}, 1)
 	go func() {
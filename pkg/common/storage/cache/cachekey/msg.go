@@ -25,6 +25,8 @@ const (
 	messageDelUserList   = "MESSAGE_DEL_USER_LIST:"
 	userDelMessagesList  = "USER_DEL_MESSAGES_LIST:"
 	sendMsgFailedFlag    = "SEND_MSG_FAILED_FLAG:"
+	sendMsgIdempotency   = "SEND_MSG_IDEMPOTENCY:"
+	msgReactions         = "MSG_REACTIONS:"
 	exTypeKeyLocker      = "EX_LOCK:"
 	reactionExSingle     = "EX_SINGLE_"
 	reactionWriteGroup   = "EX_GROUP_"
@@ -65,3 +67,11 @@ func GetLockMessageTypeKey(clientMsgID string, TypeKey string) string {
 func GetSendMsgKey(id string) string {
 	return sendMsgFailedFlag + id
 }
+
+func GetSendMsgIdempotencyKey(sendID string, clientMsgID string) string {
+	return sendMsgIdempotency + sendID + ":" + clientMsgID
+}
+
+func GetMsgReactionsKey(conversationID string, seq int64) string {
+	return msgReactions + conversationID + ":" + strconv.Itoa(int(seq))
+}
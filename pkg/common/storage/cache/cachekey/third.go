@@ -22,6 +22,8 @@ const (
 	getuiToken              = "GETUI_TOKEN"
 	getuiTaskID             = "GETUI_TASK_ID"
 	fmcToken                = "FCM_TOKEN:"
+	apnsToken               = "APNS_TOKEN:"
+	webPushSubscription     = "WEB_PUSH_SUBSCRIPTION:"
 	userBadgeUnreadCountSum = "USER_BADGE_UNREAD_COUNT_SUM:"
 )
 
@@ -29,6 +31,14 @@ func GetFcmAccountTokenKey(account string, platformID int) string {
 	return fmcToken + account + ":" + strconv.Itoa(platformID)
 }
 
+func GetApnsAccountTokenKey(account string, platformID int) string {
+	return apnsToken + account + ":" + strconv.Itoa(platformID)
+}
+
+func GetWebPushSubscriptionKey(userID string) string {
+	return webPushSubscription + userID
+}
+
 func GetUserBadgeUnreadCountSumKey(userID string) string {
 	return userBadgeUnreadCountSum + userID
 }
@@ -3,9 +3,14 @@ package cachekey
 import "github.com/KyleYe/open-im-protocol/constant"
 
 const (
-	UidPidToken = "UID_PID_TOKEN_STATUS:"
+	UidPidToken        = "UID_PID_TOKEN_STATUS:"
+	UidPidRefreshToken = "UID_PID_REFRESH_TOKEN_STATUS:"
 )
 
 func GetTokenKey(userID string, platformID int) string {
 	return UidPidToken + userID + ":" + constant.PlatformIDToName(platformID)
 }
+
+func GetRefreshTokenKey(userID string, platformID int) string {
+	return UidPidRefreshToken + userID + ":" + constant.PlatformIDToName(platformID)
+}
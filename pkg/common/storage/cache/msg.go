@@ -18,7 +18,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 )
 
 type MsgCache interface {
@@ -35,4 +37,17 @@ type MsgCache interface {
 	SetMessageTypeKeyValue(ctx context.Context, clientMsgID string, sessionType int32, typeKey, value string) error
 	LockMessageTypeKey(ctx context.Context, clientMsgID string, TypeKey string) error
 	UnLockMessageTypeKey(ctx context.Context, clientMsgID string, TypeKey string) error
+
+	// ReserveSendMsg reserves (sendID, clientMsgID) for expire. When it is already reserved, false is returned
+	// with the response of the first send, which is nil while that send is still in progress.
+	ReserveSendMsg(ctx context.Context, sendID string, clientMsgID string, expire time.Duration) (bool, *msg.SendMsgResp, error)
+	// SetSendMsgResp stores the response of the send and resets the expiration of the reservation to expire.
+	SetSendMsgResp(ctx context.Context, sendID string, clientMsgID string, resp *msg.SendMsgResp, expire time.Duration) error
+	DeleteSendMsgReservation(ctx context.Context, sendID string, clientMsgID string) error
+
+	// GetMsgReactions returns the reactions of the messages by seq, the messages not cached yet are loaded with find.
+	// Messages without reactions are cached too and omitted from the result.
+	GetMsgReactions(ctx context.Context, conversationID string, seqs []int64,
+		find func(ctx context.Context, seqs []int64) (map[int64][]*model.MsgReactionModel, error)) (map[int64][]*model.MsgReactionModel, error)
+	DelMsgReactions(ctx context.Context, conversationID string, seq int64) error
 }
@@ -0,0 +1,28 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+)
+
+// PushRetryCache queues the offline pushes that failed, each provider has its own queue ordered by the time of the next attempt.
+type PushRetryCache interface {
+	AddPushRetry(ctx context.Context, provider string, dueTime int64, retry string) error
+	// ClaimPushRetries returns at most count retries due at now and leases them until leaseUntil,
+	// they are due again then unless they were deleted.
+	ClaimPushRetries(ctx context.Context, provider string, now int64, leaseUntil int64, count int) ([]string, error)
+	DeletePushRetry(ctx context.Context, provider string, retry string) error
+}
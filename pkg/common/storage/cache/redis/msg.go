@@ -16,26 +16,39 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-server/v3/pkg/msgprocessor"
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/utils/datautil"
+	"github.com/dtm-labs/rockscache"
 	"github.com/redis/go-redis/v9"
 ) //
 
 // msgCacheTimeout is  expiration time of message cache, 86400 seconds
 const msgCacheTimeout = 86400
 
+const msgReactionsExpireTime = time.Second * 60 * 60 * 12
+
 func NewMsgCache(client redis.UniversalClient) cache.MsgCache {
-	return &msgCache{rdb: client}
+	return &msgCache{rdb: client, rcClient: rockscache.NewClient(client, *GetRocksCacheOptions())}
 }
 
 type msgCache struct {
-	rdb redis.UniversalClient
+	rdb      redis.UniversalClient
+	rcClient *rockscache.Client
+}
+
+// msgReactions is the cached value of the reactions of a message.
+type msgReactions struct {
+	Seq       int64                     `json:"seq"`
+	Reactions []*model.MsgReactionModel `json:"reactions"`
 }
 
 func (c *msgCache) getMessageCacheKey(conversationID string, seq int64) string {
@@ -107,6 +120,45 @@ func (c *msgCache) GetSendMsgStatus(ctx context.Context, id string) (int32, erro
 	return int32(result), errs.Wrap(err)
 }
 
+func (c *msgCache) ReserveSendMsg(ctx context.Context, sendID string, clientMsgID string, expire time.Duration) (bool, *msg.SendMsgResp, error) {
+	key := cachekey.GetSendMsgIdempotencyKey(sendID, clientMsgID)
+	ok, err := c.rdb.SetNX(ctx, key, "", expire).Result()
+	if err != nil {
+		return false, nil, errs.Wrap(err)
+	}
+	if ok {
+		return true, nil, nil
+	}
+	value, err := c.rdb.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// The reservation expired in between, treat it as still in progress.
+			return false, nil, nil
+		}
+		return false, nil, errs.Wrap(err)
+	}
+	if value == "" {
+		return false, nil, nil
+	}
+	var resp msg.SendMsgResp
+	if err := msgprocessor.String2Pb(value, &resp); err != nil {
+		return false, nil, errs.Wrap(err)
+	}
+	return false, &resp, nil
+}
+
+func (c *msgCache) SetSendMsgResp(ctx context.Context, sendID string, clientMsgID string, resp *msg.SendMsgResp, expire time.Duration) error {
+	value, err := msgprocessor.Pb2String(resp)
+	if err != nil {
+		return err
+	}
+	return errs.Wrap(c.rdb.Set(ctx, cachekey.GetSendMsgIdempotencyKey(sendID, clientMsgID), value, expire).Err())
+}
+
+func (c *msgCache) DeleteSendMsgReservation(ctx context.Context, sendID string, clientMsgID string) error {
+	return errs.Wrap(c.rdb.Del(ctx, cachekey.GetSendMsgIdempotencyKey(sendID, clientMsgID)).Err())
+}
+
 func (c *msgCache) LockMessageTypeKey(ctx context.Context, clientMsgID string, TypeKey string) error {
 	key := c.getLockMessageTypeKey(clientMsgID, TypeKey)
 	return errs.Wrap(c.rdb.SetNX(ctx, key, 1, time.Minute).Err())
@@ -185,3 +237,37 @@ func (c *msgCache) GetMessagesBySeq(ctx context.Context, conversationID string,
 	}
 	return seqMsgs, failedSeqs, nil
 }
+
+func (c *msgCache) GetMsgReactions(ctx context.Context, conversationID string, seqs []int64,
+	find func(ctx context.Context, seqs []int64) (map[int64][]*model.MsgReactionModel, error)) (map[int64][]*model.MsgReactionModel, error) {
+	res, err := batchGetCache2(ctx, c.rcClient, msgReactionsExpireTime, seqs, func(seq int64) string {
+		return cachekey.GetMsgReactionsKey(conversationID, seq)
+	}, func(v *msgReactions) int64 {
+		return v.Seq
+	}, func(ctx context.Context, seqs []int64) ([]*msgReactions, error) {
+		seqReactions, err := find(ctx, seqs)
+		if err != nil {
+			return nil, err
+		}
+		// Every seq gets a value, an empty one would only be kept for the short empty expiration.
+		values := make([]*msgReactions, 0, len(seqs))
+		for _, seq := range seqs {
+			values = append(values, &msgReactions{Seq: seq, Reactions: seqReactions[seq]})
+		}
+		return values, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	seqReactions := make(map[int64][]*model.MsgReactionModel)
+	for _, v := range res {
+		if len(v.Reactions) > 0 {
+			seqReactions[v.Seq] = v.Reactions
+		}
+	}
+	return seqReactions, nil
+}
+
+func (c *msgCache) DelMsgReactions(ctx context.Context, conversationID string, seq int64) error {
+	return errs.Wrap(c.rcClient.TagAsDeleted2(ctx, cachekey.GetMsgReactionsKey(conversationID, seq)))
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/redis/go-redis/v9"
+)
+
+// claimDueScript leases the due members atomically by moving their score to the end of the lease, so that every retry
+// is taken by one push instance at a time and is taken again when that instance does not delete it in time.
+var claimDueScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for i = 1, #items do
+    redis.call('ZADD', KEYS[1], 'XX', ARGV[3], items[i])
+end
+return items
+`)
+
+func NewPushRetryCache(rdb redis.UniversalClient) cache.PushRetryCache {
+	return &pushRetryCache{rdb: rdb}
+}
+
+type pushRetryCache struct {
+	rdb redis.UniversalClient
+}
+
+func (c *pushRetryCache) getOfflinePushRetryKey(provider string) string {
+	return cachekey.GetOfflinePushRetryKey(provider)
+}
+
+func (c *pushRetryCache) AddPushRetry(ctx context.Context, provider string, dueTime int64, retry string) error {
+	return errs.Wrap(c.rdb.ZAdd(ctx, c.getOfflinePushRetryKey(provider), redis.Z{Score: float64(dueTime), Member: retry}).Err())
+}
+
+func (c *pushRetryCache) ClaimPushRetries(ctx context.Context, provider string, now int64, leaseUntil int64, count int) ([]string, error) {
+	v, err := callLua(ctx, c.rdb, claimDueScript, []string{c.getOfflinePushRetryKey(provider)}, []any{now, count, leaseUntil})
+	if err != nil {
+		return nil, err
+	}
+	items, ok := v.([]any)
+	if !ok {
+		return nil, nil
+	}
+	retries := make([]string, 0, len(items))
+	for _, item := range items {
+		if retry, ok := item.(string); ok {
+			retries = append(retries, retry)
+		}
+	}
+	return retries, nil
+}
+
+func (c *pushRetryCache) DeletePushRetry(ctx context.Context, provider string, retry string) error {
+	return errs.Wrap(c.rdb.ZRem(ctx, c.getOfflinePushRetryKey(provider), retry).Err())
+}
@@ -16,14 +16,39 @@ package redis
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/log"
 	"github.com/redis/go-redis/v9"
 )
 
+// setWebPushSubscriptionScript sets the subscription unless its endpoint is new and the hash holds ARGV[3] endpoints already.
+var setWebPushSubscriptionScript = redis.NewScript(`
+local max = tonumber(ARGV[3])
+if max > 0 and redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 and redis.call('HLEN', KEYS[1]) >= max then
+    return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// delApnsTokenScript deletes the token fields ARGV[2...] holding the token ARGV[1], the other token of the device is kept.
+var delApnsTokenScript = redis.NewScript(`
+local removed = 0
+for i = 2, #ARGV do
+    if redis.call('HGET', KEYS[1], ARGV[i]) == ARGV[1] then
+        redis.call('HDEL', KEYS[1], ARGV[i])
+        removed = 1
+    end
+end
+return removed
+`)
+
 func NewThirdCache(rdb redis.UniversalClient) cache.ThirdCache {
 	return &thirdCache{rdb: rdb}
 }
@@ -64,6 +89,100 @@ func (c *thirdCache) DelFcmToken(ctx context.Context, account string, platformID
 	return errs.Wrap(c.rdb.Del(ctx, c.getFcmAccountTokenKey(account, platformID)).Err())
 }
 
+func (c *thirdCache) getApnsAccountTokenKey(account string, platformID int) string {
+	return cachekey.GetApnsAccountTokenKey(account, platformID)
+}
+
+const (
+	apnsDeviceTokenField = "device"
+	apnsVoipTokenField   = "voip"
+)
+
+func (c *thirdCache) SetApnsToken(ctx context.Context, account string, platformID int, deviceToken string, voipToken string, expireTime int64) error {
+	key := c.getApnsAccountTokenKey(account, platformID)
+	values := make(map[string]any, 2)
+	if deviceToken != "" {
+		values[apnsDeviceTokenField] = deviceToken
+	}
+	if voipToken != "" {
+		values[apnsVoipTokenField] = voipToken
+	}
+	if len(values) == 0 {
+		return errs.ErrArgs.WrapMsg("deviceToken and voipToken are empty")
+	}
+	pipe := c.rdb.TxPipeline()
+	pipe.HSet(ctx, key, values)
+	if expireTime > 0 {
+		pipe.Expire(ctx, key, time.Duration(expireTime)*time.Second)
+	}
+	_, err := pipe.Exec(ctx)
+	return errs.Wrap(err)
+}
+
+func (c *thirdCache) GetApnsToken(ctx context.Context, account string, platformID int) (string, string, error) {
+	val, err := c.rdb.HGetAll(ctx, c.getApnsAccountTokenKey(account, platformID)).Result()
+	if err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	if len(val) == 0 {
+		return "", "", errs.Wrap(redis.Nil)
+	}
+	return val[apnsDeviceTokenField], val[apnsVoipTokenField], nil
+}
+
+func (c *thirdCache) DelApnsToken(ctx context.Context, account string, platformID int) error {
+	return errs.Wrap(c.rdb.Del(ctx, c.getApnsAccountTokenKey(account, platformID)).Err())
+}
+
+func (c *thirdCache) DelApnsTokenIfEqual(ctx context.Context, account string, platformID int, token string) (bool, error) {
+	key := c.getApnsAccountTokenKey(account, platformID)
+	removed, err := delApnsTokenScript.Run(ctx, c.rdb, []string{key}, token, apnsDeviceTokenField, apnsVoipTokenField).Int()
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return removed == 1, nil
+}
+
+func (c *thirdCache) getWebPushSubscriptionKey(userID string) string {
+	return cachekey.GetWebPushSubscriptionKey(userID)
+}
+
+func (c *thirdCache) SetWebPushSubscription(ctx context.Context, userID string, subscription *model.WebPushSubscription, maxCount int) (bool, error) {
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	res, err := setWebPushSubscriptionScript.Run(ctx, c.rdb, []string{c.getWebPushSubscriptionKey(userID)}, subscription.Endpoint, string(data), maxCount).Int()
+	if err != nil {
+		return false, errs.Wrap(err)
+	}
+	return res == 1, nil
+}
+
+func (c *thirdCache) GetWebPushSubscriptions(ctx context.Context, userID string) ([]*model.WebPushSubscription, error) {
+	val, err := c.rdb.HGetAll(ctx, c.getWebPushSubscriptionKey(userID)).Result()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	subscriptions := make([]*model.WebPushSubscription, 0, len(val))
+	for endpoint, data := range val {
+		var subscription model.WebPushSubscription
+		if err := json.Unmarshal([]byte(data), &subscription); err != nil {
+			log.ZWarn(ctx, "invalid web push subscription", err, "userID", userID, "endpoint", endpoint)
+			continue
+		}
+		subscriptions = append(subscriptions, &subscription)
+	}
+	return subscriptions, nil
+}
+
+func (c *thirdCache) DelWebPushSubscriptions(ctx context.Context, userID string, endpoints ...string) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return errs.Wrap(c.rdb.HDel(ctx, c.getWebPushSubscriptionKey(userID), endpoints...).Err())
+}
+
 func (c *thirdCache) IncrUserBadgeUnreadCountSum(ctx context.Context, userID string) (int, error) {
 	seq, err := c.rdb.Incr(ctx, c.getUserBadgeUnreadCountSumKey(userID)).Result()
 
@@ -0,0 +1,92 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetApnsToken(t *testing.T) {
+	ctx := context.Background()
+	rdb, mock := redismock.NewClientMock()
+	c := NewThirdCache(rdb)
+	key := cachekey.GetApnsAccountTokenKey("u1", constant.IOSPlatformID)
+
+	mock.ExpectTxPipeline()
+	mock.ExpectHSet(key, map[string]any{apnsDeviceTokenField: "device"}).SetVal(1)
+	mock.ExpectExpire(key, time.Hour).SetVal(true)
+	mock.ExpectTxPipelineExec()
+	assert.NoError(t, c.SetApnsToken(ctx, "u1", constant.IOSPlatformID, "device", "", 3600))
+
+	// Without an expire time the tokens are kept.
+	mock.ExpectTxPipeline()
+	mock.ExpectHSet(key, map[string]any{apnsVoipTokenField: "voip"}).SetVal(1)
+	mock.ExpectTxPipelineExec()
+	assert.NoError(t, c.SetApnsToken(ctx, "u1", constant.IOSPlatformID, "", "voip", 0))
+
+	err := c.SetApnsToken(ctx, "u1", constant.IOSPlatformID, "", "", 3600)
+	assert.True(t, errs.ErrArgs.Is(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelApnsTokenIfEqual(t *testing.T) {
+	ctx := context.Background()
+	rdb, mock := redismock.NewClientMock()
+	c := NewThirdCache(rdb)
+	key := cachekey.GetApnsAccountTokenKey("u1", constant.IOSPlatformID)
+	args := []any{"voip", apnsDeviceTokenField, apnsVoipTokenField}
+
+	mock.ExpectEvalSha(delApnsTokenScript.Hash(), []string{key}, args).SetVal(int64(1))
+	ok, err := c.DelApnsTokenIfEqual(ctx, "u1", constant.IOSPlatformID, "voip")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The device registered another token since.
+	mock.ExpectEvalSha(delApnsTokenScript.Hash(), []string{key}, args).SetVal(int64(0))
+	ok, err = c.DelApnsTokenIfEqual(ctx, "u1", constant.IOSPlatformID, "voip")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetWebPushSubscription(t *testing.T) {
+	ctx := context.Background()
+	rdb, mock := redismock.NewClientMock()
+	c := NewThirdCache(rdb)
+	key := cachekey.GetWebPushSubscriptionKey("u1")
+	subscription := &model.WebPushSubscription{Endpoint: "https://push.example.com/1", P256dh: "p", Auth: "a"}
+	args := []any{subscription.Endpoint, `{"endpoint":"https://push.example.com/1","p256dh":"p","auth":"a"}`, 10}
+
+	mock.ExpectEvalSha(setWebPushSubscriptionScript.Hash(), []string{key}, args).SetVal(int64(1))
+	ok, err := c.SetWebPushSubscription(ctx, "u1", subscription, 10)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The user has the maximum number of other subscriptions.
+	mock.ExpectEvalSha(setWebPushSubscriptionScript.Hash(), []string{key}, args).SetVal(int64(0))
+	ok, err = c.SetWebPushSubscription(ctx, "u1", subscription, 10)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -25,14 +25,25 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+var compareAndSetTokenFlagScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+    redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+    return 1
+end
+return 0
+`)
+
 type tokenCache struct {
-	rdb          redis.UniversalClient
-	accessExpire time.Duration
+	rdb           redis.UniversalClient
+	accessExpire  time.Duration
+	refreshExpire time.Duration
 }
 
-func NewTokenCacheModel(rdb redis.UniversalClient, accessExpire int64) cache.TokenModel {
+// NewTokenCacheModel accessExpire and refreshExpire are in days.
+func NewTokenCacheModel(rdb redis.UniversalClient, accessExpire int64, refreshExpire int64) cache.TokenModel {
 	c := &tokenCache{rdb: rdb}
 	c.accessExpire = c.getExpireTime(accessExpire)
+	c.refreshExpire = c.getExpireTime(refreshExpire)
 	return c
 }
 
@@ -77,6 +88,52 @@ func (c *tokenCache) DeleteTokenByUidPid(ctx context.Context, userID string, pla
 	return errs.Wrap(c.rdb.HDel(ctx, cachekey.GetTokenKey(userID, platformID), fields...).Err())
 }
 
+// SetRefreshTokenFlagEx set refresh token and flag with the refresh token expire time
+func (c *tokenCache) SetRefreshTokenFlagEx(ctx context.Context, userID string, platformID int, token string, flag int) error {
+	key := cachekey.GetRefreshTokenKey(userID, platformID)
+	if err := c.rdb.HSet(ctx, key, token, flag).Err(); err != nil {
+		return errs.Wrap(err)
+	}
+	if err := c.rdb.Expire(ctx, key, c.refreshExpire).Err(); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func (c *tokenCache) CompareAndSetRefreshTokenFlag(ctx context.Context, userID string, platformID int, token string, oldFlag int, newFlag int) (bool, error) {
+	key := cachekey.GetRefreshTokenKey(userID, platformID)
+	v, err := callLua(ctx, c.rdb, compareAndSetTokenFlagScript, []string{key}, []any{token, oldFlag, newFlag})
+	if err != nil {
+		return false, err
+	}
+	n, _ := v.(int64)
+	return n == 1, nil
+}
+
+func (c *tokenCache) GetRefreshTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error) {
+	m, err := c.rdb.HGetAll(ctx, cachekey.GetRefreshTokenKey(userID, platformID)).Result()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	mm := make(map[string]int)
+	for k, v := range m {
+		mm[k] = stringutil.StringToInt(v)
+	}
+	return mm, nil
+}
+
+func (c *tokenCache) SetRefreshTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error {
+	mm := make(map[string]any)
+	for k, v := range m {
+		mm[k] = v
+	}
+	return errs.Wrap(c.rdb.HSet(ctx, cachekey.GetRefreshTokenKey(userID, platformID), mm).Err())
+}
+
+func (c *tokenCache) DeleteRefreshTokenByUidPid(ctx context.Context, userID string, platformID int, fields []string) error {
+	return errs.Wrap(c.rdb.HDel(ctx, cachekey.GetRefreshTokenKey(userID, platformID), fields...).Err())
+}
+
 func (c *tokenCache) getExpireTime(t int64) time.Duration {
 	return time.Hour * 24 * time.Duration(t)
 }
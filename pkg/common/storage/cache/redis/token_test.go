@@ -0,0 +1,44 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache/cachekey"
+	"github.com/go-redis/redismock/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareAndSetRefreshTokenFlag(t *testing.T) {
+	rdb, mock := redismock.NewClientMock()
+	c := NewTokenCacheModel(rdb, 1, 1)
+	key := cachekey.GetRefreshTokenKey("u1", constant.IOSPlatformID)
+	args := []any{"token", constant.NormalToken, constant.InValidToken}
+
+	mock.ExpectEvalSha(compareAndSetTokenFlagScript.Hash(), []string{key}, args).SetVal(int64(1))
+	ok, err := c.CompareAndSetRefreshTokenFlag(context.Background(), "u1", constant.IOSPlatformID, "token", constant.NormalToken, constant.InValidToken)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// A used refresh token no longer holds the old flag.
+	mock.ExpectEvalSha(compareAndSetTokenFlagScript.Hash(), []string{key}, args).SetVal(int64(0))
+	ok, err = c.CompareAndSetRefreshTokenFlag(context.Background(), "u1", constant.IOSPlatformID, "token", constant.NormalToken, constant.InValidToken)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,12 +2,27 @@ package cache
 
 import (
 	"context"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 )
 
 type ThirdCache interface {
 	SetFcmToken(ctx context.Context, account string, platformID int, fcmToken string, expireTime int64) (err error)
 	GetFcmToken(ctx context.Context, account string, platformID int) (string, error)
 	DelFcmToken(ctx context.Context, account string, platformID int) error
+	// SetApnsToken stores the APNs device token and VoIP token of the device, an empty token keeps the stored one.
+	// The tokens do not expire when expireTime is not positive.
+	SetApnsToken(ctx context.Context, account string, platformID int, deviceToken string, voipToken string, expireTime int64) error
+	GetApnsToken(ctx context.Context, account string, platformID int) (deviceToken string, voipToken string, err error)
+	DelApnsToken(ctx context.Context, account string, platformID int) error
+	// DelApnsTokenIfEqual deletes the device token or the VoIP token of the device when it is token, keeping the other one.
+	// It reports whether a token was deleted.
+	DelApnsTokenIfEqual(ctx context.Context, account string, platformID int, token string) (bool, error)
+	// SetWebPushSubscription adds the subscription of the user, replacing the one with the same endpoint.
+	// It reports false without adding it when the user has maxCount other subscriptions, 0 means no limit.
+	SetWebPushSubscription(ctx context.Context, userID string, subscription *model.WebPushSubscription, maxCount int) (bool, error)
+	GetWebPushSubscriptions(ctx context.Context, userID string) ([]*model.WebPushSubscription, error)
+	DelWebPushSubscriptions(ctx context.Context, userID string, endpoints ...string) error
 	IncrUserBadgeUnreadCountSum(ctx context.Context, userID string) (int, error)
 	SetUserBadgeUnreadCountSum(ctx context.Context, userID string, value int) error
 	GetUserBadgeUnreadCountSum(ctx context.Context, userID string) (int, error)
@@ -11,4 +11,12 @@ type TokenModel interface {
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
 	SetTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
 	DeleteTokenByUidPid(ctx context.Context, userID string, platformID int, fields []string) error
+
+	// SetRefreshTokenFlagEx set refresh token and flag with the refresh token expire time
+	SetRefreshTokenFlagEx(ctx context.Context, userID string, platformID int, token string, flag int) error
+	// CompareAndSetRefreshTokenFlag sets the flag of the refresh token only when it is currently oldFlag
+	CompareAndSetRefreshTokenFlag(ctx context.Context, userID string, platformID int, token string, oldFlag int, newFlag int) (bool, error)
+	GetRefreshTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
+	SetRefreshTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
+	DeleteRefreshTokenByUidPid(ctx context.Context, userID string, platformID int, fields []string) error
 }
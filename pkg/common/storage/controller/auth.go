@@ -16,15 +16,31 @@ package controller
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/KyleYe/open-im-protocol/constant"
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
-	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/tokenverify"
+	"github.com/KyleYe/open-im-tools/utils/idutil"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+// RefreshTokenSubject is the subject of refresh tokens, it keeps them from being accepted as tokens.
+const RefreshTokenSubject = "refresh"
+
+// TokenFamily returns the chain family of a token created by CreateTokenPair, or "" for other tokens.
+// The jti of such tokens is the family followed by a per token suffix, so that tokens issued within the same second differ.
+func TokenFamily(claims *tokenverify.Claims) string {
+	family, _, _ := strings.Cut(claims.ID, ".")
+	return family
+}
+
+func newTokenID(family string) string {
+	return family + "." + idutil.OperationIDGenerator()
+}
+
 type AuthDatabase interface {
 	// If the result is empty, no error is returned.
 	GetTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
@@ -32,16 +48,28 @@ type AuthDatabase interface {
 	CreateToken(ctx context.Context, userID string, platformID int) (string, error)
 
 	SetTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
+
+	// CreateTokenPair creates a short token and a refresh token of the chain family.
+	// A new chain is started when family is empty, see TokenFamily.
+	CreateTokenPair(ctx context.Context, userID string, platformID int, family string) (token string, refreshToken string, err error)
+	// If the result is empty, no error is returned.
+	GetRefreshTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error)
+	SetRefreshTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error
+	// UseRefreshToken marks a normal refresh token as used, false is returned when it is not normal.
+	UseRefreshToken(ctx context.Context, userID string, platformID int, refreshToken string) (bool, error)
 }
 
 type authDatabase struct {
-	cache        cache.TokenModel
-	accessSecret string
-	accessExpire int64
+	cache         cache.TokenModel
+	keySet        *authverify.KeySet
+	accessExpire  int64
+	refreshExpire int64
+	pairExpire    int64
 }
 
-func NewAuthDatabase(cache cache.TokenModel, accessSecret string, accessExpire int64) AuthDatabase {
-	return &authDatabase{cache: cache, accessSecret: accessSecret, accessExpire: accessExpire}
+// NewAuthDatabase accessExpire and refreshExpire are in days, pairExpire is the token lifetime of a token pair in minutes.
+func NewAuthDatabase(cache cache.TokenModel, keySet *authverify.KeySet, accessExpire int64, refreshExpire int64, pairExpire int64) AuthDatabase {
+	return &authDatabase{cache: cache, keySet: keySet, accessExpire: accessExpire, refreshExpire: refreshExpire, pairExpire: pairExpire}
 }
 
 // If the result is empty.
@@ -53,31 +81,45 @@ func (a *authDatabase) SetTokenMapByUidPid(ctx context.Context, userID string, p
 	return a.cache.SetTokenMapByUidPid(ctx, userID, platformID, m)
 }
 
-// Create Token.
-func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformID int) (string, error) {
+// deleteInvalidTokens deletes the tokens of the user on the platform that are expired or no longer normal.
+func (a *authDatabase) deleteInvalidTokens(ctx context.Context, userID string, platformID int) error {
 	tokens, err := a.cache.GetTokensWithoutError(ctx, userID, platformID)
 	if err != nil {
-		return "", err
+		return err
 	}
 	var deleteTokenKey []string
 	for k, v := range tokens {
-		_, err = tokenverify.GetClaimFromToken(k, authverify.Secret(a.accessSecret))
+		_, err = tokenverify.GetClaimFromToken(k, a.keySet.Keyfunc)
 		if err != nil || v != constant.NormalToken {
 			deleteTokenKey = append(deleteTokenKey, k)
 		}
 	}
-	if len(deleteTokenKey) != 0 {
-		err = a.cache.DeleteTokenByUidPid(ctx, userID, platformID, deleteTokenKey)
-		if err != nil {
-			return "", err
-		}
+	if len(deleteTokenKey) == 0 {
+		return nil
 	}
+	return a.cache.DeleteTokenByUidPid(ctx, userID, platformID, deleteTokenKey)
+}
 
+// pairClaims returns the claims of a token with the short lifetime of token pairs.
+func (a *authDatabase) pairClaims(userID string, platformID int) *tokenverify.Claims {
 	claims := tokenverify.BuildClaims(userID, platformID, a.accessExpire)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(a.accessSecret))
+	claims.ExpiresAt = jwt.NewNumericDate(claims.IssuedAt.Add(time.Duration(a.pairExpire) * time.Minute))
+	return &claims
+}
+
+// CreateToken creates a token without a refresh token. When refresh tokens are enabled it is as short-lived
+// as the token of a pair, so that a long-lived token is not issued by the routes without refresh.
+func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformID int) (string, error) {
+	if err := a.deleteInvalidTokens(ctx, userID, platformID); err != nil {
+		return "", err
+	}
+	claims := tokenverify.BuildClaims(userID, platformID, a.accessExpire)
+	if a.refreshExpire > 0 {
+		claims = *a.pairClaims(userID, platformID)
+	}
+	tokenString, err := a.keySet.Sign(claims)
 	if err != nil {
-		return "", errs.WrapMsg(err, "token.SignedString")
+		return "", err
 	}
 
 	if err = a.cache.SetTokenFlagEx(ctx, userID, platformID, tokenString, constant.NormalToken); err != nil {
@@ -85,3 +127,62 @@ func (a *authDatabase) CreateToken(ctx context.Context, userID string, platformI
 	}
 	return tokenString, nil
 }
+
+func (a *authDatabase) GetRefreshTokensWithoutError(ctx context.Context, userID string, platformID int) (map[string]int, error) {
+	return a.cache.GetRefreshTokensWithoutError(ctx, userID, platformID)
+}
+
+func (a *authDatabase) SetRefreshTokenMapByUidPid(ctx context.Context, userID string, platformID int, m map[string]int) error {
+	return a.cache.SetRefreshTokenMapByUidPid(ctx, userID, platformID, m)
+}
+
+func (a *authDatabase) UseRefreshToken(ctx context.Context, userID string, platformID int, refreshToken string) (bool, error) {
+	return a.cache.CompareAndSetRefreshTokenFlag(ctx, userID, platformID, refreshToken, constant.NormalToken, constant.InValidToken)
+}
+
+func (a *authDatabase) CreateTokenPair(ctx context.Context, userID string, platformID int, family string) (string, string, error) {
+	if err := a.deleteInvalidTokens(ctx, userID, platformID); err != nil {
+		return "", "", err
+	}
+	// Used and kicked refresh tokens are kept until they expire so that their reuse can be detected.
+	refreshTokens, err := a.cache.GetRefreshTokensWithoutError(ctx, userID, platformID)
+	if err != nil {
+		return "", "", err
+	}
+	var deleteTokenKey []string
+	for k := range refreshTokens {
+		if _, err := tokenverify.GetClaimFromToken(k, a.keySet.Keyfunc); err != nil {
+			deleteTokenKey = append(deleteTokenKey, k)
+		}
+	}
+	if len(deleteTokenKey) != 0 {
+		if err := a.cache.DeleteRefreshTokenByUidPid(ctx, userID, platformID, deleteTokenKey); err != nil {
+			return "", "", err
+		}
+	}
+
+	if family == "" {
+		family = idutil.OperationIDGenerator()
+	}
+	claims := a.pairClaims(userID, platformID)
+	claims.ID = newTokenID(family)
+	tokenString, err := a.keySet.Sign(*claims)
+	if err != nil {
+		return "", "", err
+	}
+	refreshClaims := tokenverify.BuildClaims(userID, platformID, a.refreshExpire)
+	refreshClaims.ID = newTokenID(family)
+	refreshClaims.Subject = RefreshTokenSubject
+	refreshTokenString, err := a.keySet.Sign(refreshClaims)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := a.cache.SetRefreshTokenFlagEx(ctx, userID, platformID, refreshTokenString, constant.NormalToken); err != nil {
+		return "", "", err
+	}
+	if err := a.cache.SetTokenFlagEx(ctx, userID, platformID, tokenString, constant.NormalToken); err != nil {
+		return "", "", err
+	}
+	return tokenString, refreshTokenString, nil
+}
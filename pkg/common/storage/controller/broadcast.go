@@ -0,0 +1,97 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+)
+
+type BroadcastDatabase interface {
+	// CreateBroadcast saves a new pending job
+	CreateBroadcast(ctx context.Context, job *model.BroadcastJob) error
+	// TakeBroadcast gets a job, mongo.ErrNoDocuments when it does not exist
+	TakeBroadcast(ctx context.Context, jobID string) (*model.BroadcastJob, error)
+	// PageBroadcasts gets the jobs newest first, a zero status gets every job
+	PageBroadcasts(ctx context.Context, status int32, pagination pagination.Pagination) (int64, []*model.BroadcastJob, error)
+	// CancelBroadcast cancels an unfinished job, false when the job is already finished
+	CancelBroadcast(ctx context.Context, jobID string) (bool, error)
+	// ClaimBroadcast leases the oldest job waiting for a worker, nil when there is none
+	ClaimBroadcast(ctx context.Context, workerID string, leaseUntil time.Time) (*model.BroadcastJob, error)
+	// ProgressBroadcast saves the progress of a leased job, false when the worker lost the job
+	ProgressBroadcast(ctx context.Context, jobID string, workerID string, cursor string, success int64, failed int64, leaseUntil time.Time, done bool) (bool, error)
+	// SetBroadcastResult saves the result of one recipient
+	SetBroadcastResult(ctx context.Context, result *model.BroadcastResult) error
+	// FindBroadcastResults gets the saved results of some recipients
+	FindBroadcastResults(ctx context.Context, jobID string, recvIDs []string) ([]*model.BroadcastResult, error)
+	// PageBroadcastResults gets the results of a job in recipient order
+	PageBroadcastResults(ctx context.Context, jobID string, onlyFailed bool, pagination pagination.Pagination) (int64, []*model.BroadcastResult, error)
+}
+
+func NewBroadcastDatabase(db database.Broadcast) BroadcastDatabase {
+	return &broadcastDatabase{db: db}
+}
+
+type broadcastDatabase struct {
+	db database.Broadcast
+}
+
+func (b *broadcastDatabase) CreateBroadcast(ctx context.Context, job *model.BroadcastJob) error {
+	return b.db.Create(ctx, job)
+}
+
+func (b *broadcastDatabase) TakeBroadcast(ctx context.Context, jobID string) (*model.BroadcastJob, error) {
+	return b.db.Take(ctx, jobID)
+}
+
+func (b *broadcastDatabase) PageBroadcasts(ctx context.Context, status int32, pagination pagination.Pagination) (int64, []*model.BroadcastJob, error) {
+	return b.db.Page(ctx, status, pagination)
+}
+
+func (b *broadcastDatabase) CancelBroadcast(ctx context.Context, jobID string) (bool, error) {
+	return b.db.Cancel(ctx, jobID)
+}
+
+func (b *broadcastDatabase) ClaimBroadcast(ctx context.Context, workerID string, leaseUntil time.Time) (*model.BroadcastJob, error) {
+	job, err := b.db.Claim(ctx, workerID, leaseUntil)
+	if err != nil {
+		if mgo.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+func (b *broadcastDatabase) ProgressBroadcast(ctx context.Context, jobID string, workerID string, cursor string, success int64, failed int64, leaseUntil time.Time, done bool) (bool, error) {
+	return b.db.Progress(ctx, jobID, workerID, cursor, success, failed, leaseUntil, done)
+}
+
+func (b *broadcastDatabase) SetBroadcastResult(ctx context.Context, result *model.BroadcastResult) error {
+	return b.db.SetResult(ctx, result)
+}
+
+func (b *broadcastDatabase) FindBroadcastResults(ctx context.Context, jobID string, recvIDs []string) ([]*model.BroadcastResult, error) {
+	return b.db.FindResults(ctx, jobID, recvIDs)
+}
+
+func (b *broadcastDatabase) PageBroadcastResults(ctx context.Context, jobID string, onlyFailed bool, pagination pagination.Pagination) (int64, []*model.BroadcastResult, error) {
+	return b.db.PageResults(ctx, jobID, onlyFailed, pagination)
+}
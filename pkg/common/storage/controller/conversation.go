@@ -69,6 +69,8 @@ type ConversationDatabase interface {
 	// FindRecvMsgNotNotifyUserIDs(ctx context.Context, groupID string) ([]string, error)
 	FindConversationUserVersion(ctx context.Context, userID string, version uint, limit int) (*relationtb.VersionLog, error)
 	FindMaxConversationUserVersionCache(ctx context.Context, userID string) (*relationtb.VersionLog, error)
+	// CompactConversationUserVersion removes the version logs unchanged since the deadline.
+	CompactConversationUserVersion(ctx context.Context, deadline time.Time) ([]*relationtb.VersionLogCompaction, error)
 	GetOwnerConversation(ctx context.Context, ownerUserID string, pagination pagination.Pagination) (int64, []*relationtb.Conversation, error)
 }
 
@@ -334,6 +336,10 @@ func (c *conversationDatabase) FindConversationUserVersion(ctx context.Context,
 	return c.conversationDB.FindConversationUserVersion(ctx, userID, version, limit)
 }
 
+func (c *conversationDatabase) CompactConversationUserVersion(ctx context.Context, deadline time.Time) ([]*relationtb.VersionLogCompaction, error) {
+	return c.conversationDB.CompactConversationUserVersion(ctx, deadline)
+}
+
 func (c *conversationDatabase) FindMaxConversationUserVersionCache(ctx context.Context, userID string) (*relationtb.VersionLog, error) {
 	return c.cache.FindMaxConversationUserVersion(ctx, userID)
 }
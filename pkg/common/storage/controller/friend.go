@@ -85,6 +85,9 @@ type FriendDatabase interface {
 
 	FindMaxFriendVersionCache(ctx context.Context, ownerUserID string) (*model.VersionLog, error)
 
+	// CompactFriendIncrVersion removes the version logs unchanged since the deadline.
+	CompactFriendIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error)
+
 	FindFriendUserID(ctx context.Context, friendUserID string) ([]string, error)
 
 	OwnerIncrVersion(ctx context.Context, ownerUserID string, friendUserIDs []string, state int32) error
@@ -380,6 +383,10 @@ func (f *friendDatabase) FindFriendIncrVersion(ctx context.Context, ownerUserID
 	return f.friend.FindIncrVersion(ctx, ownerUserID, version, limit)
 }
 
+func (f *friendDatabase) CompactFriendIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error) {
+	return f.friend.CompactIncrVersion(ctx, deadline)
+}
+
 func (f *friendDatabase) FindMaxFriendVersionCache(ctx context.Context, ownerUserID string) (*model.VersionLog, error) {
 	return f.cache.FindMaxFriendVersion(ctx, ownerUserID)
 }
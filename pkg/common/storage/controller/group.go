@@ -113,6 +113,8 @@ type GroupDatabase interface {
 	BatchFindMemberIncrVersion(ctx context.Context, groupIDs []string, versions []uint64, limits []int) (map[string]*model.VersionLog, error)
 	FindJoinIncrVersion(ctx context.Context, userID string, version uint, limit int) (*model.VersionLog, error)
 	MemberGroupIncrVersion(ctx context.Context, groupID string, userIDs []string, state int32) error
+	// CompactGroupIncrVersion removes the member and join version logs unchanged since the deadline.
+	CompactGroupIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error)
 
 	//FindSortGroupMemberUserIDs(ctx context.Context, groupID string) ([]string, error)
 	//FindSortJoinGroupIDs(ctx context.Context, userID string) ([]string, error)
@@ -529,6 +531,10 @@ func (g *groupDatabase) FindJoinIncrVersion(ctx context.Context, userID string,
 	return g.groupMemberDB.FindJoinIncrVersion(ctx, userID, version, limit)
 }
 
+func (g *groupDatabase) CompactGroupIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error) {
+	return g.groupMemberDB.CompactIncrVersion(ctx, deadline)
+}
+
 func (g *groupDatabase) FindMaxGroupMemberVersionCache(ctx context.Context, groupID string) (*model.VersionLog, error) {
 	return g.cache.FindMaxGroupMemberVersion(ctx, groupID)
 }
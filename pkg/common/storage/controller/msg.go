@@ -30,6 +30,7 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/convert"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/prommetrics"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
 	"github.com/KyleYe/open-im-tools/errs"
 	"github.com/KyleYe/open-im-tools/log"
@@ -51,6 +52,17 @@ type CommonMsgDatabase interface {
 	BatchInsertChat2DB(ctx context.Context, conversationID string, msgs []*sdkws.MsgData, currentMaxSeq int64) error
 	// RevokeMsg revokes a message in a conversation.
 	RevokeMsg(ctx context.Context, conversationID string, seq int64, revoke *model.RevokeModel) error
+	// EditMsg replaces the content of a message that is still oldContent and drops it from the cache.
+	// It returns false when the message is not stored yet, was revoked or was edited concurrently.
+	EditMsg(ctx context.Context, conversationID string, seq int64, oldContent string, content string, history *model.MsgEditModel) (bool, error)
+	// AddMsgReaction adds a reaction to a message, it returns false when the user already reacted with the emoji.
+	// When maxEmojis is positive, an emoji new to a message that already has maxEmojis distinct emojis is rejected.
+	AddMsgReaction(ctx context.Context, conversationID string, seq int64, reaction *model.MsgReactionModel, maxEmojis int) (bool, error)
+	// RemoveMsgReaction removes a reaction from a message, it returns false when there was no such reaction.
+	RemoveMsgReaction(ctx context.Context, conversationID string, seq int64, reaction *model.MsgReactionModel) (bool, error)
+	// GetMsgReactions returns the reactions of the messages by sequence numbers, messages without reactions are omitted.
+	// The reactions are cached until they change, so that pulling messages does not aggregate them every time.
+	GetMsgReactions(ctx context.Context, conversationID string, seqs []int64) (map[int64][]*model.MsgReactionModel, error)
 	// MarkSingleChatMsgsAsRead marks messages as read for a single chat by sequence numbers.
 	MarkSingleChatMsgsAsRead(ctx context.Context, userID string, conversationID string, seqs []int64) error
 	// DeleteMessagesFromCache deletes message caches from Redis by sequence numbers.
@@ -85,6 +97,10 @@ type CommonMsgDatabase interface {
 	//GetConversationMinMaxSeqInMongoAndCache(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo, minSeqCache, maxSeqCache int64, err error)
 	SetSendMsgStatus(ctx context.Context, id string, status int32) error
 	GetSendMsgStatus(ctx context.Context, id string) (int32, error)
+	// ReserveSendMsg see cache.MsgCache.ReserveSendMsg
+	ReserveSendMsg(ctx context.Context, sendID string, clientMsgID string, expire time.Duration) (bool, *pbmsg.SendMsgResp, error)
+	SetSendMsgResp(ctx context.Context, sendID string, clientMsgID string, resp *pbmsg.SendMsgResp, expire time.Duration) error
+	DeleteSendMsgReservation(ctx context.Context, sendID string, clientMsgID string) error
 	SearchMessage(ctx context.Context, req *pbmsg.SearchMessageReq) (total int64, msgData []*sdkws.MsgData, err error)
 	FindOneByDocIDs(ctx context.Context, docIDs []string, seqs map[string]int64) (map[string]*sdkws.MsgData, error)
 
@@ -314,6 +330,84 @@ func (db *commonMsgDatabase) RevokeMsg(ctx context.Context, conversationID strin
 	return db.BatchInsertBlock(ctx, conversationID, []any{revoke}, updateKeyRevoke, seq)
 }
 
+func (db *commonMsgDatabase) EditMsg(ctx context.Context, conversationID string, seq int64, oldContent string, content string, history *model.MsgEditModel) (bool, error) {
+	ok, err := db.msgDocDatabase.UpdateMsgContent(ctx, db.msgTable.GetDocID(conversationID, seq), db.msgTable.GetMsgIndex(seq), oldContent, content, history)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := db.msg.DeleteMessagesFromCache(ctx, conversationID, []int64{seq}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *commonMsgDatabase) AddMsgReaction(ctx context.Context, conversationID string, seq int64, reaction *model.MsgReactionModel, maxEmojis int) (bool, error) {
+	docID := db.msgTable.GetDocID(conversationID, seq)
+	res, err := db.msgDocDatabase.AddReaction(ctx, docID, db.msgTable.GetMsgIndex(seq), reaction, maxEmojis)
+	if err != nil {
+		return false, err
+	}
+	if res.MatchedCount == 0 {
+		if maxEmojis > 0 {
+			exist, err := db.msgDocDatabase.IsExistDocID(ctx, docID)
+			if err != nil {
+				return false, err
+			}
+			if exist {
+				return false, servererrs.ErrMsgReactionLimit.WrapMsg("too many distinct emojis on msg", "maxEmojis", maxEmojis)
+			}
+		}
+		return false, errs.ErrRecordNotFound.WrapMsg("msg is not stored yet, try again", "conversationID", conversationID, "seq", seq)
+	}
+	if res.ModifiedCount == 0 {
+		return false, nil
+	}
+	if err := db.msg.DelMsgReactions(ctx, conversationID, seq); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *commonMsgDatabase) RemoveMsgReaction(ctx context.Context, conversationID string, seq int64, reaction *model.MsgReactionModel) (bool, error) {
+	res, err := db.msgDocDatabase.PullAll(ctx, db.msgTable.GetDocID(conversationID, seq), db.msgTable.GetMsgIndex(seq), "reactions", []*model.MsgReactionModel{reaction})
+	if err != nil {
+		return false, err
+	}
+	if res.ModifiedCount == 0 {
+		return false, nil
+	}
+	if err := db.msg.DelMsgReactions(ctx, conversationID, seq); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (db *commonMsgDatabase) GetMsgReactions(ctx context.Context, conversationID string, seqs []int64) (map[int64][]*model.MsgReactionModel, error) {
+	return db.msg.GetMsgReactions(ctx, conversationID, seqs, func(ctx context.Context, seqs []int64) (map[int64][]*model.MsgReactionModel, error) {
+		return db.findMsgReactions(ctx, conversationID, seqs)
+	})
+}
+
+func (db *commonMsgDatabase) findMsgReactions(ctx context.Context, conversationID string, seqs []int64) (map[int64][]*model.MsgReactionModel, error) {
+	seqReactions := make(map[int64][]*model.MsgReactionModel)
+	for docID, seqs := range db.msgTable.GetDocIDSeqsMap(conversationID, seqs) {
+		indexes := make([]int64, 0, len(seqs))
+		for _, seq := range seqs {
+			indexes = append(indexes, db.msgTable.GetMsgIndex(seq))
+		}
+		reactions, err := db.msgDocDatabase.GetMsgReactions(ctx, docID, indexes)
+		if err != nil {
+			return nil, err
+		}
+		for i, seq := range seqs {
+			if i < len(reactions) && len(reactions[i]) > 0 {
+				seqReactions[seq] = reactions[i]
+			}
+		}
+	}
+	return seqReactions, nil
+}
+
 func (db *commonMsgDatabase) MarkSingleChatMsgsAsRead(ctx context.Context, userID string, conversationID string, totalSeqs []int64) error {
 	for docID, seqs := range db.msgTable.GetDocIDSeqsMap(conversationID, totalSeqs) {
 		var indexes []int64
@@ -822,6 +916,18 @@ func (db *commonMsgDatabase) GetSendMsgStatus(ctx context.Context, id string) (i
 	return db.msg.GetSendMsgStatus(ctx, id)
 }
 
+func (db *commonMsgDatabase) ReserveSendMsg(ctx context.Context, sendID string, clientMsgID string, expire time.Duration) (bool, *pbmsg.SendMsgResp, error) {
+	return db.msg.ReserveSendMsg(ctx, sendID, clientMsgID, expire)
+}
+
+func (db *commonMsgDatabase) SetSendMsgResp(ctx context.Context, sendID string, clientMsgID string, resp *pbmsg.SendMsgResp, expire time.Duration) error {
+	return db.msg.SetSendMsgResp(ctx, sendID, clientMsgID, resp, expire)
+}
+
+func (db *commonMsgDatabase) DeleteSendMsgReservation(ctx context.Context, sendID string, clientMsgID string) error {
+	return db.msg.DeleteSendMsgReservation(ctx, sendID, clientMsgID)
+}
+
 func (db *commonMsgDatabase) GetConversationMinMaxSeqInMongoAndCache(ctx context.Context, conversationID string) (minSeqMongo, maxSeqMongo, minSeqCache, maxSeqCache int64, err error) {
 	minSeqMongo, maxSeqMongo, err = db.GetMinMaxSeqMongo(ctx, conversationID)
 	if err != nil {
@@ -16,22 +16,48 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
 )
 
 type PushDatabase interface {
 	DelFcmToken(ctx context.Context, userID string, platformID int) error
+	DelApnsToken(ctx context.Context, userID string, platformID int) error
+	// AddPushRetry queues a failed offline push of the provider, to be taken at dueTime.
+	AddPushRetry(ctx context.Context, provider string, dueTime time.Time, retry string) error
+	// ClaimPushRetries returns at most count retries of the provider that are due and leases them for lease,
+	// a claimed retry is taken again after the lease unless it was deleted with DeletePushRetry.
+	ClaimPushRetries(ctx context.Context, provider string, lease time.Duration, count int) ([]string, error)
+	DeletePushRetry(ctx context.Context, provider string, retry string) error
 }
 
 type pushDataBase struct {
-	cache cache.ThirdCache
+	cache      cache.ThirdCache
+	retryCache cache.PushRetryCache
 }
 
-func NewPushDatabase(cache cache.ThirdCache) PushDatabase {
-	return &pushDataBase{cache: cache}
+func NewPushDatabase(cache cache.ThirdCache, retryCache cache.PushRetryCache) PushDatabase {
+	return &pushDataBase{cache: cache, retryCache: retryCache}
 }
 
 func (p *pushDataBase) DelFcmToken(ctx context.Context, userID string, platformID int) error {
 	return p.cache.DelFcmToken(ctx, userID, platformID)
 }
+
+func (p *pushDataBase) DelApnsToken(ctx context.Context, userID string, platformID int) error {
+	return p.cache.DelApnsToken(ctx, userID, platformID)
+}
+
+func (p *pushDataBase) AddPushRetry(ctx context.Context, provider string, dueTime time.Time, retry string) error {
+	return p.retryCache.AddPushRetry(ctx, provider, dueTime.UnixMilli(), retry)
+}
+
+func (p *pushDataBase) ClaimPushRetries(ctx context.Context, provider string, lease time.Duration, count int) ([]string, error) {
+	now := time.Now()
+	return p.retryCache.ClaimPushRetries(ctx, provider, now.UnixMilli(), now.Add(lease).UnixMilli(), count)
+}
+
+func (p *pushDataBase) DeletePushRetry(ctx context.Context, provider string, retry string) error {
+	return p.retryCache.DeletePushRetry(ctx, provider, retry)
+}
@@ -0,0 +1,85 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database/mgo"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+)
+
+type ScheduledMsgDatabase interface {
+	// CreateScheduledMsg saves a new pending message
+	CreateScheduledMsg(ctx context.Context, msg *model.ScheduledMsg) error
+	// TakeScheduledMsg gets a message, mongo.ErrNoDocuments when it does not exist
+	TakeScheduledMsg(ctx context.Context, scheduleID string) (*model.ScheduledMsg, error)
+	// PageScheduledMsgs gets the messages of a sender in schedule time order, a zero status gets every message
+	PageScheduledMsgs(ctx context.Context, sendID string, status int32, pagination pagination.Pagination) (int64, []*model.ScheduledMsg, error)
+	// CountPendingScheduledMsgs counts the messages of a sender waiting to be sent
+	CountPendingScheduledMsgs(ctx context.Context, sendID string) (int64, error)
+	// CancelScheduledMsg cancels a pending message, false when it is no longer pending
+	CancelScheduledMsg(ctx context.Context, scheduleID string) (bool, error)
+	// ClaimDueScheduledMsg takes the earliest due message to send it, nil when none is due
+	ClaimDueScheduledMsg(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.ScheduledMsg, error)
+	// FinishScheduledMsg saves the result of sending a claimed message
+	FinishScheduledMsg(ctx context.Context, scheduleID string, status int32, serverMsgID string, errCode int32, errMsg string, deliverTime time.Time) error
+}
+
+func NewScheduledMsgDatabase(db database.ScheduledMsg) ScheduledMsgDatabase {
+	return &scheduledMsgDatabase{db: db}
+}
+
+type scheduledMsgDatabase struct {
+	db database.ScheduledMsg
+}
+
+func (s *scheduledMsgDatabase) CreateScheduledMsg(ctx context.Context, msg *model.ScheduledMsg) error {
+	return s.db.Create(ctx, msg)
+}
+
+func (s *scheduledMsgDatabase) TakeScheduledMsg(ctx context.Context, scheduleID string) (*model.ScheduledMsg, error) {
+	return s.db.Take(ctx, scheduleID)
+}
+
+func (s *scheduledMsgDatabase) PageScheduledMsgs(ctx context.Context, sendID string, status int32, pagination pagination.Pagination) (int64, []*model.ScheduledMsg, error) {
+	return s.db.Page(ctx, sendID, status, pagination)
+}
+
+func (s *scheduledMsgDatabase) CountPendingScheduledMsgs(ctx context.Context, sendID string) (int64, error) {
+	return s.db.CountPending(ctx, sendID)
+}
+
+func (s *scheduledMsgDatabase) CancelScheduledMsg(ctx context.Context, scheduleID string) (bool, error) {
+	return s.db.Cancel(ctx, scheduleID)
+}
+
+func (s *scheduledMsgDatabase) ClaimDueScheduledMsg(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.ScheduledMsg, error) {
+	msg, err := s.db.ClaimDue(ctx, now, leaseUntil)
+	if err != nil {
+		if mgo.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *scheduledMsgDatabase) FinishScheduledMsg(ctx context.Context, scheduleID string, status int32, serverMsgID string, errCode int32, errMsg string, deliverTime time.Time) error {
+	return s.db.Finish(ctx, scheduleID, status, serverMsgID, errCode, errMsg, deliverTime)
+}
@@ -21,12 +21,17 @@ import (
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 
+	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/cache"
 	"github.com/KyleYe/open-im-tools/db/pagination"
 )
 
 type ThirdDatabase interface {
 	FcmUpdateToken(ctx context.Context, account string, platformID int, fcmToken string, expireTime int64) error
+	ApnsUpdateToken(ctx context.Context, account string, platformID int, deviceToken string, voipToken string, expireTime int64) error
+	// SetWebPushSubscription fails with ErrWebPushSubscriptionLimit when the user has maxCount other subscriptions.
+	SetWebPushSubscription(ctx context.Context, userID string, subscription *model.WebPushSubscription, maxCount int) error
+	DelWebPushSubscription(ctx context.Context, userID string, endpoint string) error
 	SetAppBadge(ctx context.Context, userID string, value int) error
 	// about log for debug
 	UploadLogs(ctx context.Context, logs []*model.Log) error
@@ -68,6 +73,25 @@ func (t *thirdDatabase) FcmUpdateToken(ctx context.Context, account string, plat
 	return t.cache.SetFcmToken(ctx, account, platformID, fcmToken, expireTime)
 }
 
+func (t *thirdDatabase) ApnsUpdateToken(ctx context.Context, account string, platformID int, deviceToken string, voipToken string, expireTime int64) error {
+	return t.cache.SetApnsToken(ctx, account, platformID, deviceToken, voipToken, expireTime)
+}
+
+func (t *thirdDatabase) SetWebPushSubscription(ctx context.Context, userID string, subscription *model.WebPushSubscription, maxCount int) error {
+	ok, err := t.cache.SetWebPushSubscription(ctx, userID, subscription, maxCount)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return servererrs.ErrWebPushSubscriptionLimit.WrapMsg("too many web push subscriptions", "userID", userID, "max", maxCount)
+	}
+	return nil
+}
+
+func (t *thirdDatabase) DelWebPushSubscription(ctx context.Context, userID string, endpoint string) error {
+	return t.cache.DelWebPushSubscriptions(ctx, userID, endpoint)
+}
+
 func (t *thirdDatabase) SetAppBadge(ctx context.Context, userID string, value int) error {
 	return t.cache.SetUserBadgeUnreadCountSum(ctx, userID, value)
 }
@@ -53,6 +53,10 @@ type UserDatabase interface {
 	IsExist(ctx context.Context, userIDs []string) (exist bool, err error)
 	// GetAllUserID Get all user IDs
 	GetAllUserID(ctx context.Context, pagination pagination.Pagination) (int64, []string, error)
+	// ScanUserID Get the user IDs after afterUserID in ascending order, optionally limited to a create time range
+	ScanUserID(ctx context.Context, afterUserID string, start *time.Time, end *time.Time, limit int) ([]string, error)
+	// FindNearby Get discoverable users around a point, nearest first
+	FindNearby(ctx context.Context, latitude, longitude, maxDistance float64, userIDs []string, excludeUserID string, pagination pagination.Pagination) (count int64, users []*model.NearbyUser, err error)
 	// Get user by userID
 	GetUserByID(ctx context.Context, userID string) (user *model.User, err error)
 	// InitOnce Inside the function, first query whether it exists in the storage, if it exists, do nothing; if it does not exist, insert it
@@ -187,6 +191,15 @@ func (u *userDatabase) GetAllUserID(ctx context.Context, pagination pagination.P
 	return u.userDB.GetAllUserID(ctx, pagination)
 }
 
+// ScanUserID Get the user IDs after afterUserID.
+func (u *userDatabase) ScanUserID(ctx context.Context, afterUserID string, start *time.Time, end *time.Time, limit int) (userIDs []string, err error) {
+	return u.userDB.ScanUserID(ctx, afterUserID, start, end, limit)
+}
+
+func (u *userDatabase) FindNearby(ctx context.Context, latitude, longitude, maxDistance float64, userIDs []string, excludeUserID string, pagination pagination.Pagination) (count int64, users []*model.NearbyUser, err error) {
+	return u.userDB.FindNearby(ctx, latitude, longitude, maxDistance, userIDs, excludeUserID, pagination)
+}
+
 func (u *userDatabase) GetUserByID(ctx context.Context, userID string) (user *model.User, err error) {
 	return u.cache.GetUserInfo(ctx, userID)
 }
@@ -0,0 +1,42 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+)
+
+type Broadcast interface {
+	Create(ctx context.Context, job *model.BroadcastJob) error
+	Take(ctx context.Context, jobID string) (*model.BroadcastJob, error)
+	// Page returns the jobs newest first, a zero status returns every job
+	Page(ctx context.Context, status int32, pagination pagination.Pagination) (int64, []*model.BroadcastJob, error)
+	// Cancel cancels a pending or running job, false when the job is already finished
+	Cancel(ctx context.Context, jobID string) (bool, error)
+	// Claim leases a pending job, or a running job whose lease expired, to workerID.
+	// It returns mongo.ErrNoDocuments when there is nothing to do.
+	Claim(ctx context.Context, workerID string, leaseUntil time.Time) (*model.BroadcastJob, error)
+	// Progress moves the cursor of a job leased to workerID, adds to the counters and renews the lease.
+	// A done job is completed. It returns false when the job is no longer leased to workerID, for example canceled.
+	Progress(ctx context.Context, jobID string, workerID string, cursor string, success int64, failed int64, leaseUntil time.Time, done bool) (bool, error)
+	// SetResult saves the result of one recipient, replacing the previous one
+	SetResult(ctx context.Context, result *model.BroadcastResult) error
+	FindResults(ctx context.Context, jobID string, recvIDs []string) ([]*model.BroadcastResult, error)
+	PageResults(ctx context.Context, jobID string, onlyFailed bool, pagination pagination.Pagination) (int64, []*model.BroadcastResult, error)
+}
@@ -16,6 +16,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
 	"github.com/KyleYe/open-im-tools/db/pagination"
@@ -57,4 +58,6 @@ type Friend interface {
 	FindOwnerFriendUserIds(ctx context.Context, ownerUserID string, limit int) ([]string, error)
 
 	IncrVersion(ctx context.Context, ownerUserID string, friendUserIDs []string, state int32) error
+	// CompactIncrVersion removes the version logs unchanged since the deadline
+	CompactIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error)
 }
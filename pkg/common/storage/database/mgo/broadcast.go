@@ -0,0 +1,172 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/mongoutil"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+	"github.com/KyleYe/open-im-tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewBroadcastMongo(db *mongo.Database) (database.Broadcast, error) {
+	jobColl := db.Collection(database.BroadcastJobName)
+	_, err := jobColl.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "job_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "create_time", Value: 1},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	resultColl := db.Collection(database.BroadcastResultName)
+	_, err = resultColl.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "job_id", Value: 1},
+				{Key: "recv_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "job_id", Value: 1},
+				{Key: "err_code", Value: 1},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &BroadcastMgo{jobColl: jobColl, resultColl: resultColl}, nil
+}
+
+type BroadcastMgo struct {
+	jobColl    *mongo.Collection
+	resultColl *mongo.Collection
+}
+
+func (b *BroadcastMgo) Create(ctx context.Context, job *model.BroadcastJob) error {
+	return mongoutil.InsertMany(ctx, b.jobColl, []*model.BroadcastJob{job})
+}
+
+func (b *BroadcastMgo) Take(ctx context.Context, jobID string) (*model.BroadcastJob, error) {
+	return mongoutil.FindOne[*model.BroadcastJob](ctx, b.jobColl, bson.M{"job_id": jobID})
+}
+
+func (b *BroadcastMgo) Page(ctx context.Context, status int32, pagination pagination.Pagination) (int64, []*model.BroadcastJob, error) {
+	filter := bson.M{}
+	if status != 0 {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}}).SetProjection(bson.M{"user_ids": 0})
+	return mongoutil.FindPage[*model.BroadcastJob](ctx, b.jobColl, filter, pagination, opts)
+}
+
+func (b *BroadcastMgo) Cancel(ctx context.Context, jobID string) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"job_id": jobID,
+		"status": bson.M{"$in": []int32{model.BroadcastStatusPending, model.BroadcastStatusRunning}},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":      model.BroadcastStatusCanceled,
+		"update_time": now,
+		"finish_time": now,
+	}}
+	res, err := mongoutil.UpdateOneResult(ctx, b.jobColl, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (b *BroadcastMgo) Claim(ctx context.Context, workerID string, leaseUntil time.Time) (*model.BroadcastJob, error) {
+	now := time.Now()
+	filter := bson.M{
+		"$or": []bson.M{
+			{"status": model.BroadcastStatusPending},
+			{"status": model.BroadcastStatusRunning, "lease_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":      model.BroadcastStatusRunning,
+		"worker_id":   workerID,
+		"lease_until": leaseUntil,
+		"update_time": now,
+	}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "create_time", Value: 1}}).SetReturnDocument(options.After)
+	return mongoutil.FindOneAndUpdate[*model.BroadcastJob](ctx, b.jobColl, filter, update, opts)
+}
+
+func (b *BroadcastMgo) Progress(ctx context.Context, jobID string, workerID string, cursor string, success int64, failed int64, leaseUntil time.Time, done bool) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"job_id":    jobID,
+		"worker_id": workerID,
+		"status":    model.BroadcastStatusRunning,
+	}
+	set := bson.M{
+		"cursor":      cursor,
+		"lease_until": leaseUntil,
+		"update_time": now,
+	}
+	if done {
+		set["status"] = model.BroadcastStatusCompleted
+		set["finish_time"] = now
+	}
+	update := bson.M{
+		"$set": set,
+		"$inc": bson.M{"success": success, "failed": failed},
+	}
+	res, err := mongoutil.UpdateOneResult(ctx, b.jobColl, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (b *BroadcastMgo) SetResult(ctx context.Context, result *model.BroadcastResult) error {
+	filter := bson.M{"job_id": result.JobID, "recv_id": result.RecvID}
+	return mongoutil.UpdateOne(ctx, b.resultColl, filter, bson.M{"$set": result}, false, options.Update().SetUpsert(true))
+}
+
+func (b *BroadcastMgo) FindResults(ctx context.Context, jobID string, recvIDs []string) ([]*model.BroadcastResult, error) {
+	return mongoutil.Find[*model.BroadcastResult](ctx, b.resultColl, bson.M{"job_id": jobID, "recv_id": bson.M{"$in": recvIDs}})
+}
+
+func (b *BroadcastMgo) PageResults(ctx context.Context, jobID string, onlyFailed bool, pagination pagination.Pagination) (int64, []*model.BroadcastResult, error) {
+	filter := bson.M{"job_id": jobID}
+	if onlyFailed {
+		filter["err_code"] = bson.M{"$ne": 0}
+	}
+	return mongoutil.FindPage[*model.BroadcastResult](ctx, b.resultColl, filter, pagination, options.Find().SetSort(bson.D{{Key: "recv_id", Value: 1}}))
+}
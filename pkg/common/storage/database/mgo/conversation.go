@@ -214,3 +214,11 @@ func (c *ConversationMgo) GetConversationNotReceiveMessageUserIDs(ctx context.Co
 func (c *ConversationMgo) FindConversationUserVersion(ctx context.Context, userID string, version uint, limit int) (*model.VersionLog, error) {
 	return c.version.FindChangeLog(ctx, userID, version, limit)
 }
+
+func (c *ConversationMgo) CompactConversationUserVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error) {
+	res, err := c.version.CompactLog(ctx, deadline)
+	if err != nil {
+		return nil, err
+	}
+	return []*model.VersionLogCompaction{res}, nil
+}
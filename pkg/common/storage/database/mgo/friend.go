@@ -251,6 +251,14 @@ func (f *FriendMgo) FindIncrVersion(ctx context.Context, ownerUserID string, ver
 	return f.owner.FindChangeLog(ctx, ownerUserID, version, limit)
 }
 
+func (f *FriendMgo) CompactIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error) {
+	res, err := f.owner.CompactLog(ctx, deadline)
+	if err != nil {
+		return nil, err
+	}
+	return []*model.VersionLogCompaction{res}, nil
+}
+
 func (f *FriendMgo) FindFriendUserID(ctx context.Context, friendUserID string) ([]string, error) {
 	filter := bson.M{
 		"friend_user_id": friendUserID,
@@ -16,6 +16,7 @@ package mgo
 
 import (
 	"context"
+	"time"
 
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
@@ -240,3 +241,15 @@ func (g *GroupMemberMgo) FindJoinIncrVersion(ctx context.Context, userID string,
 	log.ZDebug(ctx, "find join incr version", "userID", userID, "version", version)
 	return g.join.FindChangeLog(ctx, userID, version, limit)
 }
+
+func (g *GroupMemberMgo) CompactIncrVersion(ctx context.Context, deadline time.Time) ([]*model.VersionLogCompaction, error) {
+	member, err := g.member.CompactLog(ctx, deadline)
+	if err != nil {
+		return nil, err
+	}
+	join, err := g.join.CompactLog(ctx, deadline)
+	if err != nil {
+		return nil, err
+	}
+	return []*model.VersionLogCompaction{member, join}, nil
+}
@@ -79,10 +79,90 @@ func (m *MsgMgo) PushUnique(ctx context.Context, docID string, index int64, key
 	return mongoutil.UpdateOneResult(ctx, m.coll, filter, update)
 }
 
-func (m *MsgMgo) UpdateMsgContent(ctx context.Context, docID string, index int64, msg []byte) error {
+func (m *MsgMgo) PullAll(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error) {
+	var field string
+	if key == "" {
+		field = fmt.Sprintf("msgs.%d", index)
+	} else {
+		field = fmt.Sprintf("msgs.%d.%s", index, key)
+	}
 	filter := bson.M{"doc_id": docID}
-	update := bson.M{"$set": bson.M{fmt.Sprintf("msgs.%d.msg", index): msg}}
-	return mongoutil.UpdateOne(ctx, m.coll, filter, update, false)
+	update := bson.M{
+		"$pullAll": bson.M{
+			field: value,
+		},
+	}
+	return mongoutil.UpdateOneResult(ctx, m.coll, filter, update)
+}
+
+func (m *MsgMgo) AddReaction(ctx context.Context, docID string, index int64, reaction *model.MsgReactionModel, maxEmojis int) (*mongo.UpdateResult, error) {
+	field := fmt.Sprintf("msgs.%d.reactions", index)
+	filter := bson.M{"doc_id": docID}
+	if maxEmojis > 0 {
+		emojis := bson.M{
+			"$let": bson.M{
+				"vars": bson.M{"msg": bson.M{"$arrayElemAt": bson.A{"$msgs", index}}},
+				"in":   bson.M{"$ifNull": bson.A{"$$msg.reactions.emoji", bson.A{}}},
+			},
+		}
+		filter["$or"] = bson.A{
+			bson.M{field + ".emoji": reaction.Emoji},
+			bson.M{"$expr": bson.M{"$lt": bson.A{bson.M{"$size": bson.M{"$setUnion": bson.A{emojis}}}, maxEmojis}}},
+		}
+	}
+	update := bson.M{"$addToSet": bson.M{field: reaction}}
+	return mongoutil.UpdateOneResult(ctx, m.coll, filter, update)
+}
+
+func (m *MsgMgo) GetMsgReactions(ctx context.Context, docID string, indexes []int64) ([][]*model.MsgReactionModel, error) {
+	pipeline := bson.A{
+		bson.M{"$match": bson.M{"doc_id": docID}},
+		bson.M{
+			"$project": bson.M{
+				"_id": 0,
+				"reactions": bson.M{
+					"$map": bson.M{
+						"input": indexes,
+						"as":    "index",
+						"in": bson.M{
+							"$let": bson.M{
+								"vars": bson.M{"msg": bson.M{"$arrayElemAt": bson.A{"$msgs", "$$index"}}},
+								"in":   bson.M{"$ifNull": bson.A{"$$msg.reactions", bson.A{}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	res, err := mongoutil.Aggregate[*struct {
+		Reactions [][]*model.MsgReactionModel `bson:"reactions"`
+	}](ctx, m.coll, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return make([][]*model.MsgReactionModel, len(indexes)), nil
+	}
+	return res[0].Reactions, nil
+}
+
+func (m *MsgMgo) UpdateMsgContent(ctx context.Context, docID string, index int64, oldContent string, content string, history *model.MsgEditModel) (bool, error) {
+	field := fmt.Sprintf("msgs.%d", index)
+	filter := bson.M{
+		"doc_id":               docID,
+		field + ".msg.content": oldContent,
+		field + ".revoke":      nil,
+	}
+	update := bson.M{
+		"$set":  bson.M{field + ".msg.content": content},
+		"$push": bson.M{field + ".edit_history": history},
+	}
+	res, err := mongoutil.UpdateOneResult(ctx, m.coll, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
 }
 
 func (m *MsgMgo) IsExistDocID(ctx context.Context, docID string) (bool, error) {
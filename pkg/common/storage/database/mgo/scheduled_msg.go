@@ -0,0 +1,120 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mgo
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/database"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/mongoutil"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+	"github.com/KyleYe/open-im-tools/errs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewScheduledMsgMongo(db *mongo.Database) (database.ScheduledMsg, error) {
+	coll := db.Collection(database.ScheduledMsgName)
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "schedule_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "status", Value: 1},
+				{Key: "schedule_time", Value: 1},
+			},
+		},
+		{
+			Keys: bson.D{
+				{Key: "send_id", Value: 1},
+				{Key: "schedule_time", Value: 1},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &ScheduledMsgMgo{coll: coll}, nil
+}
+
+type ScheduledMsgMgo struct {
+	coll *mongo.Collection
+}
+
+func (s *ScheduledMsgMgo) Create(ctx context.Context, msg *model.ScheduledMsg) error {
+	return mongoutil.InsertMany(ctx, s.coll, []*model.ScheduledMsg{msg})
+}
+
+func (s *ScheduledMsgMgo) Take(ctx context.Context, scheduleID string) (*model.ScheduledMsg, error) {
+	return mongoutil.FindOne[*model.ScheduledMsg](ctx, s.coll, bson.M{"schedule_id": scheduleID})
+}
+
+func (s *ScheduledMsgMgo) Page(ctx context.Context, sendID string, status int32, pagination pagination.Pagination) (int64, []*model.ScheduledMsg, error) {
+	filter := bson.M{"send_id": sendID}
+	if status != 0 {
+		filter["status"] = status
+	}
+	return mongoutil.FindPage[*model.ScheduledMsg](ctx, s.coll, filter, pagination, options.Find().SetSort(bson.D{{Key: "schedule_time", Value: 1}}))
+}
+
+func (s *ScheduledMsgMgo) CountPending(ctx context.Context, sendID string) (int64, error) {
+	return mongoutil.Count(ctx, s.coll, bson.M{"send_id": sendID, "status": model.ScheduledMsgStatusPending})
+}
+
+func (s *ScheduledMsgMgo) Cancel(ctx context.Context, scheduleID string) (bool, error) {
+	filter := bson.M{"schedule_id": scheduleID, "status": model.ScheduledMsgStatusPending}
+	update := bson.M{"$set": bson.M{"status": model.ScheduledMsgStatusCanceled, "update_time": time.Now()}}
+	res, err := mongoutil.UpdateOneResult(ctx, s.coll, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (s *ScheduledMsgMgo) ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.ScheduledMsg, error) {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"status": model.ScheduledMsgStatusPending, "schedule_time": bson.M{"$lte": now}},
+			{"status": model.ScheduledMsgStatusSending, "lease_until": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":      model.ScheduledMsgStatusSending,
+		"lease_until": leaseUntil,
+		"update_time": now,
+	}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "schedule_time", Value: 1}}).SetReturnDocument(options.After)
+	return mongoutil.FindOneAndUpdate[*model.ScheduledMsg](ctx, s.coll, filter, update, opts)
+}
+
+func (s *ScheduledMsgMgo) Finish(ctx context.Context, scheduleID string, status int32, serverMsgID string, errCode int32, errMsg string, deliverTime time.Time) error {
+	filter := bson.M{"schedule_id": scheduleID, "status": model.ScheduledMsgStatusSending}
+	update := bson.M{"$set": bson.M{
+		"status":        status,
+		"server_msg_id": serverMsgID,
+		"err_code":      errCode,
+		"err_msg":       errMsg,
+		"update_time":   deliverTime,
+		"deliver_time":  deliverTime,
+	}}
+	return mongoutil.UpdateOne(ctx, s.coll, filter, update, false)
+}
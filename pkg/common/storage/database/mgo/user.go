@@ -33,18 +33,54 @@ import (
 
 func NewUserMongo(db *mongo.Database) (database.User, error) {
 	coll := db.Collection(database.UserName)
-	_, err := coll.Indexes().CreateOne(context.Background(), mongo.IndexModel{
-		Keys: bson.D{
-			{Key: "user_id", Value: 1},
+	_, err := coll.Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "user_id", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: "location", Value: "2dsphere"},
+			},
 		},
-		Options: options.Index().SetUnique(true),
 	})
 	if err != nil {
 		return nil, errs.Wrap(err)
 	}
+	if err := migrateUserLocation(context.Background(), coll); err != nil {
+		return nil, err
+	}
 	return &UserMgo{coll: coll}, nil
 }
 
+// migrateUserLocation moves the latitude and longitude fields of older versions into the location point.
+// Users without valid coordinates, including the zero values of users that never set them, get no location.
+func migrateUserLocation(ctx context.Context, coll *mongo.Collection) error {
+	valid := bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{bson.M{"$type": "$location"}, "missing"}},
+		bson.M{"$isNumber": "$latitude"},
+		bson.M{"$isNumber": "$longitude"},
+		bson.M{"$or": bson.A{bson.M{"$ne": bson.A{"$latitude", 0}}, bson.M{"$ne": bson.A{"$longitude", 0}}}},
+		bson.M{"$gte": bson.A{"$latitude", -90}}, bson.M{"$lte": bson.A{"$latitude", 90}},
+		bson.M{"$gte": bson.A{"$longitude", -180}}, bson.M{"$lte": bson.A{"$longitude", 180}},
+	}}
+	update := bson.A{
+		bson.M{"$set": bson.M{"location": bson.M{"$cond": bson.A{
+			valid,
+			bson.M{"type": "Point", "coordinates": bson.A{"$longitude", "$latitude"}},
+			"$location",
+		}}}},
+		bson.M{"$unset": bson.A{"latitude", "longitude"}},
+	}
+	filter := bson.M{"$or": bson.A{bson.M{"latitude": bson.M{"$exists": true}}, bson.M{"longitude": bson.M{"$exists": true}}}}
+	if _, err := coll.UpdateMany(ctx, filter, update); err != nil {
+		return errs.WrapMsg(err, "migrate user location")
+	}
+	return nil
+}
+
 type UserMgo struct {
 	coll *mongo.Collection
 }
@@ -130,6 +166,79 @@ func (u *UserMgo) GetAllUserID(ctx context.Context, pagination pagination.Pagina
 	return mongoutil.FindPage[string](ctx, u.coll, bson.M{}, pagination, options.Find().SetProjection(bson.M{"_id": 0, "user_id": 1}))
 }
 
+func (u *UserMgo) ScanUserID(ctx context.Context, afterUserID string, start *time.Time, end *time.Time, limit int) ([]string, error) {
+	filter := bson.M{}
+	if afterUserID != "" {
+		filter["user_id"] = bson.M{"$gt": afterUserID}
+	}
+	if start != nil || end != nil {
+		createTime := bson.M{}
+		if start != nil {
+			createTime["$gte"] = *start
+		}
+		if end != nil {
+			createTime["$lt"] = *end
+		}
+		filter["create_time"] = createTime
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 0, "user_id": 1}).SetSort(bson.M{"user_id": 1}).SetLimit(int64(limit))
+	return mongoutil.Find[string](ctx, u.coll, filter, opts)
+}
+
+func (u *UserMgo) FindNearby(ctx context.Context, latitude, longitude, maxDistance float64, userIDs []string, excludeUserID string, pagination pagination.Pagination) (int64, []*model.NearbyUser, error) {
+	if userIDs != nil && len(userIDs) == 0 {
+		return 0, nil, nil
+	}
+	userFilter := bson.M{"$ne": excludeUserID}
+	if userIDs != nil {
+		userFilter["$in"] = userIDs
+	}
+	if pagination.GetPageNumber() <= 0 || pagination.GetShowNumber() <= 0 {
+		return 0, nil, errs.ErrArgs.WrapMsg("invalid pagination", "pageNumber", pagination.GetPageNumber(), "showNumber", pagination.GetShowNumber())
+	}
+	skip := int64(pagination.GetPageNumber()-1) * int64(pagination.GetShowNumber())
+	pipeline := bson.A{
+		bson.M{
+			"$geoNear": bson.M{
+				"near":          model.NewGeoPoint(latitude, longitude),
+				"key":           "location",
+				"distanceField": "distance",
+				"maxDistance":   maxDistance,
+				"spherical":     true,
+				"query": bson.M{
+					"user_id":           userFilter,
+					"discovery_opt_out": bson.M{"$ne": true},
+				},
+			},
+		},
+		bson.M{
+			"$facet": bson.M{
+				"total": bson.A{
+					bson.M{"$count": "count"},
+				},
+				"users": bson.A{
+					bson.M{"$skip": skip},
+					bson.M{"$limit": int64(pagination.GetShowNumber())},
+				},
+			},
+		},
+	}
+	type Result struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Users []*model.NearbyUser `bson:"users"`
+	}
+	res, err := mongoutil.Aggregate[*Result](ctx, u.coll, pipeline)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(res) == 0 || len(res[0].Total) == 0 {
+		return 0, nil, nil
+	}
+	return res[0].Total[0].Count, res[0].Users, nil
+}
+
 func (u *UserMgo) Exist(ctx context.Context, userID string) (exist bool, err error) {
 	return mongoutil.Exist(ctx, u.coll, bson.M{"user_id": userID})
 }
@@ -237,7 +237,7 @@ func (l *VersionLogMgo) findChangeLog(ctx context.Context, dId string, version u
 						"if": bson.M{
 							"$or": []bson.M{
 								{"$lt": []any{"$version", version}},
-								{"$gte": []any{"$deleted", version}},
+								{"$gt": []any{"$deleted", version}},
 							},
 						},
 						"then": []any{},
@@ -299,6 +299,80 @@ func (l *VersionLogMgo) DeleteAfterUnchangedLog(ctx context.Context, deadline ti
 	})
 }
 
+// CompactLog removes the logs unchanged since the deadline. The deleted version of a compacted doc
+// is raised to the newest removed log, a client with an older version has to sync in full.
+func (l *VersionLogMgo) CompactLog(ctx context.Context, deadline time.Time) (*model.VersionLogCompaction, error) {
+	filter := bson.M{
+		"logs.last_update": bson.M{
+			"$lt": deadline,
+		},
+	}
+	expired := bson.M{
+		"$filter": bson.M{
+			"input": "$logs",
+			"as":    "l",
+			"cond": bson.M{
+				"$lt": []any{"$$l.last_update", deadline},
+			},
+		},
+	}
+	res := &model.VersionLogCompaction{Collection: l.coll.Name()}
+	count, err := mongoutil.Aggregate[struct {
+		Docs int64 `bson:"docs"`
+		Logs int64 `bson:"logs"`
+	}](ctx, l.coll, []bson.M{
+		{
+			"$match": filter,
+		},
+		{
+			"$group": bson.M{
+				"_id":  nil,
+				"docs": bson.M{"$sum": 1},
+				"logs": bson.M{"$sum": bson.M{"$size": expired}},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(count) == 0 {
+		return res, nil
+	}
+	res.Docs, res.Logs = count[0].Docs, count[0].Logs
+	pipeline := []bson.M{
+		{
+			"$set": bson.M{
+				"deleted": bson.M{
+					"$max": []any{"$deleted", bson.M{"$max": bson.M{
+						"$map": bson.M{
+							"input": expired,
+							"as":    "l",
+							"in":    "$$l.version",
+						},
+					}}},
+				},
+			},
+		},
+		{
+			"$set": bson.M{
+				"logs": bson.M{
+					"$filter": bson.M{
+						"input": "$logs",
+						"as":    "l",
+						"cond": bson.M{
+							"$gte": []any{"$$l.last_update", deadline},
+						},
+					},
+				},
+			},
+		},
+	}
+	if _, err := l.coll.UpdateMany(ctx, filter, pipeline); err != nil {
+		return nil, errs.WrapMsg(err, "compact version log failed", "coll", l.coll.Name())
+	}
+	return res, nil
+}
+
 func (l *VersionLogMgo) Delete(ctx context.Context, dId string) error {
 	return mongoutil.DeleteOne(ctx, l.coll, bson.M{"d_id": dId})
 }
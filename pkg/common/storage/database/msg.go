@@ -28,7 +28,15 @@ type Msg interface {
 	Create(ctx context.Context, model *model.MsgDocModel) error
 	UpdateMsg(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error)
 	PushUnique(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error)
-	UpdateMsgContent(ctx context.Context, docID string, index int64, msg []byte) error
+	PullAll(ctx context.Context, docID string, index int64, key string, value any) (*mongo.UpdateResult, error)
+	// AddReaction adds the reaction to the message at index unless it is already there. When maxEmojis is positive,
+	// nothing matches when the emoji is new to the message and the message already has maxEmojis distinct emojis.
+	AddReaction(ctx context.Context, docID string, index int64, reaction *model.MsgReactionModel, maxEmojis int) (*mongo.UpdateResult, error)
+	// GetMsgReactions returns the reactions of the messages at indexes, in the order of indexes.
+	GetMsgReactions(ctx context.Context, docID string, indexes []int64) ([][]*model.MsgReactionModel, error)
+	// UpdateMsgContent replaces the content of the message when it is still oldContent and not revoked,
+	// history is appended to its edit history. It returns false when nothing matched.
+	UpdateMsgContent(ctx context.Context, docID string, index int64, oldContent string, content string, history *model.MsgEditModel) (bool, error)
 	IsExistDocID(ctx context.Context, docID string) (bool, error)
 	FindOneByDocID(ctx context.Context, docID string) (*model.MsgDocModel, error)
 	GetMsgBySeqIndexIn1Doc(ctx context.Context, userID, docID string, seqs []int64) ([]*model.MsgInfoModel, error)
@@ -2,6 +2,8 @@ package database
 
 const (
 	BlackName               = "black"
+	BroadcastJobName        = "broadcast_job"
+	BroadcastResultName     = "broadcast_result"
 	ConversationName        = "conversation"
 	FriendName              = "friend"
 	FriendVersionName       = "friend_version"
@@ -17,4 +19,5 @@ const (
 	UserName                = "user"
 	SeqConversationName     = "seq"
 	SeqUserName             = "seq_user"
+	ScheduledMsgName        = "scheduled_msg"
 )
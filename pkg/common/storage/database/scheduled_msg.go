@@ -0,0 +1,38 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/storage/model"
+	"github.com/KyleYe/open-im-tools/db/pagination"
+)
+
+type ScheduledMsg interface {
+	Create(ctx context.Context, msg *model.ScheduledMsg) error
+	Take(ctx context.Context, scheduleID string) (*model.ScheduledMsg, error)
+	// Page returns the messages of sendID in schedule time order, a zero status returns every message
+	Page(ctx context.Context, sendID string, status int32, pagination pagination.Pagination) (int64, []*model.ScheduledMsg, error)
+	CountPending(ctx context.Context, sendID string) (int64, error)
+	// Cancel cancels a pending message, false when it is no longer pending
+	Cancel(ctx context.Context, scheduleID string) (bool, error)
+	// ClaimDue marks the earliest due pending message, or a sending message whose lease expired, as sending until leaseUntil.
+	// It returns mongo.ErrNoDocuments when no message is due.
+	ClaimDue(ctx context.Context, now time.Time, leaseUntil time.Time) (*model.ScheduledMsg, error)
+	// Finish saves the result of a sending message
+	Finish(ctx context.Context, scheduleID string, status int32, serverMsgID string, errCode int32, errMsg string, deliverTime time.Time) error
+}
@@ -35,7 +35,13 @@ type User interface {
 	PageFindUserWithKeyword(ctx context.Context, level1 int64, level2 int64, userID, nickName string, pagination pagination.Pagination) (count int64, users []*model.User, err error)
 	Exist(ctx context.Context, userID string) (exist bool, err error)
 	GetAllUserID(ctx context.Context, pagination pagination.Pagination) (count int64, userIDs []string, err error)
+	// ScanUserID returns up to limit user IDs greater than afterUserID in ascending order.
+	// A non nil start or end keeps only the users created in [start, end).
+	ScanUserID(ctx context.Context, afterUserID string, start *time.Time, end *time.Time, limit int) (userIDs []string, err error)
 	GetUserGlobalRecvMsgOpt(ctx context.Context, userID string) (opt int, err error)
+	// FindNearby returns discoverable users within maxDistance meters of the point, nearest first.
+	// A nil userIDs searches every user, excludeUserID is never returned.
+	FindNearby(ctx context.Context, latitude, longitude, maxDistance float64, userIDs []string, excludeUserID string, pagination pagination.Pagination) (count int64, users []*model.NearbyUser, err error)
 	// Get user total quantity
 	CountTotal(ctx context.Context, before *time.Time) (count int64, err error)
 	// Get user total quantity every day
@@ -17,5 +17,7 @@ type VersionLog interface {
 	FindChangeLog(ctx context.Context, dId string, version uint, limit int) (*model.VersionLog, error)
 	BatchFindChangeLog(ctx context.Context, dIds []string, versions []uint, limits []int) ([]*model.VersionLog, error)
 	DeleteAfterUnchangedLog(ctx context.Context, deadline time.Time) error
+	// CompactLog removes the logs unchanged since the deadline and raises the deleted version of the docs
+	CompactLog(ctx context.Context, deadline time.Time) (*model.VersionLogCompaction, error)
 	Delete(ctx context.Context, dId string) error
 }
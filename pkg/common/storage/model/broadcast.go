@@ -0,0 +1,68 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+const (
+	BroadcastStatusPending   = 1
+	BroadcastStatusRunning   = 2
+	BroadcastStatusCompleted = 3
+	BroadcastStatusCanceled  = 4
+)
+
+// BroadcastJob is an admin message sent to many users in the background. Recipients are processed in
+// ascending user ID order and Cursor is the last one handled, so a job resumes from there after a restart.
+type BroadcastJob struct {
+	JobID    string `bson:"job_id"`
+	OpUserID string `bson:"op_user_id"`
+	// MsgData is the encoded sdkws.MsgData, the recvID is set for every recipient
+	MsgData []byte `bson:"msg_data"`
+	// TargetType is the type of the broadcast target, the users are the listed UserIDs or the users created in the time range
+	TargetType      int32     `bson:"target_type"`
+	UserIDs         []string  `bson:"user_ids"`
+	CreateTimeBegin time.Time `bson:"create_time_begin"`
+	CreateTimeEnd   time.Time `bson:"create_time_end"`
+	// Rate is the number of messages sent per second
+	Rate       int32     `bson:"rate"`
+	Status     int32     `bson:"status"`
+	Cursor     string    `bson:"cursor"`
+	Total      int64     `bson:"total"`
+	Success    int64     `bson:"success"`
+	Failed     int64     `bson:"failed"`
+	WorkerID   string    `bson:"worker_id"`
+	LeaseUntil time.Time `bson:"lease_until"`
+	CreateTime time.Time `bson:"create_time"`
+	UpdateTime time.Time `bson:"update_time"`
+	FinishTime time.Time `bson:"finish_time"`
+}
+
+// BroadcastResult is the send result of one recipient of a broadcast job.
+type BroadcastResult struct {
+	JobID       string    `bson:"job_id"`
+	RecvID      string    `bson:"recv_id"`
+	ClientMsgID string    `bson:"client_msg_id"`
+	ServerMsgID string    `bson:"server_msg_id"`
+	SendTime    int64     `bson:"send_time"`
+	ErrCode     int32     `bson:"err_code"`
+	ErrMsg      string    `bson:"err_msg"`
+	CreateTime  time.Time `bson:"create_time"`
+}
+
+func (r *BroadcastResult) Failed() bool {
+	return r.ErrCode != 0
+}
@@ -43,6 +43,19 @@ type RevokeModel struct {
 	Time     int64  `bson:"time"`
 }
 
+// MsgEditModel is a prior version of an edited message.
+type MsgEditModel struct {
+	Content string `bson:"content"`
+	UserID  string `bson:"user_id"`
+	Time    int64  `bson:"time"`
+}
+
+// MsgReactionModel is an emoji reaction of a user, a user reacts with each emoji at most once.
+type MsgReactionModel struct {
+	Emoji  string `bson:"emoji"`
+	UserID string `bson:"user_id"`
+}
+
 type OfflinePushModel struct {
 	Title         string `bson:"title"`
 	Desc          string `bson:"desc"`
@@ -77,10 +90,12 @@ type MsgDataModel struct {
 }
 
 type MsgInfoModel struct {
-	Msg     *MsgDataModel `bson:"msg"`
-	Revoke  *RevokeModel  `bson:"revoke"`
-	DelList []string      `bson:"del_list"`
-	IsRead  bool          `bson:"is_read"`
+	Msg         *MsgDataModel       `bson:"msg"`
+	Revoke      *RevokeModel        `bson:"revoke"`
+	DelList     []string            `bson:"del_list"`
+	IsRead      bool                `bson:"is_read"`
+	EditHistory []*MsgEditModel     `bson:"edit_history,omitempty"`
+	Reactions   []*MsgReactionModel `bson:"reactions,omitempty"`
 }
 
 type UserCount struct {
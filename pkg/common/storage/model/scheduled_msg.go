@@ -0,0 +1,46 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+)
+
+const (
+	ScheduledMsgStatusPending  = 1
+	ScheduledMsgStatusSending  = 2
+	ScheduledMsgStatusSent     = 3
+	ScheduledMsgStatusFailed   = 4
+	ScheduledMsgStatusCanceled = 5
+)
+
+// ScheduledMsg is a message sent later by its sender. It is sent with the identity of SendID once ScheduleTime
+// is reached, so it is verified at delivery time like any other message.
+type ScheduledMsg struct {
+	ScheduleID string `bson:"schedule_id"`
+	SendID     string `bson:"send_id"`
+	// MsgData is the encoded sdkws.MsgData
+	MsgData      []byte    `bson:"msg_data"`
+	ScheduleTime time.Time `bson:"schedule_time"`
+	Status       int32     `bson:"status"`
+	// LeaseUntil is when a message left sending by a stopped dispatcher is dispatched again
+	LeaseUntil  time.Time `bson:"lease_until"`
+	ServerMsgID string    `bson:"server_msg_id"`
+	ErrCode     int32     `bson:"err_code"`
+	ErrMsg      string    `bson:"err_msg"`
+	CreateTime  time.Time `bson:"create_time"`
+	UpdateTime  time.Time `bson:"update_time"`
+	DeliverTime time.Time `bson:"deliver_time"`
+}
@@ -26,8 +26,25 @@ type User struct {
 	AppMangerLevel   int32     `bson:"app_manger_level"`
 	GlobalRecvMsgOpt int32     `bson:"global_recv_msg_opt"`
 	CreateTime       time.Time `bson:"create_time"`
-	Latitude         float64   `gorm:"column:latitude"`
-	Longitude        float64   `gorm:"column:longitude"`
+	Location         *GeoPoint `bson:"location,omitempty"`
+	LocationTime     time.Time `bson:"location_time"`
+	DiscoveryOptOut  bool      `bson:"discovery_opt_out"`
+}
+
+// GeoPoint is a GeoJSON point, the coordinates are [longitude, latitude].
+type GeoPoint struct {
+	Type        string    `bson:"type"`
+	Coordinates []float64 `bson:"coordinates"`
+}
+
+func NewGeoPoint(latitude, longitude float64) *GeoPoint {
+	return &GeoPoint{Type: "Point", Coordinates: []float64{longitude, latitude}}
+}
+
+// NearbyUser is a user returned by a geospatial query with its distance in meters.
+type NearbyUser struct {
+	User     `bson:",inline"`
+	Distance float64 `bson:"distance"`
 }
 
 func (u *User) GetNickname() string {
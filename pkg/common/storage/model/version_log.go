@@ -58,6 +58,18 @@ type VersionLog struct {
 	LogLen     int                `bson:"log_len"`
 }
 
+// VersionLogCompaction counts the docs and logs compacted in a version log collection.
+type VersionLogCompaction struct {
+	Collection string
+	Docs       int64
+	Logs       int64
+}
+
+// Compacted reports whether logs newer than the version have been removed by a compaction.
+func (v *VersionLog) Compacted(version uint64) bool {
+	return version < uint64(v.Deleted)
+}
+
 func (v *VersionLog) DeleteAndChangeIDs() (insertIds, deleteIds, updateIds []string) {
 	for _, l := range v.Logs {
 		switch l.State {
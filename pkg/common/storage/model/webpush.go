@@ -0,0 +1,24 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// WebPushSubscription is the PushSubscription of a browser, kept in redis per user and endpoint.
+type WebPushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	// P256dh is the base64url encoded public key of the browser
+	P256dh string `json:"p256dh"`
+	// Auth is the base64url encoded authentication secret
+	Auth string `json:"auth"`
+}
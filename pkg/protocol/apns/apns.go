@@ -0,0 +1,34 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apns
+
+import (
+	"errors"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+)
+
+func (x *ApnsUpdateTokenReq) Check() error {
+	if x.PlatformID > constant.AdminPlatformID || x.PlatformID < constant.IOSPlatformID {
+		return errors.New("platformID is invalidate")
+	}
+	if x.DeviceToken == "" && x.VoipToken == "" {
+		return errors.New("deviceToken and voipToken are empty")
+	}
+	if x.Account == "" {
+		return errors.New("account is empty")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: apns/apns.proto
+
+package apns
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApnsUpdateTokenReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlatformID int32  `protobuf:"varint,1,opt,name=platformID,proto3" json:"platformID"`
+	Account    string `protobuf:"bytes,2,opt,name=account,proto3" json:"account"`
+	// Token of the alert notifications, kept unchanged when empty
+	DeviceToken string `protobuf:"bytes,3,opt,name=deviceToken,proto3" json:"deviceToken"`
+	// PushKit token of the VoIP pushes sent for signaling, kept unchanged when empty
+	VoipToken  string `protobuf:"bytes,4,opt,name=voipToken,proto3" json:"voipToken"`
+	ExpireTime int64  `protobuf:"varint,5,opt,name=expireTime,proto3" json:"expireTime"`
+}
+
+func (x *ApnsUpdateTokenReq) Reset() {
+	*x = ApnsUpdateTokenReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apns_apns_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApnsUpdateTokenReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApnsUpdateTokenReq) ProtoMessage() {}
+
+func (x *ApnsUpdateTokenReq) ProtoReflect() protoreflect.Message {
+	mi := &file_apns_apns_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApnsUpdateTokenReq.ProtoReflect.Descriptor instead.
+func (*ApnsUpdateTokenReq) Descriptor() ([]byte, []int) {
+	return file_apns_apns_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApnsUpdateTokenReq) GetPlatformID() int32 {
+	if x != nil {
+		return x.PlatformID
+	}
+	return 0
+}
+
+func (x *ApnsUpdateTokenReq) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ApnsUpdateTokenReq) GetDeviceToken() string {
+	if x != nil {
+		return x.DeviceToken
+	}
+	return ""
+}
+
+func (x *ApnsUpdateTokenReq) GetVoipToken() string {
+	if x != nil {
+		return x.VoipToken
+	}
+	return ""
+}
+
+func (x *ApnsUpdateTokenReq) GetExpireTime() int64 {
+	if x != nil {
+		return x.ExpireTime
+	}
+	return 0
+}
+
+type ApnsUpdateTokenResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ApnsUpdateTokenResp) Reset() {
+	*x = ApnsUpdateTokenResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_apns_apns_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApnsUpdateTokenResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApnsUpdateTokenResp) ProtoMessage() {}
+
+func (x *ApnsUpdateTokenResp) ProtoReflect() protoreflect.Message {
+	mi := &file_apns_apns_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApnsUpdateTokenResp.ProtoReflect.Descriptor instead.
+func (*ApnsUpdateTokenResp) Descriptor() ([]byte, []int) {
+	return file_apns_apns_proto_rawDescGZIP(), []int{1}
+}
+
+var File_apns_apns_proto protoreflect.FileDescriptor
+
+var file_apns_apns_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x61, 0x70, 0x6e, 0x73, 0x2f, 0x61, 0x70, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0b, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x61, 0x70, 0x6e, 0x73, 0x22, 0xae,
+	0x01, 0x0a, 0x12, 0x61, 0x70, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x74, 0x66,
+	0x6f, 0x72, 0x6d, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x76, 0x6f, 0x69, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x6f, 0x69, 0x70, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x15, 0x0a, 0x13, 0x61, 0x70, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x32, 0x5c, 0x0a, 0x04, 0x61, 0x70, 0x6e, 0x73, 0x12, 0x54,
+	0x0a, 0x0f, 0x61, 0x70, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x61, 0x70, 0x6e, 0x73, 0x2e,
+	0x61, 0x70, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
+	0x65, 0x71, 0x1a, 0x20, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x61, 0x70, 0x6e, 0x73,
+	0x2e, 0x61, 0x70, 0x6e, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x4b, 0x79, 0x6c, 0x65, 0x59, 0x65, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x69,
+	0x6d, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x61, 0x70, 0x6e, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_apns_apns_proto_rawDescOnce sync.Once
+	file_apns_apns_proto_rawDescData = file_apns_apns_proto_rawDesc
+)
+
+func file_apns_apns_proto_rawDescGZIP() []byte {
+	file_apns_apns_proto_rawDescOnce.Do(func() {
+		file_apns_apns_proto_rawDescData = protoimpl.X.CompressGZIP(file_apns_apns_proto_rawDescData)
+	})
+	return file_apns_apns_proto_rawDescData
+}
+
+var file_apns_apns_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_apns_apns_proto_goTypes = []interface{}{
+	(*ApnsUpdateTokenReq)(nil),  // 0: openim.apns.apnsUpdateTokenReq
+	(*ApnsUpdateTokenResp)(nil), // 1: openim.apns.apnsUpdateTokenResp
+}
+var file_apns_apns_proto_depIdxs = []int32{
+	0, // 0: openim.apns.apns.apnsUpdateToken:input_type -> openim.apns.apnsUpdateTokenReq
+	1, // 1: openim.apns.apns.apnsUpdateToken:output_type -> openim.apns.apnsUpdateTokenResp
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_apns_apns_proto_init() }
+func file_apns_apns_proto_init() {
+	if File_apns_apns_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_apns_apns_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApnsUpdateTokenReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_apns_apns_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApnsUpdateTokenResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_apns_apns_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apns_apns_proto_goTypes,
+		DependencyIndexes: file_apns_apns_proto_depIdxs,
+		MessageInfos:      file_apns_apns_proto_msgTypes,
+	}.Build()
+	File_apns_apns_proto = out.File
+	file_apns_apns_proto_rawDesc = nil
+	file_apns_apns_proto_goTypes = nil
+	file_apns_apns_proto_depIdxs = nil
+}
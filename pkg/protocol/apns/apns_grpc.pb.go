@@ -0,0 +1,123 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: apns/apns.proto
+
+package apns
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Apns_ApnsUpdateToken_FullMethodName = "/openim.apns.apns/apnsUpdateToken"
+)
+
+// ApnsClient is the client API for Apns service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ApnsClient interface {
+	// Register the APNs tokens of a device for the native APNs offline pusher
+	ApnsUpdateToken(ctx context.Context, in *ApnsUpdateTokenReq, opts ...grpc.CallOption) (*ApnsUpdateTokenResp, error)
+}
+
+type apnsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewApnsClient(cc grpc.ClientConnInterface) ApnsClient {
+	return &apnsClient{cc}
+}
+
+func (c *apnsClient) ApnsUpdateToken(ctx context.Context, in *ApnsUpdateTokenReq, opts ...grpc.CallOption) (*ApnsUpdateTokenResp, error) {
+	out := new(ApnsUpdateTokenResp)
+	err := c.cc.Invoke(ctx, Apns_ApnsUpdateToken_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ApnsServer is the server API for Apns service.
+// All implementations should embed UnimplementedApnsServer
+// for forward compatibility
+type ApnsServer interface {
+	// Register the APNs tokens of a device for the native APNs offline pusher
+	ApnsUpdateToken(context.Context, *ApnsUpdateTokenReq) (*ApnsUpdateTokenResp, error)
+}
+
+// UnimplementedApnsServer should be embedded to have forward compatible implementations.
+type UnimplementedApnsServer struct {
+}
+
+func (UnimplementedApnsServer) ApnsUpdateToken(context.Context, *ApnsUpdateTokenReq) (*ApnsUpdateTokenResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApnsUpdateToken not implemented")
+}
+
+// UnsafeApnsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ApnsServer will
+// result in compilation errors.
+type UnsafeApnsServer interface {
+	mustEmbedUnimplementedApnsServer()
+}
+
+func RegisterApnsServer(s grpc.ServiceRegistrar, srv ApnsServer) {
+	s.RegisterService(&Apns_ServiceDesc, srv)
+}
+
+func _Apns_ApnsUpdateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApnsUpdateTokenReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApnsServer).ApnsUpdateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Apns_ApnsUpdateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApnsServer).ApnsUpdateToken(ctx, req.(*ApnsUpdateTokenReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Apns_ServiceDesc is the grpc.ServiceDesc for Apns service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Apns_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.apns.apns",
+	HandlerType: (*ApnsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "apnsUpdateToken",
+			Handler:    _Apns_ApnsUpdateToken_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apns/apns.proto",
+}
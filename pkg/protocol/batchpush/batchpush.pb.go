@@ -0,0 +1,389 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: batchpush/batchpush.proto
+
+package batchpush
+
+import (
+	msggateway "github.com/KyleYe/open-im-protocol/msggateway"
+	sdkws "github.com/KyleYe/open-im-protocol/sdkws"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// One message and the users it is pushed to
+type PushMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MsgData       *sdkws.MsgData `protobuf:"bytes,1,opt,name=msgData,proto3" json:"msgData"`
+	PushToUserIDs []string       `protobuf:"bytes,2,rep,name=pushToUserIDs,proto3" json:"pushToUserIDs"`
+}
+
+func (x *PushMsg) Reset() {
+	*x = PushMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batchpush_batchpush_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsg) ProtoMessage() {}
+
+func (x *PushMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_batchpush_batchpush_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsg.ProtoReflect.Descriptor instead.
+func (*PushMsg) Descriptor() ([]byte, []int) {
+	return file_batchpush_batchpush_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PushMsg) GetMsgData() *sdkws.MsgData {
+	if x != nil {
+		return x.MsgData
+	}
+	return nil
+}
+
+func (x *PushMsg) GetPushToUserIDs() []string {
+	if x != nil {
+		return x.PushToUserIDs
+	}
+	return nil
+}
+
+type BatchPushMsgsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Msgs []*PushMsg `protobuf:"bytes,1,rep,name=msgs,proto3" json:"msgs"`
+}
+
+func (x *BatchPushMsgsReq) Reset() {
+	*x = BatchPushMsgsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batchpush_batchpush_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPushMsgsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPushMsgsReq) ProtoMessage() {}
+
+func (x *BatchPushMsgsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_batchpush_batchpush_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPushMsgsReq.ProtoReflect.Descriptor instead.
+func (*BatchPushMsgsReq) Descriptor() ([]byte, []int) {
+	return file_batchpush_batchpush_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BatchPushMsgsReq) GetMsgs() []*PushMsg {
+	if x != nil {
+		return x.Msgs
+	}
+	return nil
+}
+
+type PushMsgResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SinglePushResult []*msggateway.SingleMsgToUserResults `protobuf:"bytes,1,rep,name=singlePushResult,proto3" json:"singlePushResult"`
+}
+
+func (x *PushMsgResult) Reset() {
+	*x = PushMsgResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batchpush_batchpush_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushMsgResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushMsgResult) ProtoMessage() {}
+
+func (x *PushMsgResult) ProtoReflect() protoreflect.Message {
+	mi := &file_batchpush_batchpush_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushMsgResult.ProtoReflect.Descriptor instead.
+func (*PushMsgResult) Descriptor() ([]byte, []int) {
+	return file_batchpush_batchpush_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PushMsgResult) GetSinglePushResult() []*msggateway.SingleMsgToUserResults {
+	if x != nil {
+		return x.SinglePushResult
+	}
+	return nil
+}
+
+type BatchPushMsgsResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// In the order of the msgs of the request
+	Results []*PushMsgResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results"`
+}
+
+func (x *BatchPushMsgsResp) Reset() {
+	*x = BatchPushMsgsResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batchpush_batchpush_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchPushMsgsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchPushMsgsResp) ProtoMessage() {}
+
+func (x *BatchPushMsgsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_batchpush_batchpush_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchPushMsgsResp.ProtoReflect.Descriptor instead.
+func (*BatchPushMsgsResp) Descriptor() ([]byte, []int) {
+	return file_batchpush_batchpush_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchPushMsgsResp) GetResults() []*PushMsgResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+var File_batchpush_batchpush_proto protoreflect.FileDescriptor
+
+var file_batchpush_batchpush_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x6f, 0x70, 0x65,
+	0x6e, 0x69, 0x6d, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x75, 0x73, 0x68, 0x1a, 0x11, 0x73,
+	0x64, 0x6b, 0x77, 0x73, 0x2f, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x1b, 0x6d, 0x73, 0x67, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x73, 0x67,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x60, 0x0a,
+	0x07, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x12, 0x2f, 0x0a, 0x07, 0x6d, 0x73, 0x67, 0x44,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x69, 0x6d, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x4d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61,
+	0x52, 0x07, 0x6d, 0x73, 0x67, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x75, 0x73,
+	0x68, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0d, 0x70, 0x75, 0x73, 0x68, 0x54, 0x6f, 0x55, 0x73, 0x65, 0x72, 0x49, 0x44, 0x73, 0x22,
+	0x41, 0x0a, 0x10, 0x62, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x73, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x70, 0x75, 0x73, 0x68, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6d, 0x73,
+	0x67, 0x73, 0x22, 0x66, 0x0a, 0x0d, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x55, 0x0a, 0x10, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x50, 0x75, 0x73,
+	0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x6d, 0x73, 0x67, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4d, 0x73, 0x67, 0x54, 0x6f, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x10, 0x73, 0x69, 0x6e, 0x67, 0x6c, 0x65,
+	0x50, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x4e, 0x0a, 0x11, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x39, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70,
+	0x75, 0x73, 0x68, 0x2e, 0x70, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x32, 0x65, 0x0a, 0x09, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x12, 0x58, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x12, 0x22, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69,
+	0x6d, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x62, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x6f,
+	0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x75, 0x73, 0x68, 0x2e,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x50, 0x75, 0x73, 0x68, 0x4d, 0x73, 0x67, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x4b, 0x79, 0x6c, 0x65, 0x59, 0x65, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x69, 0x6d, 0x2d, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x70, 0x75, 0x73, 0x68, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_batchpush_batchpush_proto_rawDescOnce sync.Once
+	file_batchpush_batchpush_proto_rawDescData = file_batchpush_batchpush_proto_rawDesc
+)
+
+func file_batchpush_batchpush_proto_rawDescGZIP() []byte {
+	file_batchpush_batchpush_proto_rawDescOnce.Do(func() {
+		file_batchpush_batchpush_proto_rawDescData = protoimpl.X.CompressGZIP(file_batchpush_batchpush_proto_rawDescData)
+	})
+	return file_batchpush_batchpush_proto_rawDescData
+}
+
+var file_batchpush_batchpush_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_batchpush_batchpush_proto_goTypes = []interface{}{
+	(*PushMsg)(nil),                           // 0: openim.batchpush.pushMsg
+	(*BatchPushMsgsReq)(nil),                  // 1: openim.batchpush.batchPushMsgsReq
+	(*PushMsgResult)(nil),                     // 2: openim.batchpush.pushMsgResult
+	(*BatchPushMsgsResp)(nil),                 // 3: openim.batchpush.batchPushMsgsResp
+	(*sdkws.MsgData)(nil),                     // 4: openim.sdkws.MsgData
+	(*msggateway.SingleMsgToUserResults)(nil), // 5: openim.msggateway.SingleMsgToUserResults
+}
+var file_batchpush_batchpush_proto_depIdxs = []int32{
+	4, // 0: openim.batchpush.pushMsg.msgData:type_name -> openim.sdkws.MsgData
+	0, // 1: openim.batchpush.batchPushMsgsReq.msgs:type_name -> openim.batchpush.pushMsg
+	5, // 2: openim.batchpush.pushMsgResult.singlePushResult:type_name -> openim.msggateway.SingleMsgToUserResults
+	2, // 3: openim.batchpush.batchPushMsgsResp.results:type_name -> openim.batchpush.pushMsgResult
+	1, // 4: openim.batchpush.batchPush.batchPushMsgs:input_type -> openim.batchpush.batchPushMsgsReq
+	3, // 5: openim.batchpush.batchPush.batchPushMsgs:output_type -> openim.batchpush.batchPushMsgsResp
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_batchpush_batchpush_proto_init() }
+func file_batchpush_batchpush_proto_init() {
+	if File_batchpush_batchpush_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_batchpush_batchpush_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batchpush_batchpush_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPushMsgsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batchpush_batchpush_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PushMsgResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batchpush_batchpush_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BatchPushMsgsResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_batchpush_batchpush_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_batchpush_batchpush_proto_goTypes,
+		DependencyIndexes: file_batchpush_batchpush_proto_depIdxs,
+		MessageInfos:      file_batchpush_batchpush_proto_msgTypes,
+	}.Build()
+	File_batchpush_batchpush_proto = out.File
+	file_batchpush_batchpush_proto_rawDesc = nil
+	file_batchpush_batchpush_proto_goTypes = nil
+	file_batchpush_batchpush_proto_depIdxs = nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: batchpush/batchpush.proto
+
+package batchpush
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	BatchPush_BatchPushMsgs_FullMethodName = "/openim.batchpush.batchPush/batchPushMsgs"
+)
+
+// BatchPushClient is the client API for BatchPush service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BatchPushClient interface {
+	// Pushes many messages to many users in one call. Every connection gets the messages of one conversation
+	// in a single frame
+	BatchPushMsgs(ctx context.Context, in *BatchPushMsgsReq, opts ...grpc.CallOption) (*BatchPushMsgsResp, error)
+}
+
+type batchPushClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBatchPushClient(cc grpc.ClientConnInterface) BatchPushClient {
+	return &batchPushClient{cc}
+}
+
+func (c *batchPushClient) BatchPushMsgs(ctx context.Context, in *BatchPushMsgsReq, opts ...grpc.CallOption) (*BatchPushMsgsResp, error) {
+	out := new(BatchPushMsgsResp)
+	err := c.cc.Invoke(ctx, BatchPush_BatchPushMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BatchPushServer is the server API for BatchPush service.
+// All implementations should embed UnimplementedBatchPushServer
+// for forward compatibility
+type BatchPushServer interface {
+	// Pushes many messages to many users in one call. Every connection gets the messages of one conversation
+	// in a single frame
+	BatchPushMsgs(context.Context, *BatchPushMsgsReq) (*BatchPushMsgsResp, error)
+}
+
+// UnimplementedBatchPushServer should be embedded to have forward compatible implementations.
+type UnimplementedBatchPushServer struct {
+}
+
+func (UnimplementedBatchPushServer) BatchPushMsgs(context.Context, *BatchPushMsgsReq) (*BatchPushMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchPushMsgs not implemented")
+}
+
+// UnsafeBatchPushServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BatchPushServer will
+// result in compilation errors.
+type UnsafeBatchPushServer interface {
+	mustEmbedUnimplementedBatchPushServer()
+}
+
+func RegisterBatchPushServer(s grpc.ServiceRegistrar, srv BatchPushServer) {
+	s.RegisterService(&BatchPush_ServiceDesc, srv)
+}
+
+func _BatchPush_BatchPushMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchPushMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BatchPushServer).BatchPushMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BatchPush_BatchPushMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BatchPushServer).BatchPushMsgs(ctx, req.(*BatchPushMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BatchPush_ServiceDesc is the grpc.ServiceDesc for BatchPush service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BatchPush_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.batchpush.batchPush",
+	HandlerType: (*BatchPushServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "batchPushMsgs",
+			Handler:    _BatchPush_BatchPushMsgs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "batchpush/batchpush.proto",
+}
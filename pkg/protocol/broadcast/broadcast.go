@@ -0,0 +1,106 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package broadcast
+
+import "errors"
+
+const (
+	TargetUserIDs    = 1
+	TargetAll        = 2
+	TargetCreateTime = 3
+)
+
+// MaxRecipientsLimit bounds one page of GetBroadcastRecipients.
+const MaxRecipientsLimit = 1000
+
+func (x *BroadcastTarget) Check() error {
+	switch x.Type {
+	case TargetUserIDs:
+		if len(x.UserIDs) == 0 {
+			return errors.New("userIDs is empty")
+		}
+	case TargetAll:
+	case TargetCreateTime:
+		if x.CreateTimeBegin < 0 || x.CreateTimeEnd < 0 {
+			return errors.New("create time is invalid")
+		}
+		if x.CreateTimeBegin == 0 && x.CreateTimeEnd == 0 {
+			return errors.New("create time range is empty")
+		}
+		if x.CreateTimeEnd != 0 && x.CreateTimeBegin >= x.CreateTimeEnd {
+			return errors.New("createTimeBegin must be before createTimeEnd")
+		}
+	default:
+		return errors.New("target type is invalid")
+	}
+	return nil
+}
+
+func (x *CreateBroadcastReq) Check() error {
+	if x.MsgData == nil {
+		return errors.New("msgData is empty")
+	}
+	if x.Target == nil {
+		return errors.New("target is empty")
+	}
+	if x.Rate < 0 {
+		return errors.New("rate is invalid")
+	}
+	return x.Target.Check()
+}
+
+func (x *GetBroadcastReq) Check() error {
+	if x.JobID == "" {
+		return errors.New("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetBroadcastsReq) Check() error {
+	if x.Status < 0 || x.Status > 4 {
+		return errors.New("status is invalid")
+	}
+	if x.Pagination == nil {
+		return errors.New("pagination is empty")
+	}
+	return nil
+}
+
+func (x *GetBroadcastResultsReq) Check() error {
+	if x.JobID == "" {
+		return errors.New("jobID is empty")
+	}
+	if x.Pagination == nil {
+		return errors.New("pagination is empty")
+	}
+	return nil
+}
+
+func (x *CancelBroadcastReq) Check() error {
+	if x.JobID == "" {
+		return errors.New("jobID is empty")
+	}
+	return nil
+}
+
+func (x *GetBroadcastRecipientsReq) Check() error {
+	if x.Limit <= 0 {
+		return errors.New("limit is invalid")
+	}
+	if x.CreateTimeBegin < 0 || x.CreateTimeEnd < 0 {
+		return errors.New("create time is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: broadcast/broadcast.proto
+
+package broadcast
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Broadcast_CreateBroadcast_FullMethodName     = "/openim.broadcast.broadcast/createBroadcast"
+	Broadcast_GetBroadcast_FullMethodName        = "/openim.broadcast.broadcast/getBroadcast"
+	Broadcast_GetBroadcasts_FullMethodName       = "/openim.broadcast.broadcast/getBroadcasts"
+	Broadcast_GetBroadcastResults_FullMethodName = "/openim.broadcast.broadcast/getBroadcastResults"
+	Broadcast_CancelBroadcast_FullMethodName     = "/openim.broadcast.broadcast/cancelBroadcast"
+)
+
+// BroadcastClient is the client API for Broadcast service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BroadcastClient interface {
+	// Create a job sending one message to every recipient of the target in the background
+	CreateBroadcast(ctx context.Context, in *CreateBroadcastReq, opts ...grpc.CallOption) (*CreateBroadcastResp, error)
+	GetBroadcast(ctx context.Context, in *GetBroadcastReq, opts ...grpc.CallOption) (*GetBroadcastResp, error)
+	GetBroadcasts(ctx context.Context, in *GetBroadcastsReq, opts ...grpc.CallOption) (*GetBroadcastsResp, error)
+	GetBroadcastResults(ctx context.Context, in *GetBroadcastResultsReq, opts ...grpc.CallOption) (*GetBroadcastResultsResp, error)
+	// Stop a pending or running job, the messages already sent are kept
+	CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastResp, error)
+}
+
+type broadcastClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBroadcastClient(cc grpc.ClientConnInterface) BroadcastClient {
+	return &broadcastClient{cc}
+}
+
+func (c *broadcastClient) CreateBroadcast(ctx context.Context, in *CreateBroadcastReq, opts ...grpc.CallOption) (*CreateBroadcastResp, error) {
+	out := new(CreateBroadcastResp)
+	err := c.cc.Invoke(ctx, Broadcast_CreateBroadcast_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *broadcastClient) GetBroadcast(ctx context.Context, in *GetBroadcastReq, opts ...grpc.CallOption) (*GetBroadcastResp, error) {
+	out := new(GetBroadcastResp)
+	err := c.cc.Invoke(ctx, Broadcast_GetBroadcast_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *broadcastClient) GetBroadcasts(ctx context.Context, in *GetBroadcastsReq, opts ...grpc.CallOption) (*GetBroadcastsResp, error) {
+	out := new(GetBroadcastsResp)
+	err := c.cc.Invoke(ctx, Broadcast_GetBroadcasts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *broadcastClient) GetBroadcastResults(ctx context.Context, in *GetBroadcastResultsReq, opts ...grpc.CallOption) (*GetBroadcastResultsResp, error) {
+	out := new(GetBroadcastResultsResp)
+	err := c.cc.Invoke(ctx, Broadcast_GetBroadcastResults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *broadcastClient) CancelBroadcast(ctx context.Context, in *CancelBroadcastReq, opts ...grpc.CallOption) (*CancelBroadcastResp, error) {
+	out := new(CancelBroadcastResp)
+	err := c.cc.Invoke(ctx, Broadcast_CancelBroadcast_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BroadcastServer is the server API for Broadcast service.
+// All implementations should embed UnimplementedBroadcastServer
+// for forward compatibility
+type BroadcastServer interface {
+	// Create a job sending one message to every recipient of the target in the background
+	CreateBroadcast(context.Context, *CreateBroadcastReq) (*CreateBroadcastResp, error)
+	GetBroadcast(context.Context, *GetBroadcastReq) (*GetBroadcastResp, error)
+	GetBroadcasts(context.Context, *GetBroadcastsReq) (*GetBroadcastsResp, error)
+	GetBroadcastResults(context.Context, *GetBroadcastResultsReq) (*GetBroadcastResultsResp, error)
+	// Stop a pending or running job, the messages already sent are kept
+	CancelBroadcast(context.Context, *CancelBroadcastReq) (*CancelBroadcastResp, error)
+}
+
+// UnimplementedBroadcastServer should be embedded to have forward compatible implementations.
+type UnimplementedBroadcastServer struct {
+}
+
+func (UnimplementedBroadcastServer) CreateBroadcast(context.Context, *CreateBroadcastReq) (*CreateBroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBroadcast not implemented")
+}
+func (UnimplementedBroadcastServer) GetBroadcast(context.Context, *GetBroadcastReq) (*GetBroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcast not implemented")
+}
+func (UnimplementedBroadcastServer) GetBroadcasts(context.Context, *GetBroadcastsReq) (*GetBroadcastsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcasts not implemented")
+}
+func (UnimplementedBroadcastServer) GetBroadcastResults(context.Context, *GetBroadcastResultsReq) (*GetBroadcastResultsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcastResults not implemented")
+}
+func (UnimplementedBroadcastServer) CancelBroadcast(context.Context, *CancelBroadcastReq) (*CancelBroadcastResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBroadcast not implemented")
+}
+
+// UnsafeBroadcastServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BroadcastServer will
+// result in compilation errors.
+type UnsafeBroadcastServer interface {
+	mustEmbedUnimplementedBroadcastServer()
+}
+
+func RegisterBroadcastServer(s grpc.ServiceRegistrar, srv BroadcastServer) {
+	s.RegisterService(&Broadcast_ServiceDesc, srv)
+}
+
+func _Broadcast_CreateBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastServer).CreateBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcast_CreateBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastServer).CreateBroadcast(ctx, req.(*CreateBroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broadcast_GetBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastServer).GetBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcast_GetBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastServer).GetBroadcast(ctx, req.(*GetBroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broadcast_GetBroadcasts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastServer).GetBroadcasts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcast_GetBroadcasts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastServer).GetBroadcasts(ctx, req.(*GetBroadcastsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broadcast_GetBroadcastResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastResultsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastServer).GetBroadcastResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcast_GetBroadcastResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastServer).GetBroadcastResults(ctx, req.(*GetBroadcastResultsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Broadcast_CancelBroadcast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBroadcastReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastServer).CancelBroadcast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Broadcast_CancelBroadcast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastServer).CancelBroadcast(ctx, req.(*CancelBroadcastReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Broadcast_ServiceDesc is the grpc.ServiceDesc for Broadcast service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Broadcast_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.broadcast.broadcast",
+	HandlerType: (*BroadcastServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "createBroadcast",
+			Handler:    _Broadcast_CreateBroadcast_Handler,
+		},
+		{
+			MethodName: "getBroadcast",
+			Handler:    _Broadcast_GetBroadcast_Handler,
+		},
+		{
+			MethodName: "getBroadcasts",
+			Handler:    _Broadcast_GetBroadcasts_Handler,
+		},
+		{
+			MethodName: "getBroadcastResults",
+			Handler:    _Broadcast_GetBroadcastResults_Handler,
+		},
+		{
+			MethodName: "cancelBroadcast",
+			Handler:    _Broadcast_CancelBroadcast_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "broadcast/broadcast.proto",
+}
+
+const (
+	BroadcastRecipient_GetBroadcastRecipients_FullMethodName = "/openim.broadcast.broadcastRecipient/getBroadcastRecipients"
+)
+
+// BroadcastRecipientClient is the client API for BroadcastRecipient service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BroadcastRecipientClient interface {
+	// Page through the userIDs of the users a broadcast is sent to, served by the user service
+	GetBroadcastRecipients(ctx context.Context, in *GetBroadcastRecipientsReq, opts ...grpc.CallOption) (*GetBroadcastRecipientsResp, error)
+}
+
+type broadcastRecipientClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBroadcastRecipientClient(cc grpc.ClientConnInterface) BroadcastRecipientClient {
+	return &broadcastRecipientClient{cc}
+}
+
+func (c *broadcastRecipientClient) GetBroadcastRecipients(ctx context.Context, in *GetBroadcastRecipientsReq, opts ...grpc.CallOption) (*GetBroadcastRecipientsResp, error) {
+	out := new(GetBroadcastRecipientsResp)
+	err := c.cc.Invoke(ctx, BroadcastRecipient_GetBroadcastRecipients_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BroadcastRecipientServer is the server API for BroadcastRecipient service.
+// All implementations should embed UnimplementedBroadcastRecipientServer
+// for forward compatibility
+type BroadcastRecipientServer interface {
+	// Page through the userIDs of the users a broadcast is sent to, served by the user service
+	GetBroadcastRecipients(context.Context, *GetBroadcastRecipientsReq) (*GetBroadcastRecipientsResp, error)
+}
+
+// UnimplementedBroadcastRecipientServer should be embedded to have forward compatible implementations.
+type UnimplementedBroadcastRecipientServer struct {
+}
+
+func (UnimplementedBroadcastRecipientServer) GetBroadcastRecipients(context.Context, *GetBroadcastRecipientsReq) (*GetBroadcastRecipientsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBroadcastRecipients not implemented")
+}
+
+// UnsafeBroadcastRecipientServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BroadcastRecipientServer will
+// result in compilation errors.
+type UnsafeBroadcastRecipientServer interface {
+	mustEmbedUnimplementedBroadcastRecipientServer()
+}
+
+func RegisterBroadcastRecipientServer(s grpc.ServiceRegistrar, srv BroadcastRecipientServer) {
+	s.RegisterService(&BroadcastRecipient_ServiceDesc, srv)
+}
+
+func _BroadcastRecipient_GetBroadcastRecipients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBroadcastRecipientsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BroadcastRecipientServer).GetBroadcastRecipients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BroadcastRecipient_GetBroadcastRecipients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BroadcastRecipientServer).GetBroadcastRecipients(ctx, req.(*GetBroadcastRecipientsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BroadcastRecipient_ServiceDesc is the grpc.ServiceDesc for BroadcastRecipient service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BroadcastRecipient_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.broadcast.broadcastRecipient",
+	HandlerType: (*BroadcastRecipientServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "getBroadcastRecipients",
+			Handler:    _BroadcastRecipient_GetBroadcastRecipients_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "broadcast/broadcast.proto",
+}
@@ -0,0 +1,50 @@
+#!/usr/bin/env bash
+# Copyright © 2024 OpenIM. All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Generates the server-side protocol extensions. Messages shared with the
+# clients live in github.com/KyleYe/open-im-protocol and are imported from
+# the module cache.
+
+set -e
+
+cd "$(dirname "$0")"
+
+PROTOCOL_DIR=$(go list -m -f '{{.Dir}}' github.com/KyleYe/open-im-protocol)
+
+PROTO_NAMES=(
+    "location"
+    "session"
+    "msgedit"
+    "reaction"
+    "batchpush"
+    "apns"
+    "webpush"
+    "versionlog"
+    "broadcast"
+    "scheduledmsg"
+)
+
+for name in "${PROTO_NAMES[@]}"; do
+  protoc -I . -I "${PROTOCOL_DIR}" \
+    --go_out=. --go_opt=module=github.com/KyleYe/open-im-server/v3/pkg/protocol \
+    --go-grpc_out=. --go-grpc_opt=module=github.com/KyleYe/open-im-server/v3/pkg/protocol,require_unimplemented_servers=false \
+    ${name}/${name}.proto
+done
+
+if [ "$(uname -s)" == "Darwin" ]; then
+    find . -type f -name '*.pb.go' -exec sed -i '' 's/,omitempty"`/\"\`/g' {} +
+else
+    find . -type f -name '*.pb.go' -exec sed -i 's/,omitempty"`/\"\`/g' {} +
+fi
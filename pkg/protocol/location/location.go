@@ -0,0 +1,60 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package location
+
+import "errors"
+
+func checkCoordinate(latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 {
+		return errors.New("latitude is invalid")
+	}
+	if longitude < -180 || longitude > 180 {
+		return errors.New("longitude is invalid")
+	}
+	return nil
+}
+
+func (x *UpdateLocationReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	return checkCoordinate(x.Latitude, x.Longitude)
+}
+
+func (x *ClearLocationReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	return nil
+}
+
+func (x *GetNearbyUsersReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	if x.Pagination == nil {
+		return errors.New("pagination is empty")
+	}
+	if x.Pagination.PageNumber < 1 {
+		return errors.New("pageNumber is invalid")
+	}
+	if x.Radius < 0 {
+		return errors.New("radius is invalid")
+	}
+	if _, ok := NearbyScope_name[int32(x.Scope)]; !ok {
+		return errors.New("scope is invalid")
+	}
+	return checkCoordinate(x.Latitude, x.Longitude)
+}
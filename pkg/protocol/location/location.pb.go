@@ -0,0 +1,720 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: location/location.proto
+
+package location
+
+import (
+	sdkws "github.com/KyleYe/open-im-protocol/sdkws"
+	wrapperspb "github.com/KyleYe/open-im-protocol/wrapperspb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NearbyScope int32
+
+const (
+	// Only the caller's friends
+	NearbyScope_Friends NearbyScope = 0
+	// Friends and friends of friends
+	NearbyScope_FriendsOfFriends NearbyScope = 1
+	// Every discoverable user
+	NearbyScope_All NearbyScope = 2
+)
+
+// Enum value maps for NearbyScope.
+var (
+	NearbyScope_name = map[int32]string{
+		0: "Friends",
+		1: "FriendsOfFriends",
+		2: "All",
+	}
+	NearbyScope_value = map[string]int32{
+		"Friends":          0,
+		"FriendsOfFriends": 1,
+		"All":              2,
+	}
+)
+
+func (x NearbyScope) Enum() *NearbyScope {
+	p := new(NearbyScope)
+	*p = x
+	return p
+}
+
+func (x NearbyScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NearbyScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_location_location_proto_enumTypes[0].Descriptor()
+}
+
+func (NearbyScope) Type() protoreflect.EnumType {
+	return &file_location_location_proto_enumTypes[0]
+}
+
+func (x NearbyScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NearbyScope.Descriptor instead.
+func (NearbyScope) EnumDescriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{0}
+}
+
+type UpdateLocationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID    string  `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID"`
+	Latitude  float64 `protobuf:"fixed64,2,opt,name=latitude,proto3" json:"latitude"`
+	Longitude float64 `protobuf:"fixed64,3,opt,name=longitude,proto3" json:"longitude"`
+	// Whether the user can be found by nearby searches, unchanged when absent
+	Discoverable *wrapperspb.BoolValue `protobuf:"bytes,4,opt,name=discoverable,proto3" json:"discoverable"`
+}
+
+func (x *UpdateLocationReq) Reset() {
+	*x = UpdateLocationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLocationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLocationReq) ProtoMessage() {}
+
+func (x *UpdateLocationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLocationReq.ProtoReflect.Descriptor instead.
+func (*UpdateLocationReq) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateLocationReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UpdateLocationReq) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *UpdateLocationReq) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *UpdateLocationReq) GetDiscoverable() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Discoverable
+	}
+	return nil
+}
+
+type UpdateLocationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateLocationResp) Reset() {
+	*x = UpdateLocationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateLocationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLocationResp) ProtoMessage() {}
+
+func (x *UpdateLocationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLocationResp.ProtoReflect.Descriptor instead.
+func (*UpdateLocationResp) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{1}
+}
+
+type ClearLocationReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID"`
+}
+
+func (x *ClearLocationReq) Reset() {
+	*x = ClearLocationReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearLocationReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearLocationReq) ProtoMessage() {}
+
+func (x *ClearLocationReq) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearLocationReq.ProtoReflect.Descriptor instead.
+func (*ClearLocationReq) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClearLocationReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type ClearLocationResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClearLocationResp) Reset() {
+	*x = ClearLocationResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearLocationResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearLocationResp) ProtoMessage() {}
+
+func (x *ClearLocationResp) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearLocationResp.ProtoReflect.Descriptor instead.
+func (*ClearLocationResp) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{3}
+}
+
+type GetNearbyUsersReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pagination *sdkws.RequestPagination `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination"`
+	UserID     string                   `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID"`
+	Latitude   float64                  `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude"`
+	Longitude  float64                  `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude"`
+	// Search radius in meters, the server default is used when zero
+	Radius float64     `protobuf:"fixed64,5,opt,name=radius,proto3" json:"radius"`
+	Scope  NearbyScope `protobuf:"varint,6,opt,name=scope,proto3,enum=openim.location.NearbyScope" json:"scope"`
+}
+
+func (x *GetNearbyUsersReq) Reset() {
+	*x = GetNearbyUsersReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNearbyUsersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyUsersReq) ProtoMessage() {}
+
+func (x *GetNearbyUsersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyUsersReq.ProtoReflect.Descriptor instead.
+func (*GetNearbyUsersReq) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetNearbyUsersReq) GetPagination() *sdkws.RequestPagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *GetNearbyUsersReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *GetNearbyUsersReq) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *GetNearbyUsersReq) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *GetNearbyUsersReq) GetRadius() float64 {
+	if x != nil {
+		return x.Radius
+	}
+	return 0
+}
+
+func (x *GetNearbyUsersReq) GetScope() NearbyScope {
+	if x != nil {
+		return x.Scope
+	}
+	return NearbyScope_Friends
+}
+
+type NearbyUser struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	User *sdkws.UserInfo `protobuf:"bytes,1,opt,name=user,proto3" json:"user"`
+	// Distance from the search point in meters
+	Distance float64 `protobuf:"fixed64,2,opt,name=distance,proto3" json:"distance"`
+}
+
+func (x *NearbyUser) Reset() {
+	*x = NearbyUser{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NearbyUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NearbyUser) ProtoMessage() {}
+
+func (x *NearbyUser) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NearbyUser.ProtoReflect.Descriptor instead.
+func (*NearbyUser) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NearbyUser) GetUser() *sdkws.UserInfo {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *NearbyUser) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type GetNearbyUsersResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users []*NearbyUser `protobuf:"bytes,1,rep,name=users,proto3" json:"users"`
+	Total int32         `protobuf:"varint,2,opt,name=total,proto3" json:"total"`
+}
+
+func (x *GetNearbyUsersResp) Reset() {
+	*x = GetNearbyUsersResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_location_location_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNearbyUsersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNearbyUsersResp) ProtoMessage() {}
+
+func (x *GetNearbyUsersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_location_location_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNearbyUsersResp.ProtoReflect.Descriptor instead.
+func (*GetNearbyUsersResp) Descriptor() ([]byte, []int) {
+	return file_location_location_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetNearbyUsersResp) GetUsers() []*NearbyUser {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetNearbyUsersResp) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_location_location_proto protoreflect.FileDescriptor
+
+var file_location_location_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6f, 0x70, 0x65, 0x6e, 0x69,
+	0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x11, 0x73, 0x64, 0x6b, 0x77,
+	0x73, 0x2f, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x77,
+	0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x70, 0x62, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65,
+	0x72, 0x73, 0x70, 0x62, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69,
+	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x62,
+	0x6c, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x2a, 0x0a, 0x10, 0x63, 0x6c, 0x65, 0x61,
+	0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x44, 0x22, 0x13, 0x0a, 0x11, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0xf2, 0x01, 0x0a, 0x11, 0x67, 0x65,
+	0x74, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x3f, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x73, 0x64, 0x6b,
+	0x77, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75,
+	0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x72, 0x61, 0x64, 0x69, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4e, 0x65, 0x61, 0x72,
+	0x62, 0x79, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x22, 0x54,
+	0x0a, 0x0a, 0x6e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x55, 0x73, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x69, 0x6d, 0x2e, 0x73, 0x64, 0x6b, 0x77, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x22, 0x5d, 0x0a, 0x12, 0x67, 0x65, 0x74, 0x4e, 0x65, 0x61, 0x72, 0x62,
+	0x79, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x31, 0x0a, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x6e, 0x65, 0x61, 0x72,
+	0x62, 0x79, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x2a, 0x39, 0x0a, 0x0b, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x53, 0x63, 0x6f,
+	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x10, 0x00, 0x12,
+	0x14, 0x0a, 0x10, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x66, 0x46, 0x72, 0x69, 0x65,
+	0x6e, 0x64, 0x73, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x6c, 0x6c, 0x10, 0x02, 0x32, 0x98,
+	0x02, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x59, 0x0a, 0x0e, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x1a, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x56, 0x0a, 0x0d, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d,
+	0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x63, 0x6c, 0x65, 0x61, 0x72, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x22, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x63, 0x6c, 0x65,
+	0x61, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x59,
+	0x0a, 0x0e, 0x67, 0x65, 0x74, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x12, 0x22, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x67, 0x65, 0x74, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x67, 0x65, 0x74, 0x4e, 0x65, 0x61, 0x72, 0x62, 0x79,
+	0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x42, 0x3b, 0x5a, 0x39, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4b, 0x79, 0x6c, 0x65, 0x59, 0x65, 0x2f, 0x6f,
+	0x70, 0x65, 0x6e, 0x2d, 0x69, 0x6d, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x76, 0x33,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2f, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_location_location_proto_rawDescOnce sync.Once
+	file_location_location_proto_rawDescData = file_location_location_proto_rawDesc
+)
+
+func file_location_location_proto_rawDescGZIP() []byte {
+	file_location_location_proto_rawDescOnce.Do(func() {
+		file_location_location_proto_rawDescData = protoimpl.X.CompressGZIP(file_location_location_proto_rawDescData)
+	})
+	return file_location_location_proto_rawDescData
+}
+
+var file_location_location_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_location_location_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_location_location_proto_goTypes = []interface{}{
+	(NearbyScope)(0),                // 0: openim.location.NearbyScope
+	(*UpdateLocationReq)(nil),       // 1: openim.location.updateLocationReq
+	(*UpdateLocationResp)(nil),      // 2: openim.location.updateLocationResp
+	(*ClearLocationReq)(nil),        // 3: openim.location.clearLocationReq
+	(*ClearLocationResp)(nil),       // 4: openim.location.clearLocationResp
+	(*GetNearbyUsersReq)(nil),       // 5: openim.location.getNearbyUsersReq
+	(*NearbyUser)(nil),              // 6: openim.location.nearbyUser
+	(*GetNearbyUsersResp)(nil),      // 7: openim.location.getNearbyUsersResp
+	(*wrapperspb.BoolValue)(nil),    // 8: openim.protobuf.BoolValue
+	(*sdkws.RequestPagination)(nil), // 9: openim.sdkws.RequestPagination
+	(*sdkws.UserInfo)(nil),          // 10: openim.sdkws.UserInfo
+}
+var file_location_location_proto_depIdxs = []int32{
+	8,  // 0: openim.location.updateLocationReq.discoverable:type_name -> openim.protobuf.BoolValue
+	9,  // 1: openim.location.getNearbyUsersReq.pagination:type_name -> openim.sdkws.RequestPagination
+	0,  // 2: openim.location.getNearbyUsersReq.scope:type_name -> openim.location.NearbyScope
+	10, // 3: openim.location.nearbyUser.user:type_name -> openim.sdkws.UserInfo
+	6,  // 4: openim.location.getNearbyUsersResp.users:type_name -> openim.location.nearbyUser
+	1,  // 5: openim.location.location.updateLocation:input_type -> openim.location.updateLocationReq
+	3,  // 6: openim.location.location.clearLocation:input_type -> openim.location.clearLocationReq
+	5,  // 7: openim.location.location.getNearbyUsers:input_type -> openim.location.getNearbyUsersReq
+	2,  // 8: openim.location.location.updateLocation:output_type -> openim.location.updateLocationResp
+	4,  // 9: openim.location.location.clearLocation:output_type -> openim.location.clearLocationResp
+	7,  // 10: openim.location.location.getNearbyUsers:output_type -> openim.location.getNearbyUsersResp
+	8,  // [8:11] is the sub-list for method output_type
+	5,  // [5:8] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_location_location_proto_init() }
+func file_location_location_proto_init() {
+	if File_location_location_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_location_location_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLocationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateLocationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearLocationReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClearLocationResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNearbyUsersReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NearbyUser); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_location_location_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNearbyUsersResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_location_location_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_location_location_proto_goTypes,
+		DependencyIndexes: file_location_location_proto_depIdxs,
+		EnumInfos:         file_location_location_proto_enumTypes,
+		MessageInfos:      file_location_location_proto_msgTypes,
+	}.Build()
+	File_location_location_proto = out.File
+	file_location_location_proto_rawDesc = nil
+	file_location_location_proto_goTypes = nil
+	file_location_location_proto_depIdxs = nil
+}
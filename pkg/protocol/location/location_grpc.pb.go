@@ -0,0 +1,201 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: location/location.proto
+
+package location
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Location_UpdateLocation_FullMethodName = "/openim.location.location/updateLocation"
+	Location_ClearLocation_FullMethodName  = "/openim.location.location/clearLocation"
+	Location_GetNearbyUsers_FullMethodName = "/openim.location.location/getNearbyUsers"
+)
+
+// LocationClient is the client API for Location service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LocationClient interface {
+	// Report the caller's current position
+	UpdateLocation(ctx context.Context, in *UpdateLocationReq, opts ...grpc.CallOption) (*UpdateLocationResp, error)
+	// Remove the stored position, the user no longer shows up in nearby searches
+	ClearLocation(ctx context.Context, in *ClearLocationReq, opts ...grpc.CallOption) (*ClearLocationResp, error)
+	// Users around a point sorted by distance
+	GetNearbyUsers(ctx context.Context, in *GetNearbyUsersReq, opts ...grpc.CallOption) (*GetNearbyUsersResp, error)
+}
+
+type locationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLocationClient(cc grpc.ClientConnInterface) LocationClient {
+	return &locationClient{cc}
+}
+
+func (c *locationClient) UpdateLocation(ctx context.Context, in *UpdateLocationReq, opts ...grpc.CallOption) (*UpdateLocationResp, error) {
+	out := new(UpdateLocationResp)
+	err := c.cc.Invoke(ctx, Location_UpdateLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationClient) ClearLocation(ctx context.Context, in *ClearLocationReq, opts ...grpc.CallOption) (*ClearLocationResp, error) {
+	out := new(ClearLocationResp)
+	err := c.cc.Invoke(ctx, Location_ClearLocation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *locationClient) GetNearbyUsers(ctx context.Context, in *GetNearbyUsersReq, opts ...grpc.CallOption) (*GetNearbyUsersResp, error) {
+	out := new(GetNearbyUsersResp)
+	err := c.cc.Invoke(ctx, Location_GetNearbyUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LocationServer is the server API for Location service.
+// All implementations should embed UnimplementedLocationServer
+// for forward compatibility
+type LocationServer interface {
+	// Report the caller's current position
+	UpdateLocation(context.Context, *UpdateLocationReq) (*UpdateLocationResp, error)
+	// Remove the stored position, the user no longer shows up in nearby searches
+	ClearLocation(context.Context, *ClearLocationReq) (*ClearLocationResp, error)
+	// Users around a point sorted by distance
+	GetNearbyUsers(context.Context, *GetNearbyUsersReq) (*GetNearbyUsersResp, error)
+}
+
+// UnimplementedLocationServer should be embedded to have forward compatible implementations.
+type UnimplementedLocationServer struct {
+}
+
+func (UnimplementedLocationServer) UpdateLocation(context.Context, *UpdateLocationReq) (*UpdateLocationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocation not implemented")
+}
+func (UnimplementedLocationServer) ClearLocation(context.Context, *ClearLocationReq) (*ClearLocationResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearLocation not implemented")
+}
+func (UnimplementedLocationServer) GetNearbyUsers(context.Context, *GetNearbyUsersReq) (*GetNearbyUsersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearbyUsers not implemented")
+}
+
+// UnsafeLocationServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LocationServer will
+// result in compilation errors.
+type UnsafeLocationServer interface {
+	mustEmbedUnimplementedLocationServer()
+}
+
+func RegisterLocationServer(s grpc.ServiceRegistrar, srv LocationServer) {
+	s.RegisterService(&Location_ServiceDesc, srv)
+}
+
+func _Location_UpdateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServer).UpdateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Location_UpdateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServer).UpdateLocation(ctx, req.(*UpdateLocationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Location_ClearLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearLocationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServer).ClearLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Location_ClearLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServer).ClearLocation(ctx, req.(*ClearLocationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Location_GetNearbyUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNearbyUsersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LocationServer).GetNearbyUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Location_GetNearbyUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LocationServer).GetNearbyUsers(ctx, req.(*GetNearbyUsersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Location_ServiceDesc is the grpc.ServiceDesc for Location service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Location_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.location.location",
+	HandlerType: (*LocationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "updateLocation",
+			Handler:    _Location_UpdateLocation_Handler,
+		},
+		{
+			MethodName: "clearLocation",
+			Handler:    _Location_ClearLocation_Handler,
+		},
+		{
+			MethodName: "getNearbyUsers",
+			Handler:    _Location_GetNearbyUsers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "location/location.proto",
+}
@@ -0,0 +1,36 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package msgedit
+
+import "errors"
+
+// MsgEditNotification is the content type of the notification sent when a message is edited.
+const MsgEditNotification = 2103
+
+func (x *EditMsgReq) Check() error {
+	if x.ConversationID == "" {
+		return errors.New("conversationID is empty")
+	}
+	if x.Seq <= 0 {
+		return errors.New("seq is invalid")
+	}
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	if x.Content == "" {
+		return errors.New("content is empty")
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: msgedit/msgedit.proto
+
+package msgedit
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	MsgEdit_EditMsg_FullMethodName = "/openim.msgedit.msgEdit/editMsg"
+)
+
+// MsgEditClient is the client API for MsgEdit service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MsgEditClient interface {
+	// Replace the content of a sent message, the prior content is kept in the edit history
+	EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error)
+}
+
+type msgEditClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMsgEditClient(cc grpc.ClientConnInterface) MsgEditClient {
+	return &msgEditClient{cc}
+}
+
+func (c *msgEditClient) EditMsg(ctx context.Context, in *EditMsgReq, opts ...grpc.CallOption) (*EditMsgResp, error) {
+	out := new(EditMsgResp)
+	err := c.cc.Invoke(ctx, MsgEdit_EditMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MsgEditServer is the server API for MsgEdit service.
+// All implementations should embed UnimplementedMsgEditServer
+// for forward compatibility
+type MsgEditServer interface {
+	// Replace the content of a sent message, the prior content is kept in the edit history
+	EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error)
+}
+
+// UnimplementedMsgEditServer should be embedded to have forward compatible implementations.
+type UnimplementedMsgEditServer struct {
+}
+
+func (UnimplementedMsgEditServer) EditMsg(context.Context, *EditMsgReq) (*EditMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditMsg not implemented")
+}
+
+// UnsafeMsgEditServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MsgEditServer will
+// result in compilation errors.
+type UnsafeMsgEditServer interface {
+	mustEmbedUnimplementedMsgEditServer()
+}
+
+func RegisterMsgEditServer(s grpc.ServiceRegistrar, srv MsgEditServer) {
+	s.RegisterService(&MsgEdit_ServiceDesc, srv)
+}
+
+func _MsgEdit_EditMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgEditServer).EditMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MsgEdit_EditMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgEditServer).EditMsg(ctx, req.(*EditMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MsgEdit_ServiceDesc is the grpc.ServiceDesc for MsgEdit service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MsgEdit_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.msgedit.msgEdit",
+	HandlerType: (*MsgEditServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "editMsg",
+			Handler:    _MsgEdit_EditMsg_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "msgedit/msgedit.proto",
+}
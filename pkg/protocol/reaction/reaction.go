@@ -0,0 +1,50 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reaction
+
+import "errors"
+
+// MsgReactionNotification is the content type of the notification sent when the reactions of a message change.
+const MsgReactionNotification = 2104
+
+// MaxEmojiLength is the maximum length in bytes of a reaction emoji.
+const MaxEmojiLength = 64
+
+func checkReaction(conversationID string, seq int64, userID string, emoji string) error {
+	if conversationID == "" {
+		return errors.New("conversationID is empty")
+	}
+	if seq <= 0 {
+		return errors.New("seq is invalid")
+	}
+	if userID == "" {
+		return errors.New("userID is empty")
+	}
+	if emoji == "" {
+		return errors.New("emoji is empty")
+	}
+	if len(emoji) > MaxEmojiLength {
+		return errors.New("emoji is too long")
+	}
+	return nil
+}
+
+func (x *AddReactionReq) Check() error {
+	return checkReaction(x.ConversationID, x.Seq, x.UserID, x.Emoji)
+}
+
+func (x *RemoveReactionReq) Check() error {
+	return checkReaction(x.ConversationID, x.Seq, x.UserID, x.Emoji)
+}
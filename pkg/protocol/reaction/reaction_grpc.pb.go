@@ -0,0 +1,162 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: reaction/reaction.proto
+
+package reaction
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Reaction_AddReaction_FullMethodName    = "/openim.reaction.reaction/addReaction"
+	Reaction_RemoveReaction_FullMethodName = "/openim.reaction.reaction/removeReaction"
+)
+
+// ReactionClient is the client API for Reaction service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReactionClient interface {
+	// Add an emoji reaction of the user to a message, adding the same emoji twice has no effect
+	AddReaction(ctx context.Context, in *AddReactionReq, opts ...grpc.CallOption) (*AddReactionResp, error)
+	// Remove an emoji reaction of the user from a message
+	RemoveReaction(ctx context.Context, in *RemoveReactionReq, opts ...grpc.CallOption) (*RemoveReactionResp, error)
+}
+
+type reactionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReactionClient(cc grpc.ClientConnInterface) ReactionClient {
+	return &reactionClient{cc}
+}
+
+func (c *reactionClient) AddReaction(ctx context.Context, in *AddReactionReq, opts ...grpc.CallOption) (*AddReactionResp, error) {
+	out := new(AddReactionResp)
+	err := c.cc.Invoke(ctx, Reaction_AddReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reactionClient) RemoveReaction(ctx context.Context, in *RemoveReactionReq, opts ...grpc.CallOption) (*RemoveReactionResp, error) {
+	out := new(RemoveReactionResp)
+	err := c.cc.Invoke(ctx, Reaction_RemoveReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReactionServer is the server API for Reaction service.
+// All implementations should embed UnimplementedReactionServer
+// for forward compatibility
+type ReactionServer interface {
+	// Add an emoji reaction of the user to a message, adding the same emoji twice has no effect
+	AddReaction(context.Context, *AddReactionReq) (*AddReactionResp, error)
+	// Remove an emoji reaction of the user from a message
+	RemoveReaction(context.Context, *RemoveReactionReq) (*RemoveReactionResp, error)
+}
+
+// UnimplementedReactionServer should be embedded to have forward compatible implementations.
+type UnimplementedReactionServer struct {
+}
+
+func (UnimplementedReactionServer) AddReaction(context.Context, *AddReactionReq) (*AddReactionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReaction not implemented")
+}
+func (UnimplementedReactionServer) RemoveReaction(context.Context, *RemoveReactionReq) (*RemoveReactionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveReaction not implemented")
+}
+
+// UnsafeReactionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReactionServer will
+// result in compilation errors.
+type UnsafeReactionServer interface {
+	mustEmbedUnimplementedReactionServer()
+}
+
+func RegisterReactionServer(s grpc.ServiceRegistrar, srv ReactionServer) {
+	s.RegisterService(&Reaction_ServiceDesc, srv)
+}
+
+func _Reaction_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).AddReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_AddReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).AddReaction(ctx, req.(*AddReactionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reaction_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReactionServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reaction_RemoveReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReactionServer).RemoveReaction(ctx, req.(*RemoveReactionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Reaction_ServiceDesc is the grpc.ServiceDesc for Reaction service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reaction_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.reaction.reaction",
+	HandlerType: (*ReactionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "addReaction",
+			Handler:    _Reaction_AddReaction_Handler,
+		},
+		{
+			MethodName: "removeReaction",
+			Handler:    _Reaction_RemoveReaction_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "reaction/reaction.proto",
+}
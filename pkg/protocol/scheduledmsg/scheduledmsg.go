@@ -0,0 +1,50 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledmsg
+
+import "errors"
+
+func (x *ScheduleSendMsgReq) Check() error {
+	if x.MsgData == nil {
+		return errors.New("msgData is empty")
+	}
+	if x.MsgData.SendID == "" {
+		return errors.New("sendID is empty")
+	}
+	if x.ScheduleTime <= 0 {
+		return errors.New("scheduleTime is invalid")
+	}
+	return nil
+}
+
+func (x *CancelScheduledMsgReq) Check() error {
+	if x.ScheduleID == "" {
+		return errors.New("scheduleID is empty")
+	}
+	return nil
+}
+
+func (x *GetScheduledMsgsReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	if x.Status < 0 || x.Status > 5 {
+		return errors.New("status is invalid")
+	}
+	if x.Pagination == nil {
+		return errors.New("pagination is empty")
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: scheduledmsg/scheduledmsg.proto
+
+package scheduledmsg
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ScheduledMsg_ScheduleSendMsg_FullMethodName       = "/openim.scheduledmsg.scheduledMsg/scheduleSendMsg"
+	ScheduledMsg_CancelScheduledMsg_FullMethodName    = "/openim.scheduledmsg.scheduledMsg/cancelScheduledMsg"
+	ScheduledMsg_GetScheduledMsgs_FullMethodName      = "/openim.scheduledmsg.scheduledMsg/getScheduledMsgs"
+	ScheduledMsg_DispatchScheduledMsgs_FullMethodName = "/openim.scheduledmsg.scheduledMsg/dispatchScheduledMsgs"
+)
+
+// ScheduledMsgClient is the client API for ScheduledMsg service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ScheduledMsgClient interface {
+	// Send the message with the identity of its sender at the schedule time, it is verified when it is sent
+	ScheduleSendMsg(ctx context.Context, in *ScheduleSendMsgReq, opts ...grpc.CallOption) (*ScheduleSendMsgResp, error)
+	// Cancel a message not sent yet
+	CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error)
+	GetScheduledMsgs(ctx context.Context, in *GetScheduledMsgsReq, opts ...grpc.CallOption) (*GetScheduledMsgsResp, error)
+	// Send the due messages, called by the cron task
+	DispatchScheduledMsgs(ctx context.Context, in *DispatchScheduledMsgsReq, opts ...grpc.CallOption) (*DispatchScheduledMsgsResp, error)
+}
+
+type scheduledMsgClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScheduledMsgClient(cc grpc.ClientConnInterface) ScheduledMsgClient {
+	return &scheduledMsgClient{cc}
+}
+
+func (c *scheduledMsgClient) ScheduleSendMsg(ctx context.Context, in *ScheduleSendMsgReq, opts ...grpc.CallOption) (*ScheduleSendMsgResp, error) {
+	out := new(ScheduleSendMsgResp)
+	err := c.cc.Invoke(ctx, ScheduledMsg_ScheduleSendMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledMsgClient) CancelScheduledMsg(ctx context.Context, in *CancelScheduledMsgReq, opts ...grpc.CallOption) (*CancelScheduledMsgResp, error) {
+	out := new(CancelScheduledMsgResp)
+	err := c.cc.Invoke(ctx, ScheduledMsg_CancelScheduledMsg_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledMsgClient) GetScheduledMsgs(ctx context.Context, in *GetScheduledMsgsReq, opts ...grpc.CallOption) (*GetScheduledMsgsResp, error) {
+	out := new(GetScheduledMsgsResp)
+	err := c.cc.Invoke(ctx, ScheduledMsg_GetScheduledMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scheduledMsgClient) DispatchScheduledMsgs(ctx context.Context, in *DispatchScheduledMsgsReq, opts ...grpc.CallOption) (*DispatchScheduledMsgsResp, error) {
+	out := new(DispatchScheduledMsgsResp)
+	err := c.cc.Invoke(ctx, ScheduledMsg_DispatchScheduledMsgs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScheduledMsgServer is the server API for ScheduledMsg service.
+// All implementations should embed UnimplementedScheduledMsgServer
+// for forward compatibility
+type ScheduledMsgServer interface {
+	// Send the message with the identity of its sender at the schedule time, it is verified when it is sent
+	ScheduleSendMsg(context.Context, *ScheduleSendMsgReq) (*ScheduleSendMsgResp, error)
+	// Cancel a message not sent yet
+	CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error)
+	GetScheduledMsgs(context.Context, *GetScheduledMsgsReq) (*GetScheduledMsgsResp, error)
+	// Send the due messages, called by the cron task
+	DispatchScheduledMsgs(context.Context, *DispatchScheduledMsgsReq) (*DispatchScheduledMsgsResp, error)
+}
+
+// UnimplementedScheduledMsgServer should be embedded to have forward compatible implementations.
+type UnimplementedScheduledMsgServer struct {
+}
+
+func (UnimplementedScheduledMsgServer) ScheduleSendMsg(context.Context, *ScheduleSendMsgReq) (*ScheduleSendMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleSendMsg not implemented")
+}
+func (UnimplementedScheduledMsgServer) CancelScheduledMsg(context.Context, *CancelScheduledMsgReq) (*CancelScheduledMsgResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledMsg not implemented")
+}
+func (UnimplementedScheduledMsgServer) GetScheduledMsgs(context.Context, *GetScheduledMsgsReq) (*GetScheduledMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledMsgs not implemented")
+}
+func (UnimplementedScheduledMsgServer) DispatchScheduledMsgs(context.Context, *DispatchScheduledMsgsReq) (*DispatchScheduledMsgsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchScheduledMsgs not implemented")
+}
+
+// UnsafeScheduledMsgServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScheduledMsgServer will
+// result in compilation errors.
+type UnsafeScheduledMsgServer interface {
+	mustEmbedUnimplementedScheduledMsgServer()
+}
+
+func RegisterScheduledMsgServer(s grpc.ServiceRegistrar, srv ScheduledMsgServer) {
+	s.RegisterService(&ScheduledMsg_ServiceDesc, srv)
+}
+
+func _ScheduledMsg_ScheduleSendMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleSendMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledMsgServer).ScheduleSendMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledMsg_ScheduleSendMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledMsgServer).ScheduleSendMsg(ctx, req.(*ScheduleSendMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledMsg_CancelScheduledMsg_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMsgReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledMsgServer).CancelScheduledMsg(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledMsg_CancelScheduledMsg_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledMsgServer).CancelScheduledMsg(ctx, req.(*CancelScheduledMsgReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledMsg_GetScheduledMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledMsgServer).GetScheduledMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledMsg_GetScheduledMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledMsgServer).GetScheduledMsgs(ctx, req.(*GetScheduledMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScheduledMsg_DispatchScheduledMsgs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchScheduledMsgsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScheduledMsgServer).DispatchScheduledMsgs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScheduledMsg_DispatchScheduledMsgs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScheduledMsgServer).DispatchScheduledMsgs(ctx, req.(*DispatchScheduledMsgsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScheduledMsg_ServiceDesc is the grpc.ServiceDesc for ScheduledMsg service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScheduledMsg_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.scheduledmsg.scheduledMsg",
+	HandlerType: (*ScheduledMsgServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "scheduleSendMsg",
+			Handler:    _ScheduledMsg_ScheduleSendMsg_Handler,
+		},
+		{
+			MethodName: "cancelScheduledMsg",
+			Handler:    _ScheduledMsg_CancelScheduledMsg_Handler,
+		},
+		{
+			MethodName: "getScheduledMsgs",
+			Handler:    _ScheduledMsg_GetScheduledMsgs_Handler,
+		},
+		{
+			MethodName: "dispatchScheduledMsgs",
+			Handler:    _ScheduledMsg_DispatchScheduledMsgs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scheduledmsg/scheduledmsg.proto",
+}
@@ -0,0 +1,321 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: versionlog/versionlog.proto
+
+package versionlog
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CompactVersionLogReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Millisecond timestamp, the logs unchanged since are removed
+	Deadline int64 `protobuf:"varint,1,opt,name=deadline,proto3" json:"deadline"`
+}
+
+func (x *CompactVersionLogReq) Reset() {
+	*x = CompactVersionLogReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_versionlog_versionlog_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactVersionLogReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactVersionLogReq) ProtoMessage() {}
+
+func (x *CompactVersionLogReq) ProtoReflect() protoreflect.Message {
+	mi := &file_versionlog_versionlog_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactVersionLogReq.ProtoReflect.Descriptor instead.
+func (*CompactVersionLogReq) Descriptor() ([]byte, []int) {
+	return file_versionlog_versionlog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CompactVersionLogReq) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+type VersionLogCompaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection"`
+	Docs       int64  `protobuf:"varint,2,opt,name=docs,proto3" json:"docs"`
+	Logs       int64  `protobuf:"varint,3,opt,name=logs,proto3" json:"logs"`
+}
+
+func (x *VersionLogCompaction) Reset() {
+	*x = VersionLogCompaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_versionlog_versionlog_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionLogCompaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionLogCompaction) ProtoMessage() {}
+
+func (x *VersionLogCompaction) ProtoReflect() protoreflect.Message {
+	mi := &file_versionlog_versionlog_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionLogCompaction.ProtoReflect.Descriptor instead.
+func (*VersionLogCompaction) Descriptor() ([]byte, []int) {
+	return file_versionlog_versionlog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VersionLogCompaction) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *VersionLogCompaction) GetDocs() int64 {
+	if x != nil {
+		return x.Docs
+	}
+	return 0
+}
+
+func (x *VersionLogCompaction) GetLogs() int64 {
+	if x != nil {
+		return x.Logs
+	}
+	return 0
+}
+
+type CompactVersionLogResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Compactions []*VersionLogCompaction `protobuf:"bytes,1,rep,name=compactions,proto3" json:"compactions"`
+}
+
+func (x *CompactVersionLogResp) Reset() {
+	*x = CompactVersionLogResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_versionlog_versionlog_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompactVersionLogResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompactVersionLogResp) ProtoMessage() {}
+
+func (x *CompactVersionLogResp) ProtoReflect() protoreflect.Message {
+	mi := &file_versionlog_versionlog_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompactVersionLogResp.ProtoReflect.Descriptor instead.
+func (*CompactVersionLogResp) Descriptor() ([]byte, []int) {
+	return file_versionlog_versionlog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CompactVersionLogResp) GetCompactions() []*VersionLogCompaction {
+	if x != nil {
+		return x.Compactions
+	}
+	return nil
+}
+
+var File_versionlog_versionlog_proto protoreflect.FileDescriptor
+
+var file_versionlog_versionlog_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x2f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x6f,
+	0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67,
+	0x22, 0x32, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x22, 0x5e, 0x0a, 0x14, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c,
+	0x6f, 0x67, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x6f, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x6c, 0x6f, 0x67, 0x73, 0x22, 0x62, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x12, 0x49, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x6f,
+	0x67, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0x74, 0x0a, 0x0a, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x12, 0x66, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x12, 0x27, 0x2e, 0x6f, 0x70,
+	0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x6f,
+	0x67, 0x52, 0x65, 0x71, 0x1a, 0x28, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x42, 0x3d,
+	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4b, 0x79, 0x6c,
+	0x65, 0x59, 0x65, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x69, 0x6d, 0x2d, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x2f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x6c, 0x6f, 0x67, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_versionlog_versionlog_proto_rawDescOnce sync.Once
+	file_versionlog_versionlog_proto_rawDescData = file_versionlog_versionlog_proto_rawDesc
+)
+
+func file_versionlog_versionlog_proto_rawDescGZIP() []byte {
+	file_versionlog_versionlog_proto_rawDescOnce.Do(func() {
+		file_versionlog_versionlog_proto_rawDescData = protoimpl.X.CompressGZIP(file_versionlog_versionlog_proto_rawDescData)
+	})
+	return file_versionlog_versionlog_proto_rawDescData
+}
+
+var file_versionlog_versionlog_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_versionlog_versionlog_proto_goTypes = []interface{}{
+	(*CompactVersionLogReq)(nil),  // 0: openim.versionlog.compactVersionLogReq
+	(*VersionLogCompaction)(nil),  // 1: openim.versionlog.versionLogCompaction
+	(*CompactVersionLogResp)(nil), // 2: openim.versionlog.compactVersionLogResp
+}
+var file_versionlog_versionlog_proto_depIdxs = []int32{
+	1, // 0: openim.versionlog.compactVersionLogResp.compactions:type_name -> openim.versionlog.versionLogCompaction
+	0, // 1: openim.versionlog.versionLog.compactVersionLog:input_type -> openim.versionlog.compactVersionLogReq
+	2, // 2: openim.versionlog.versionLog.compactVersionLog:output_type -> openim.versionlog.compactVersionLogResp
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_versionlog_versionlog_proto_init() }
+func file_versionlog_versionlog_proto_init() {
+	if File_versionlog_versionlog_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_versionlog_versionlog_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactVersionLogReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_versionlog_versionlog_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionLogCompaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_versionlog_versionlog_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompactVersionLogResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_versionlog_versionlog_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_versionlog_versionlog_proto_goTypes,
+		DependencyIndexes: file_versionlog_versionlog_proto_depIdxs,
+		MessageInfos:      file_versionlog_versionlog_proto_msgTypes,
+	}.Build()
+	File_versionlog_versionlog_proto = out.File
+	file_versionlog_versionlog_proto_rawDesc = nil
+	file_versionlog_versionlog_proto_goTypes = nil
+	file_versionlog_versionlog_proto_depIdxs = nil
+}
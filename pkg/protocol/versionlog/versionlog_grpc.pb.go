@@ -0,0 +1,123 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: versionlog/versionlog.proto
+
+package versionlog
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	VersionLog_CompactVersionLog_FullMethodName = "/openim.versionlog.versionLog/compactVersionLog"
+)
+
+// VersionLogClient is the client API for VersionLog service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VersionLogClient interface {
+	// Compact the incremental sync version logs of the service, a client with an older version syncs in full
+	CompactVersionLog(ctx context.Context, in *CompactVersionLogReq, opts ...grpc.CallOption) (*CompactVersionLogResp, error)
+}
+
+type versionLogClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVersionLogClient(cc grpc.ClientConnInterface) VersionLogClient {
+	return &versionLogClient{cc}
+}
+
+func (c *versionLogClient) CompactVersionLog(ctx context.Context, in *CompactVersionLogReq, opts ...grpc.CallOption) (*CompactVersionLogResp, error) {
+	out := new(CompactVersionLogResp)
+	err := c.cc.Invoke(ctx, VersionLog_CompactVersionLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VersionLogServer is the server API for VersionLog service.
+// All implementations should embed UnimplementedVersionLogServer
+// for forward compatibility
+type VersionLogServer interface {
+	// Compact the incremental sync version logs of the service, a client with an older version syncs in full
+	CompactVersionLog(context.Context, *CompactVersionLogReq) (*CompactVersionLogResp, error)
+}
+
+// UnimplementedVersionLogServer should be embedded to have forward compatible implementations.
+type UnimplementedVersionLogServer struct {
+}
+
+func (UnimplementedVersionLogServer) CompactVersionLog(context.Context, *CompactVersionLogReq) (*CompactVersionLogResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactVersionLog not implemented")
+}
+
+// UnsafeVersionLogServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VersionLogServer will
+// result in compilation errors.
+type UnsafeVersionLogServer interface {
+	mustEmbedUnimplementedVersionLogServer()
+}
+
+func RegisterVersionLogServer(s grpc.ServiceRegistrar, srv VersionLogServer) {
+	s.RegisterService(&VersionLog_ServiceDesc, srv)
+}
+
+func _VersionLog_CompactVersionLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactVersionLogReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VersionLogServer).CompactVersionLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VersionLog_CompactVersionLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VersionLogServer).CompactVersionLog(ctx, req.(*CompactVersionLogReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VersionLog_ServiceDesc is the grpc.ServiceDesc for VersionLog service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VersionLog_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.versionlog.versionLog",
+	HandlerType: (*VersionLogServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "compactVersionLog",
+			Handler:    _VersionLog_CompactVersionLog_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "versionlog/versionlog.proto",
+}
@@ -0,0 +1,90 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webpush
+
+import (
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+)
+
+const (
+	// P256dhLength is the length of an uncompressed P-256 public key
+	P256dhLength = 65
+	AuthLength   = 16
+)
+
+// DecodeKey decodes a base64url key of a subscription, with or without padding.
+func DecodeKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
+
+func (x *WebPushSubscription) Check() error {
+	if x == nil {
+		return errors.New("subscription is empty")
+	}
+	u, err := url.Parse(x.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.New("endpoint is not a https url")
+	}
+	if !isPublicHost(u.Hostname()) {
+		return errors.New("endpoint is not a public host")
+	}
+	if key, err := DecodeKey(x.P256Dh); err != nil || len(key) != P256dhLength {
+		return errors.New("p256dh is invalid")
+	}
+	if auth, err := DecodeKey(x.Auth); err != nil || len(auth) != AuthLength {
+		return errors.New("auth is invalid")
+	}
+	return nil
+}
+
+// isPublicHost rejects the hosts of the internal network, the server posts to the endpoint of every subscription.
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return true
+	}
+	return IsPublicIP(ip)
+}
+
+// IsPublicIP rejects the addresses of the internal network. A public host name can still resolve to them,
+// so the push client checks the address it dials as well.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsUnspecified()
+}
+
+func (x *RegisterWebPushSubscriptionReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	return x.Subscription.Check()
+}
+
+func (x *UnregisterWebPushSubscriptionReq) Check() error {
+	if x.UserID == "" {
+		return errors.New("userID is empty")
+	}
+	if x.Endpoint == "" {
+		return errors.New("endpoint is empty")
+	}
+	return nil
+}
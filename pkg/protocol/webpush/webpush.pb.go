@@ -0,0 +1,462 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: webpush/webpush.proto
+
+package webpush
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PushSubscription of a browser as returned by pushManager.subscribe
+type WebPushSubscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL of the push service
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint"`
+	// Base64url encoded P-256 public key of the browser, keys.p256dh
+	P256Dh string `protobuf:"bytes,2,opt,name=p256dh,proto3" json:"p256dh"`
+	// Base64url encoded authentication secret, keys.auth
+	Auth string `protobuf:"bytes,3,opt,name=auth,proto3" json:"auth"`
+}
+
+func (x *WebPushSubscription) Reset() {
+	*x = WebPushSubscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webpush_webpush_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebPushSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebPushSubscription) ProtoMessage() {}
+
+func (x *WebPushSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_webpush_webpush_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebPushSubscription.ProtoReflect.Descriptor instead.
+func (*WebPushSubscription) Descriptor() ([]byte, []int) {
+	return file_webpush_webpush_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WebPushSubscription) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *WebPushSubscription) GetP256Dh() string {
+	if x != nil {
+		return x.P256Dh
+	}
+	return ""
+}
+
+func (x *WebPushSubscription) GetAuth() string {
+	if x != nil {
+		return x.Auth
+	}
+	return ""
+}
+
+type RegisterWebPushSubscriptionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID       string               `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID"`
+	Subscription *WebPushSubscription `protobuf:"bytes,2,opt,name=subscription,proto3" json:"subscription"`
+}
+
+func (x *RegisterWebPushSubscriptionReq) Reset() {
+	*x = RegisterWebPushSubscriptionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webpush_webpush_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterWebPushSubscriptionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebPushSubscriptionReq) ProtoMessage() {}
+
+func (x *RegisterWebPushSubscriptionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_webpush_webpush_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebPushSubscriptionReq.ProtoReflect.Descriptor instead.
+func (*RegisterWebPushSubscriptionReq) Descriptor() ([]byte, []int) {
+	return file_webpush_webpush_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegisterWebPushSubscriptionReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *RegisterWebPushSubscriptionReq) GetSubscription() *WebPushSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type RegisterWebPushSubscriptionResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterWebPushSubscriptionResp) Reset() {
+	*x = RegisterWebPushSubscriptionResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webpush_webpush_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterWebPushSubscriptionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebPushSubscriptionResp) ProtoMessage() {}
+
+func (x *RegisterWebPushSubscriptionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_webpush_webpush_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebPushSubscriptionResp.ProtoReflect.Descriptor instead.
+func (*RegisterWebPushSubscriptionResp) Descriptor() ([]byte, []int) {
+	return file_webpush_webpush_proto_rawDescGZIP(), []int{2}
+}
+
+type UnregisterWebPushSubscriptionReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserID   string `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID"`
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint"`
+}
+
+func (x *UnregisterWebPushSubscriptionReq) Reset() {
+	*x = UnregisterWebPushSubscriptionReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webpush_webpush_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnregisterWebPushSubscriptionReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterWebPushSubscriptionReq) ProtoMessage() {}
+
+func (x *UnregisterWebPushSubscriptionReq) ProtoReflect() protoreflect.Message {
+	mi := &file_webpush_webpush_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterWebPushSubscriptionReq.ProtoReflect.Descriptor instead.
+func (*UnregisterWebPushSubscriptionReq) Descriptor() ([]byte, []int) {
+	return file_webpush_webpush_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UnregisterWebPushSubscriptionReq) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *UnregisterWebPushSubscriptionReq) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+type UnregisterWebPushSubscriptionResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnregisterWebPushSubscriptionResp) Reset() {
+	*x = UnregisterWebPushSubscriptionResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_webpush_webpush_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnregisterWebPushSubscriptionResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnregisterWebPushSubscriptionResp) ProtoMessage() {}
+
+func (x *UnregisterWebPushSubscriptionResp) ProtoReflect() protoreflect.Message {
+	mi := &file_webpush_webpush_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnregisterWebPushSubscriptionResp.ProtoReflect.Descriptor instead.
+func (*UnregisterWebPushSubscriptionResp) Descriptor() ([]byte, []int) {
+	return file_webpush_webpush_proto_rawDescGZIP(), []int{4}
+}
+
+var File_webpush_webpush_proto protoreflect.FileDescriptor
+
+var file_webpush_webpush_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2f, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73,
+	0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e,
+	0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x22, 0x5d, 0x0a, 0x13, 0x77, 0x65, 0x62, 0x50, 0x75,
+	0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x32,
+	0x35, 0x36, 0x64, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x32, 0x35, 0x36,
+	0x64, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x81, 0x01, 0x0a, 0x1e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x47, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d,
+	0x2e, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x77, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x21, 0x0a, 0x1f, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x22, 0x56, 0x0a,
+	0x20, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75,
+	0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x23, 0x0a, 0x21, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x32, 0x90, 0x02, 0x0a, 0x07, 0x77,
+	0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x12, 0x7e, 0x0a, 0x1b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x77,
+	0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57,
+	0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x2f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69, 0x6d, 0x2e, 0x77,
+	0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57,
+	0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x84, 0x01, 0x0a, 0x1d, 0x75, 0x6e, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x69,
+	0x6d, 0x2e, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x75, 0x6e, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x1a, 0x31, 0x2e, 0x6f, 0x70, 0x65,
+	0x6e, 0x69, 0x6d, 0x2e, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x2e, 0x75, 0x6e, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x65, 0x62, 0x50, 0x75, 0x73, 0x68, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x42, 0x3a, 0x5a,
+	0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4b, 0x79, 0x6c, 0x65,
+	0x59, 0x65, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x69, 0x6d, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2f, 0x76, 0x33, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x2f, 0x77, 0x65, 0x62, 0x70, 0x75, 0x73, 0x68, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_webpush_webpush_proto_rawDescOnce sync.Once
+	file_webpush_webpush_proto_rawDescData = file_webpush_webpush_proto_rawDesc
+)
+
+func file_webpush_webpush_proto_rawDescGZIP() []byte {
+	file_webpush_webpush_proto_rawDescOnce.Do(func() {
+		file_webpush_webpush_proto_rawDescData = protoimpl.X.CompressGZIP(file_webpush_webpush_proto_rawDescData)
+	})
+	return file_webpush_webpush_proto_rawDescData
+}
+
+var file_webpush_webpush_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_webpush_webpush_proto_goTypes = []interface{}{
+	(*WebPushSubscription)(nil),               // 0: openim.webpush.webPushSubscription
+	(*RegisterWebPushSubscriptionReq)(nil),    // 1: openim.webpush.registerWebPushSubscriptionReq
+	(*RegisterWebPushSubscriptionResp)(nil),   // 2: openim.webpush.registerWebPushSubscriptionResp
+	(*UnregisterWebPushSubscriptionReq)(nil),  // 3: openim.webpush.unregisterWebPushSubscriptionReq
+	(*UnregisterWebPushSubscriptionResp)(nil), // 4: openim.webpush.unregisterWebPushSubscriptionResp
+}
+var file_webpush_webpush_proto_depIdxs = []int32{
+	0, // 0: openim.webpush.registerWebPushSubscriptionReq.subscription:type_name -> openim.webpush.webPushSubscription
+	1, // 1: openim.webpush.webPush.registerWebPushSubscription:input_type -> openim.webpush.registerWebPushSubscriptionReq
+	3, // 2: openim.webpush.webPush.unregisterWebPushSubscription:input_type -> openim.webpush.unregisterWebPushSubscriptionReq
+	2, // 3: openim.webpush.webPush.registerWebPushSubscription:output_type -> openim.webpush.registerWebPushSubscriptionResp
+	4, // 4: openim.webpush.webPush.unregisterWebPushSubscription:output_type -> openim.webpush.unregisterWebPushSubscriptionResp
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_webpush_webpush_proto_init() }
+func file_webpush_webpush_proto_init() {
+	if File_webpush_webpush_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_webpush_webpush_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebPushSubscription); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webpush_webpush_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterWebPushSubscriptionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webpush_webpush_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterWebPushSubscriptionResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webpush_webpush_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnregisterWebPushSubscriptionReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_webpush_webpush_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnregisterWebPushSubscriptionResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_webpush_webpush_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_webpush_webpush_proto_goTypes,
+		DependencyIndexes: file_webpush_webpush_proto_depIdxs,
+		MessageInfos:      file_webpush_webpush_proto_msgTypes,
+	}.Build()
+	File_webpush_webpush_proto = out.File
+	file_webpush_webpush_proto_rawDesc = nil
+	file_webpush_webpush_proto_goTypes = nil
+	file_webpush_webpush_proto_depIdxs = nil
+}
@@ -0,0 +1,162 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.12.4
+// source: webpush/webpush.proto
+
+package webpush
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	WebPush_RegisterWebPushSubscription_FullMethodName   = "/openim.webpush.webPush/registerWebPushSubscription"
+	WebPush_UnregisterWebPushSubscription_FullMethodName = "/openim.webpush.webPush/unregisterWebPushSubscription"
+)
+
+// WebPushClient is the client API for WebPush service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebPushClient interface {
+	// Register the subscription of a browser for the Web Push offline pusher, a user can have several browsers
+	RegisterWebPushSubscription(ctx context.Context, in *RegisterWebPushSubscriptionReq, opts ...grpc.CallOption) (*RegisterWebPushSubscriptionResp, error)
+	// Remove a subscription, e.g. when the user logs out of the browser
+	UnregisterWebPushSubscription(ctx context.Context, in *UnregisterWebPushSubscriptionReq, opts ...grpc.CallOption) (*UnregisterWebPushSubscriptionResp, error)
+}
+
+type webPushClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebPushClient(cc grpc.ClientConnInterface) WebPushClient {
+	return &webPushClient{cc}
+}
+
+func (c *webPushClient) RegisterWebPushSubscription(ctx context.Context, in *RegisterWebPushSubscriptionReq, opts ...grpc.CallOption) (*RegisterWebPushSubscriptionResp, error) {
+	out := new(RegisterWebPushSubscriptionResp)
+	err := c.cc.Invoke(ctx, WebPush_RegisterWebPushSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webPushClient) UnregisterWebPushSubscription(ctx context.Context, in *UnregisterWebPushSubscriptionReq, opts ...grpc.CallOption) (*UnregisterWebPushSubscriptionResp, error) {
+	out := new(UnregisterWebPushSubscriptionResp)
+	err := c.cc.Invoke(ctx, WebPush_UnregisterWebPushSubscription_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebPushServer is the server API for WebPush service.
+// All implementations should embed UnimplementedWebPushServer
+// for forward compatibility
+type WebPushServer interface {
+	// Register the subscription of a browser for the Web Push offline pusher, a user can have several browsers
+	RegisterWebPushSubscription(context.Context, *RegisterWebPushSubscriptionReq) (*RegisterWebPushSubscriptionResp, error)
+	// Remove a subscription, e.g. when the user logs out of the browser
+	UnregisterWebPushSubscription(context.Context, *UnregisterWebPushSubscriptionReq) (*UnregisterWebPushSubscriptionResp, error)
+}
+
+// UnimplementedWebPushServer should be embedded to have forward compatible implementations.
+type UnimplementedWebPushServer struct {
+}
+
+func (UnimplementedWebPushServer) RegisterWebPushSubscription(context.Context, *RegisterWebPushSubscriptionReq) (*RegisterWebPushSubscriptionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebPushSubscription not implemented")
+}
+func (UnimplementedWebPushServer) UnregisterWebPushSubscription(context.Context, *UnregisterWebPushSubscriptionReq) (*UnregisterWebPushSubscriptionResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnregisterWebPushSubscription not implemented")
+}
+
+// UnsafeWebPushServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebPushServer will
+// result in compilation errors.
+type UnsafeWebPushServer interface {
+	mustEmbedUnimplementedWebPushServer()
+}
+
+func RegisterWebPushServer(s grpc.ServiceRegistrar, srv WebPushServer) {
+	s.RegisterService(&WebPush_ServiceDesc, srv)
+}
+
+func _WebPush_RegisterWebPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebPushSubscriptionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebPushServer).RegisterWebPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebPush_RegisterWebPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebPushServer).RegisterWebPushSubscription(ctx, req.(*RegisterWebPushSubscriptionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebPush_UnregisterWebPushSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnregisterWebPushSubscriptionReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebPushServer).UnregisterWebPushSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebPush_UnregisterWebPushSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebPushServer).UnregisterWebPushSubscription(ctx, req.(*UnregisterWebPushSubscriptionReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebPush_ServiceDesc is the grpc.ServiceDesc for WebPush service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebPush_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "openim.webpush.webPush",
+	HandlerType: (*WebPushServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "registerWebPushSubscription",
+			Handler:    _WebPush_RegisterWebPushSubscription_Handler,
+		},
+		{
+			MethodName: "unregisterWebPushSubscription",
+			Handler:    _WebPush_UnregisterWebPushSubscription_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webpush/webpush.proto",
+}
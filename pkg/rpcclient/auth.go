@@ -19,6 +19,7 @@ import (
 
 	"github.com/KyleYe/open-im-protocol/auth"
 	pbAuth "github.com/KyleYe/open-im-protocol/auth"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/session"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/system/program"
 	"google.golang.org/grpc"
@@ -30,13 +31,14 @@ func NewAuth(discov discovery.SvcDiscoveryRegistry, rpcRegisterName string) *Aut
 		program.ExitWithError(err)
 	}
 	client := auth.NewAuthClient(conn)
-	return &Auth{discov: discov, conn: conn, Client: client}
+	return &Auth{discov: discov, conn: conn, Client: client, SessionClient: session.NewSessionClient(conn)}
 }
 
 type Auth struct {
-	conn   grpc.ClientConnInterface
-	Client auth.AuthClient
-	discov discovery.SvcDiscoveryRegistry
+	conn          grpc.ClientConnInterface
+	Client        auth.AuthClient
+	SessionClient session.SessionClient
+	discov        discovery.SvcDiscoveryRegistry
 }
 
 func (a *Auth) ParseToken(ctx context.Context, token string) (*pbAuth.ParseTokenResp, error) {
@@ -23,6 +23,10 @@ import (
 	"github.com/KyleYe/open-im-protocol/msg"
 	"github.com/KyleYe/open-im-protocol/sdkws"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/msgedit"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/reaction"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/scheduledmsg"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/log"
 	"github.com/KyleYe/open-im-tools/mcontext"
@@ -77,9 +81,11 @@ func newContentTypeConf(conf *config.Notification) map[int32]config.Notification
 		constant.ConversationUnreadNotification:      conf.ConversationChanged,
 		constant.ConversationPrivateChatNotification: conf.ConversationSetPrivate,
 		// msg
-		constant.MsgRevokeNotification:  {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
-		constant.HasReadReceipt:         {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
-		constant.DeleteMsgsNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.MsgRevokeNotification:   {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.HasReadReceipt:          {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		constant.DeleteMsgsNotification:  {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		msgedit.MsgEditNotification:      {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
+		reaction.MsgReactionNotification: {IsSendMsg: false, ReliabilityLevel: constant.ReliableNotificationNoMsg},
 	}
 }
 
@@ -130,9 +136,13 @@ func newSessionTypeConf() map[int32]int32 {
 }
 
 type Message struct {
-	conn   grpc.ClientConnInterface
-	Client msg.MsgClient
-	discov discovery.SvcDiscoveryRegistry
+	conn            grpc.ClientConnInterface
+	Client          msg.MsgClient
+	EditClient      msgedit.MsgEditClient
+	ReactionClient  reaction.ReactionClient
+	BroadcastClient broadcast.BroadcastClient
+	ScheduledClient scheduledmsg.ScheduledMsgClient
+	discov          discovery.SvcDiscoveryRegistry
 }
 
 func NewMessage(discov discovery.SvcDiscoveryRegistry, rpcRegisterName string) *Message {
@@ -141,7 +151,7 @@ func NewMessage(discov discovery.SvcDiscoveryRegistry, rpcRegisterName string) *
 		program.ExitWithError(err)
 	}
 	client := msg.NewMsgClient(conn)
-	return &Message{discov: discov, conn: conn, Client: client}
+	return &Message{discov: discov, conn: conn, Client: client, EditClient: msgedit.NewMsgEditClient(conn), ReactionClient: reaction.NewReactionClient(conn), BroadcastClient: broadcast.NewBroadcastClient(conn), ScheduledClient: scheduledmsg.NewScheduledMsgClient(conn)}
 }
 
 type MessageRpcClient Message
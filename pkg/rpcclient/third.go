@@ -18,16 +18,20 @@ import (
 	"context"
 
 	"github.com/KyleYe/open-im-protocol/third"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/apns"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/webpush"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/system/program"
 	"google.golang.org/grpc"
 )
 
 type Third struct {
-	conn       grpc.ClientConnInterface
-	Client     third.ThirdClient
-	discov     discovery.SvcDiscoveryRegistry
-	GrafanaUrl string
+	conn          grpc.ClientConnInterface
+	Client        third.ThirdClient
+	ApnsClient    apns.ApnsClient
+	WebPushClient webpush.WebPushClient
+	discov        discovery.SvcDiscoveryRegistry
+	GrafanaUrl    string
 }
 
 func NewThird(discov discovery.SvcDiscoveryRegistry, rpcRegisterName, grafanaUrl string) *Third {
@@ -39,7 +43,7 @@ func NewThird(discov discovery.SvcDiscoveryRegistry, rpcRegisterName, grafanaUrl
 	if err != nil {
 		program.ExitWithError(err)
 	}
-	return &Third{discov: discov, Client: client, conn: conn, GrafanaUrl: grafanaUrl}
+	return &Third{discov: discov, Client: client, ApnsClient: apns.NewApnsClient(conn), WebPushClient: webpush.NewWebPushClient(conn), conn: conn, GrafanaUrl: grafanaUrl}
 }
 func (t *Third) DeleteOutdatedData(ctx context.Context, expires int64) error {
 	_, err := t.Client.DeleteOutdatedData(ctx, &third.DeleteOutdatedDataReq{ExpireTime: expires})
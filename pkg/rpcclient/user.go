@@ -22,6 +22,8 @@ import (
 	"github.com/KyleYe/open-im-protocol/user"
 	"github.com/KyleYe/open-im-server/v3/pkg/authverify"
 	"github.com/KyleYe/open-im-server/v3/pkg/common/servererrs"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/broadcast"
+	"github.com/KyleYe/open-im-server/v3/pkg/protocol/location"
 	"github.com/KyleYe/open-im-tools/discovery"
 	"github.com/KyleYe/open-im-tools/system/program"
 	"github.com/KyleYe/open-im-tools/utils/datautil"
@@ -32,6 +34,8 @@ import (
 type User struct {
 	conn                  grpc.ClientConnInterface
 	Client                user.UserClient
+	LocationClient        location.LocationClient
+	BroadcastClient       broadcast.BroadcastRecipientClient
 	Discov                discovery.SvcDiscoveryRegistry
 	MessageGateWayRpcName string
 	imAdminUserID         []string
@@ -46,6 +50,8 @@ func NewUser(discov discovery.SvcDiscoveryRegistry, rpcRegisterName, messageGate
 	}
 	client := user.NewUserClient(conn)
 	return &User{Discov: discov, Client: client,
+		LocationClient:        location.NewLocationClient(conn),
+		BroadcastClient:       broadcast.NewBroadcastRecipientClient(conn),
 		conn:                  conn,
 		MessageGateWayRpcName: messageGateWayRpcName,
 		imAdminUserID:         imAdminUserID}
@@ -0,0 +1,421 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/s3"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	awss3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	minPartSize int64 = 1024 * 1024 * 5        // 5MB
+	maxPartSize int64 = 1024 * 1024 * 1024 * 5 // 5GB
+	maxNumSize  int64 = 10000
+)
+
+const successCode = http.StatusOK
+
+type Config struct {
+	// Endpoint of an S3 compatible service, empty for AWS S3
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	AccessKeySecret string
+	SessionToken    string
+	PublicRead      bool
+	// PathStyle addresses the bucket as endpoint/bucket instead of bucket.endpoint
+	PathStyle bool
+}
+
+type Aws struct {
+	bucket        string
+	region        string
+	bucketURL     string
+	publicRead    bool
+	credentials   aws.CredentialsProvider
+	client        *awss3.Client
+	presignClient *awss3.PresignClient
+}
+
+// NewAws creates the engine. Without an access key the default credential chain of the SDK is used,
+// e.g. the IAM role of the instance.
+func NewAws(conf Config) (*Aws, error) {
+	if conf.Bucket == "" || conf.Region == "" {
+		return nil, errs.New("aws bucket and region are required").Wrap()
+	}
+	opts := []func(*awsconfig.LoadOptions) error{awsconfig.WithRegion(conf.Region)}
+	if conf.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(conf.AccessKeyID, conf.AccessKeySecret, conf.SessionToken)))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(context.Background(), opts...)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "load aws config failed")
+	}
+	client := awss3.NewFromConfig(cfg, func(o *awss3.Options) {
+		if conf.Endpoint != "" {
+			o.BaseEndpoint = aws.String(conf.Endpoint)
+		}
+		o.UsePathStyle = conf.PathStyle
+	})
+	bucketURL, err := getBucketURL(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &Aws{
+		bucket:        conf.Bucket,
+		region:        conf.Region,
+		bucketURL:     bucketURL,
+		publicRead:    conf.PublicRead,
+		credentials:   cfg.Credentials,
+		client:        client,
+		presignClient: awss3.NewPresignClient(client),
+	}, nil
+}
+
+func getBucketURL(conf Config) (string, error) {
+	if conf.Endpoint == "" {
+		if conf.PathStyle {
+			return fmt.Sprintf("https://s3.%s.amazonaws.com/%s", conf.Region, conf.Bucket), nil
+		}
+		return fmt.Sprintf("https://%s.s3.%s.amazonaws.com", conf.Bucket, conf.Region), nil
+	}
+	u, err := url.Parse(conf.Endpoint)
+	if err != nil {
+		return "", errs.WrapMsg(err, "invalid aws endpoint", "endpoint", conf.Endpoint)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", errs.New("aws endpoint must be an absolute url", "endpoint", conf.Endpoint).Wrap()
+	}
+	if conf.PathStyle {
+		return strings.TrimSuffix(conf.Endpoint, "/") + "/" + conf.Bucket, nil
+	}
+	return u.Scheme + "://" + conf.Bucket + "." + u.Host, nil
+}
+
+func (a *Aws) Engine() string {
+	return "aws"
+}
+
+func (a *Aws) PartLimit() *s3.PartLimit {
+	return &s3.PartLimit{
+		MinPartSize: minPartSize,
+		MaxPartSize: maxPartSize,
+		MaxNumSize:  maxNumSize,
+	}
+}
+
+func (a *Aws) InitiateMultipartUpload(ctx context.Context, name string) (*s3.InitiateMultipartUploadResult, error) {
+	result, err := a.client.CreateMultipartUpload(ctx, &awss3.CreateMultipartUploadInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3.InitiateMultipartUploadResult{
+		UploadID: aws.ToString(result.UploadId),
+		Bucket:   aws.ToString(result.Bucket),
+		Key:      aws.ToString(result.Key),
+	}, nil
+}
+
+func (a *Aws) CompleteMultipartUpload(ctx context.Context, uploadID string, name string, parts []s3.Part) (*s3.CompleteMultipartUploadResult, error) {
+	awsParts := make([]awss3types.CompletedPart, len(parts))
+	for i, part := range parts {
+		awsParts[i] = awss3types.CompletedPart{
+			PartNumber: aws.Int32(int32(part.PartNumber)),
+			ETag:       aws.String(part.ETag),
+		}
+	}
+	result, err := a.client.CompleteMultipartUpload(ctx, &awss3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(a.bucket),
+		Key:             aws.String(name),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &awss3types.CompletedMultipartUpload{Parts: awsParts},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3.CompleteMultipartUploadResult{
+		Location: aws.ToString(result.Location),
+		Bucket:   aws.ToString(result.Bucket),
+		Key:      aws.ToString(result.Key),
+		ETag:     formatETag(aws.ToString(result.ETag)),
+	}, nil
+}
+
+func (a *Aws) PartSize(ctx context.Context, size int64) (int64, error) {
+	if size <= 0 {
+		return 0, errors.New("size must be greater than 0")
+	}
+	if size > maxPartSize*maxNumSize {
+		return 0, fmt.Errorf("AWS size must be less than the maximum allowed limit")
+	}
+	if size <= minPartSize*maxNumSize {
+		return minPartSize, nil
+	}
+	partSize := size / maxNumSize
+	if size%maxNumSize != 0 {
+		partSize++
+	}
+	return partSize, nil
+}
+
+func (a *Aws) AuthSign(ctx context.Context, uploadID string, name string, expire time.Duration, partNumbers []int) (*s3.AuthSignResult, error) {
+	result := s3.AuthSignResult{
+		Parts: make([]s3.SignPart, len(partNumbers)),
+	}
+	for i, partNumber := range partNumbers {
+		part, err := a.presignClient.PresignUploadPart(ctx, &awss3.UploadPartInput{
+			Bucket:     aws.String(a.bucket),
+			Key:        aws.String(name),
+			UploadId:   aws.String(uploadID),
+			PartNumber: aws.Int32(int32(partNumber)),
+		}, awss3.WithPresignExpires(expire))
+		if err != nil {
+			return nil, err
+		}
+		result.Parts[i] = s3.SignPart{
+			PartNumber: partNumber,
+			URL:        part.URL,
+			Header:     part.SignedHeader,
+		}
+	}
+	return &result, nil
+}
+
+func (a *Aws) PresignedPutObject(ctx context.Context, name string, expire time.Duration) (string, error) {
+	res, err := a.presignClient.PresignPutObject(ctx, &awss3.PutObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	}, awss3.WithPresignExpires(expire))
+	if err != nil {
+		return "", err
+	}
+	return res.URL, nil
+}
+
+func (a *Aws) DeleteObject(ctx context.Context, name string) error {
+	_, err := a.client.DeleteObject(ctx, &awss3.DeleteObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	})
+	return err
+}
+
+func (a *Aws) CopyObject(ctx context.Context, src string, dst string) (*s3.CopyObjectInfo, error) {
+	result, err := a.client.CopyObject(ctx, &awss3.CopyObjectInput{
+		Bucket:     aws.String(a.bucket),
+		CopySource: aws.String(a.bucket + "/" + escapeKey(src)),
+		Key:        aws.String(dst),
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &s3.CopyObjectInfo{Key: dst}
+	if result.CopyObjectResult != nil {
+		res.ETag = formatETag(aws.ToString(result.CopyObjectResult.ETag))
+	}
+	return res, nil
+}
+
+func (a *Aws) StatObject(ctx context.Context, name string) (*s3.ObjectInfo, error) {
+	info, err := a.client.HeadObject(ctx, &awss3.HeadObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s3.ObjectInfo{
+		ETag:         formatETag(aws.ToString(info.ETag)),
+		Key:          name,
+		Size:         aws.ToInt64(info.ContentLength),
+		LastModified: aws.ToTime(info.LastModified),
+	}, nil
+}
+
+func (a *Aws) IsNotFound(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "NotFound", "NoSuchKey":
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Aws) AbortMultipartUpload(ctx context.Context, uploadID string, name string) error {
+	_, err := a.client.AbortMultipartUpload(ctx, &awss3.AbortMultipartUploadInput{
+		Bucket:   aws.String(a.bucket),
+		Key:      aws.String(name),
+		UploadId: aws.String(uploadID),
+	})
+	return err
+}
+
+func (a *Aws) ListUploadedParts(ctx context.Context, uploadID string, name string, partNumberMarker int, maxParts int) (*s3.ListUploadedPartsResult, error) {
+	result, err := a.client.ListParts(ctx, &awss3.ListPartsInput{
+		Bucket:           aws.String(a.bucket),
+		Key:              aws.String(name),
+		UploadId:         aws.String(uploadID),
+		MaxParts:         aws.Int32(int32(maxParts)),
+		PartNumberMarker: aws.String(strconv.Itoa(partNumberMarker)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &s3.ListUploadedPartsResult{
+		Key:           aws.ToString(result.Key),
+		UploadID:      aws.ToString(result.UploadId),
+		MaxParts:      int(aws.ToInt32(result.MaxParts)),
+		UploadedParts: make([]s3.UploadedPart, len(result.Parts)),
+	}
+	if marker := aws.ToString(result.NextPartNumberMarker); marker != "" {
+		res.NextPartNumberMarker, err = strconv.Atoi(marker)
+		if err != nil {
+			return nil, errs.WrapMsg(err, "invalid next part number marker", "marker", marker)
+		}
+	}
+	for i, part := range result.Parts {
+		res.UploadedParts[i] = s3.UploadedPart{
+			PartNumber:   int(aws.ToInt32(part.PartNumber)),
+			LastModified: aws.ToTime(part.LastModified),
+			ETag:         formatETag(aws.ToString(part.ETag)),
+			Size:         aws.ToInt64(part.Size),
+		}
+	}
+	return res, nil
+}
+
+// AccessURL returns a presigned download url. S3 does not process images, the original object is returned for them.
+func (a *Aws) AccessURL(ctx context.Context, name string, expire time.Duration, opt *s3.AccessURLOption) (string, error) {
+	input := &awss3.GetObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(name),
+	}
+	if opt != nil {
+		if opt.ContentType != "" {
+			input.ResponseContentType = aws.String(opt.ContentType)
+		}
+		if opt.Filename != "" {
+			input.ResponseContentDisposition = aws.String(`attachment; filename=` + strconv.Quote(opt.Filename))
+		}
+	}
+	if a.publicRead && input.ResponseContentType == nil && input.ResponseContentDisposition == nil {
+		return a.bucketURL + "/" + escapeKey(name), nil
+	}
+	res, err := a.presignClient.PresignGetObject(ctx, input, awss3.WithPresignExpires(expire))
+	if err != nil {
+		return "", err
+	}
+	return res.URL, nil
+}
+
+// FormData signs a browser based POST upload, https://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-HTTPPOSTConstructPolicy.html
+func (a *Aws) FormData(ctx context.Context, name string, size int64, contentType string, duration time.Duration) (*s3.FormData, error) {
+	creds, err := a.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "retrieve aws credentials failed")
+	}
+	now := time.Now().UTC()
+	expires := now.Add(duration)
+	date := now.Format("20060102")
+	credential := strings.Join([]string{creds.AccessKeyID, date, a.region, "s3", "aws4_request"}, "/")
+	formData := map[string]string{
+		"key":                   name,
+		"success_action_status": strconv.Itoa(successCode),
+		"x-amz-algorithm":       "AWS4-HMAC-SHA256",
+		"x-amz-credential":      credential,
+		"x-amz-date":            now.Format("20060102T150405Z"),
+		"x-amz-security-token":  creds.SessionToken,
+		"Content-Type":          contentType,
+	}
+	conditions := []any{map[string]string{"bucket": a.bucket}}
+	for key, value := range formData {
+		if value == "" {
+			delete(formData, key)
+			continue
+		}
+		conditions = append(conditions, map[string]string{key: value})
+	}
+	if size > 0 {
+		conditions = append(conditions, []any{"content-length-range", 0, size})
+	}
+	policy, err := json.Marshal(map[string]any{
+		"expiration": expires.Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, errs.WrapMsg(err, "marshal post policy failed")
+	}
+	encodedPolicy := base64.StdEncoding.EncodeToString(policy)
+	formData["policy"] = encodedPolicy
+	formData["x-amz-signature"] = hex.EncodeToString(hmacSHA256(signingKey(creds.SecretAccessKey, date, a.region), encodedPolicy))
+	return &s3.FormData{
+		URL:          a.bucketURL,
+		File:         "file",
+		FormData:     formData,
+		Expires:      expires,
+		SuccessCodes: []int{successCode},
+	}, nil
+}
+
+func signingKey(secret string, date string, region string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func formatETag(etag string) string {
+	return strings.ToLower(strings.Trim(etag, `"`))
+}
+
+func escapeKey(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
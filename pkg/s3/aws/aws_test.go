@@ -0,0 +1,69 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketURL(t *testing.T) {
+	cases := []struct {
+		conf Config
+		url  string
+	}{
+		{Config{Region: "us-east-1", Bucket: "im"}, "https://im.s3.us-east-1.amazonaws.com"},
+		{Config{Region: "us-east-1", Bucket: "im", PathStyle: true}, "https://s3.us-east-1.amazonaws.com/im"},
+		{Config{Endpoint: "http://127.0.0.1:9000/", Region: "us-east-1", Bucket: "im", PathStyle: true}, "http://127.0.0.1:9000/im"},
+		{Config{Endpoint: "https://storage.example.com", Region: "auto", Bucket: "im"}, "https://im.storage.example.com"},
+	}
+	for _, c := range cases {
+		u, err := getBucketURL(c.conf)
+		assert.NoError(t, err)
+		assert.Equal(t, c.url, u)
+	}
+	_, err := getBucketURL(Config{Endpoint: "127.0.0.1:9000", Bucket: "im"})
+	assert.Error(t, err)
+}
+
+func TestFormData(t *testing.T) {
+	a, err := NewAws(Config{Region: "us-east-1", Bucket: "im", AccessKeyID: "AKID", AccessKeySecret: "secret"})
+	assert.NoError(t, err)
+	fd, err := a.FormData(context.Background(), "openim/data/a.png", 1024, "image/png", time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://im.s3.us-east-1.amazonaws.com", fd.URL)
+	assert.Equal(t, "openim/data/a.png", fd.FormData["key"])
+	assert.Equal(t, "image/png", fd.FormData["Content-Type"])
+	assert.NotContains(t, fd.FormData, "x-amz-security-token")
+
+	policy, err := base64.StdEncoding.DecodeString(fd.FormData["policy"])
+	assert.NoError(t, err)
+	var p struct {
+		Conditions []any `json:"conditions"`
+	}
+	assert.NoError(t, json.Unmarshal(policy, &p))
+	assert.Contains(t, p.Conditions, map[string]any{"bucket": "im"})
+	assert.Contains(t, p.Conditions, []any{"content-length-range", float64(0), float64(1024)})
+
+	date := fd.FormData["x-amz-date"][:8]
+	sign := hex.EncodeToString(hmacSHA256(signingKey("secret", date, "us-east-1"), fd.FormData["policy"]))
+	assert.Equal(t, sign, fd.FormData["x-amz-signature"])
+}
@@ -0,0 +1,306 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/s3"
+)
+
+// FormDataPath is the path, relative to the /object route, that browser based uploads are posted to.
+const FormDataPath = "upload_form_data"
+
+const (
+	methodGet  = http.MethodGet
+	methodPut  = http.MethodPut
+	methodPost = http.MethodPost
+
+	queryExpires            = "expires"
+	querySign               = "sign"
+	queryUploadID           = "uploadId"
+	queryPartNumber         = "partNumber"
+	queryContentType        = "response-content-type"
+	queryContentDisposition = "response-content-disposition"
+
+	formKey       = "key"
+	formPolicy    = "policy"
+	formSignature = "signature"
+	formFile      = "file"
+
+	successCode = http.StatusOK
+
+	// maxFormFieldsSize bounds the fields other than the file read into memory
+	maxFormFieldsSize int64 = 1024 * 64
+)
+
+type uploadPolicy struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"contentType"`
+	Expires     int64  `json:"expires"`
+}
+
+func encodePolicy(policy []byte) string {
+	return base64.StdEncoding.EncodeToString(policy)
+}
+
+func (l *Local) sign(method string, name string, payload string) string {
+	h := hmac.New(sha256.New, l.secret)
+	h.Write([]byte(method + "\n" + name + "\n" + payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// signURL returns the url of the object under the /object route, signed for method until expire.
+func (l *Local) signURL(method string, name string, query url.Values, expire time.Duration) string {
+	query.Set(queryExpires, strconv.FormatInt(time.Now().Add(expire).Unix(), 10))
+	query.Set(querySign, l.sign(method, name, query.Encode()))
+	return l.url + "/" + name + "?" + query.Encode()
+}
+
+// verifyURL checks the signature and the expiry of a url produced by signURL.
+func (l *Local) verifyURL(method string, name string, query url.Values) error {
+	query = cloneValues(query)
+	sign := query.Get(querySign)
+	query.Del(querySign)
+	if sign == "" || !hmac.Equal([]byte(sign), []byte(l.sign(method, name, query.Encode()))) {
+		return errs.ErrNoPermission.WrapMsg("invalid object signature")
+	}
+	expires, err := strconv.ParseInt(query.Get(queryExpires), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return errs.ErrNoPermission.WrapMsg("object url expired")
+	}
+	return nil
+}
+
+func cloneValues(values url.Values) url.Values {
+	clone := make(url.Values, len(values))
+	for k, v := range values {
+		clone[k] = append([]string(nil), v...)
+	}
+	return clone
+}
+
+// IsSigned reports whether the request carries a signature of this engine, unsigned requests are left to the api.
+func IsSigned(r *http.Request) bool {
+	return r.URL.Query().Has(querySign)
+}
+
+// ServeObject serves a signed request for the object name: GET and HEAD download it, PUT uploads it or one of its parts.
+func (l *Local) ServeObject(w http.ResponseWriter, r *http.Request, name string) {
+	name = strings.TrimPrefix(name, "/")
+	if err := checkKey(name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	method := r.Method
+	if method == http.MethodHead {
+		method = methodGet
+	}
+	query := r.URL.Query()
+	if err := l.verifyURL(method, name, query); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	switch method {
+	case methodGet:
+		l.serveGet(w, r, name, query)
+	case methodPut:
+		l.servePut(w, r, name, query)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (l *Local) serveGet(w http.ResponseWriter, r *http.Request, name string, query url.Values) {
+	file, err := os.Open(l.dataPath(name))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil || stat.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	if contentType := query.Get(queryContentType); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	if disposition := query.Get(queryContentDisposition); disposition != "" {
+		w.Header().Set("Content-Disposition", disposition)
+	}
+	if meta, err := l.readMeta(name); err == nil {
+		w.Header().Set("ETag", strconv.Quote(meta.ETag))
+	}
+	http.ServeContent(w, r, name, stat.ModTime(), file)
+}
+
+func (l *Local) servePut(w http.ResponseWriter, r *http.Request, name string, query url.Values) {
+	var (
+		etag string
+		err  error
+	)
+	if uploadID := query.Get(queryUploadID); uploadID != "" {
+		partNumber, perr := strconv.Atoi(query.Get(queryPartNumber))
+		if perr != nil {
+			http.Error(w, "invalid part number", http.StatusBadRequest)
+			return
+		}
+		etag, err = l.putPart(uploadID, name, partNumber, http.MaxBytesReader(w, r.Body, maxPartSize))
+	} else {
+		var info *s3.ObjectInfo
+		info, err = l.putObject(name, http.MaxBytesReader(w, r.Body, maxPartSize))
+		if info != nil {
+			etag = info.ETag
+		}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("ETag", strconv.Quote(etag))
+	w.WriteHeader(http.StatusOK)
+}
+
+// ServeFormData serves a multipart form posted with the fields returned by FormData.
+// The form is read as a stream: policy and signature have to precede the file part,
+// which is only stored once they are verified.
+func (l *Local) ServeFormData(w http.ResponseWriter, r *http.Request) {
+	if r.Method != methodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxPartSize+maxFormFieldsSize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	values := make(map[string]string)
+	remaining := maxFormFieldsSize
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			http.Error(w, "form file missing", http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			writeError(w, errs.ErrArgs.WrapMsg(err.Error()))
+			return
+		}
+		if part.FormName() != formFile {
+			value, err := io.ReadAll(io.LimitReader(part, remaining+1))
+			if err != nil {
+				writeError(w, errs.ErrArgs.WrapMsg(err.Error()))
+				return
+			}
+			remaining -= int64(len(value))
+			if remaining < 0 {
+				http.Error(w, "form fields too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			values[part.FormName()] = string(value)
+			continue
+		}
+		policy, err := l.verifyFormPolicy(values)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		info, err := l.putObject(policy.Key, &exactReader{r: part, size: policy.Size})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("ETag", strconv.Quote(info.ETag))
+		w.WriteHeader(successCode)
+		return
+	}
+}
+
+// verifyFormPolicy checks the signature and the expiry of the policy and that the key is the one it was signed for.
+func (l *Local) verifyFormPolicy(values map[string]string) (*uploadPolicy, error) {
+	encodedPolicy := values[formPolicy]
+	signature := values[formSignature]
+	if signature == "" || !hmac.Equal([]byte(signature), []byte(l.sign(methodPost, FormDataPath, encodedPolicy))) {
+		return nil, errs.ErrNoPermission.WrapMsg("invalid form signature")
+	}
+	data, err := base64.StdEncoding.DecodeString(encodedPolicy)
+	if err != nil {
+		return nil, errs.ErrArgs.WrapMsg("invalid form policy")
+	}
+	var policy uploadPolicy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, errs.ErrArgs.WrapMsg("invalid form policy")
+	}
+	if time.Now().Unix() > policy.Expires {
+		return nil, errs.ErrNoPermission.WrapMsg("form policy expired")
+	}
+	if values[formKey] != policy.Key {
+		return nil, errs.ErrArgs.WrapMsg("form key mismatching")
+	}
+	return &policy, nil
+}
+
+// exactReader fails unless r yields exactly size bytes, so a mismatching file is never stored.
+type exactReader struct {
+	r    io.Reader
+	size int64
+	read int64
+}
+
+func (e *exactReader) Read(p []byte) (int, error) {
+	n, err := e.r.Read(p)
+	e.read += int64(n)
+	if e.read > e.size || (err == io.EOF && e.read != e.size) {
+		return n, errs.ErrArgs.WrapMsg("form file size mismatching", "size", e.size)
+	}
+	return n, err
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesErr):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errs.ErrNoPermission.Is(err):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errs.ErrArgs.Is(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errs.ErrRecordNotFound.Is(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
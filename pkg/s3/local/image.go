@@ -0,0 +1,115 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/s3"
+	"golang.org/x/image/draw"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	imageThumbnailPath = "openim/thumbnail"
+	maxImageSize       = 1024 * 1024 * 50
+
+	formatPng  = "png"
+	formatJpeg = "jpeg"
+	formatJpg  = "jpg"
+	formatGif  = "gif"
+)
+
+// thumbnail returns the key and the content type of the thumbnail of the object, the thumbnail is stored once per etag and size.
+func (l *Local) thumbnail(info *s3.ObjectInfo, opt *s3.Image) (string, string, error) {
+	if info.Size > maxImageSize {
+		return "", "", errs.New("file size too large").Wrap()
+	}
+	data, err := os.ReadFile(l.dataPath(info.Key))
+	if err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", "", errs.New("object not image").Wrap()
+	}
+	bounds := img.Bounds()
+	width, height := opt.Width, opt.Height
+	if width > bounds.Dx() || width <= 0 {
+		width = bounds.Dx()
+	}
+	if height > bounds.Dy() || height <= 0 {
+		height = bounds.Dy()
+	}
+	imgFormat := strings.ToLower(opt.Format)
+	if imgFormat == formatJpg {
+		imgFormat = formatJpeg
+	}
+	switch imgFormat {
+	case formatPng, formatJpeg, formatGif:
+	default:
+		imgFormat = ""
+	}
+	if width == bounds.Dx() && height == bounds.Dy() && (imgFormat == format || imgFormat == "") {
+		return info.Key, "image/" + format, nil
+	}
+	if imgFormat == "" {
+		imgFormat = formatPng
+	}
+	key := path.Join(imageThumbnailPath, info.ETag, fmt.Sprintf("image_w%d_h%d.%s", width, height, imgFormat))
+	if _, err := os.Stat(l.dataPath(key)); err == nil {
+		return key, "image/" + imgFormat, nil
+	}
+	thumbnail := resizeImage(img, width, height)
+	buf := bytes.NewBuffer(nil)
+	switch imgFormat {
+	case formatPng:
+		err = png.Encode(buf, thumbnail)
+	case formatJpeg:
+		err = jpeg.Encode(buf, thumbnail, nil)
+	case formatGif:
+		err = gif.Encode(buf, thumbnail, nil)
+	}
+	if err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	if _, err := l.putObject(key, buf); err != nil {
+		return "", "", err
+	}
+	return key, "image/" + imgFormat, nil
+}
+
+// resizeImage scales img to fit in maxWidth x maxHeight, keeping the aspect ratio.
+func resizeImage(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	scale := min(float64(maxWidth)/float64(bounds.Dx()), float64(maxHeight)/float64(bounds.Dy()))
+	width := max(int(float64(bounds.Dx())*scale), 1)
+	height := max(int(float64(bounds.Dy())*scale), 1)
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.ApproxBiLinear.Scale(thumbnail, thumbnail.Bounds(), img, bounds, draw.Over, nil)
+	return thumbnail
+}
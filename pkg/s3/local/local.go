@@ -0,0 +1,547 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/s3"
+)
+
+const (
+	minPartSize int64 = 1024 * 1024 * 5        // 5MB
+	maxPartSize int64 = 1024 * 1024 * 1024 * 5 // 5GB
+	maxNumSize  int64 = 10000
+)
+
+const (
+	dataDir   = "data"
+	metaDir   = "meta"
+	uploadDir = "upload"
+	tempDir   = "temp"
+
+	uploadKeyFile = "key"
+)
+
+type Config struct {
+	// Directory the objects are stored in
+	Directory string
+	// URL of the /object route of the api service, e.g. http://127.0.0.1:10002/object
+	URL string
+	// Secret signs the upload and download urls
+	Secret string
+}
+
+type Local struct {
+	dir    string
+	url    string
+	secret []byte
+}
+
+type objectMeta struct {
+	ETag string `json:"etag"`
+}
+
+func NewLocal(conf Config) (*Local, error) {
+	if conf.Directory == "" {
+		return nil, errs.New("local object directory is required").Wrap()
+	}
+	if conf.Secret == "" {
+		return nil, errs.New("local object secret is required").Wrap()
+	}
+	dir, err := filepath.Abs(conf.Directory)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "invalid local object directory", "directory", conf.Directory)
+	}
+	for _, sub := range []string{dataDir, metaDir, uploadDir, tempDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, errs.WrapMsg(err, "create local object directory failed", "directory", dir)
+		}
+	}
+	return &Local{
+		dir:    dir,
+		url:    strings.TrimSuffix(conf.URL, "/"),
+		secret: []byte(conf.Secret),
+	}, nil
+}
+
+func (l *Local) Engine() string {
+	return "local"
+}
+
+func (l *Local) PartLimit() *s3.PartLimit {
+	return &s3.PartLimit{
+		MinPartSize: minPartSize,
+		MaxPartSize: maxPartSize,
+		MaxNumSize:  maxNumSize,
+	}
+}
+
+// checkKey rejects names that would escape the directory.
+func checkKey(name string) error {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, `\`) || path.Clean(name) != name {
+		return errs.ErrArgs.WrapMsg("invalid object name", "name", name)
+	}
+	for _, elem := range strings.Split(name, "/") {
+		if elem == "." || elem == ".." {
+			return errs.ErrArgs.WrapMsg("invalid object name", "name", name)
+		}
+	}
+	return nil
+}
+
+func (l *Local) dataPath(name string) string {
+	return filepath.Join(l.dir, dataDir, filepath.FromSlash(name))
+}
+
+func (l *Local) metaPath(name string) string {
+	return filepath.Join(l.dir, metaDir, filepath.FromSlash(name))
+}
+
+func (l *Local) uploadPath(uploadID string) (string, error) {
+	if uploadID == "" || strings.ContainsAny(uploadID, `/\.`) {
+		return "", errs.ErrArgs.WrapMsg("invalid upload id", "uploadID", uploadID)
+	}
+	return filepath.Join(l.dir, uploadDir, uploadID), nil
+}
+
+func partPath(uploadPath string, partNumber int) string {
+	return filepath.Join(uploadPath, strconv.Itoa(partNumber))
+}
+
+// writeFile writes r to a temporary file that is then renamed to filename, it returns the md5 and the size of the content.
+func (l *Local) writeFile(filename string, r io.Reader) (string, int64, error) {
+	temp, err := os.CreateTemp(filepath.Join(l.dir, tempDir), "object-")
+	if err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	defer os.Remove(temp.Name())
+	h := md5.New()
+	size, err := io.Copy(io.MultiWriter(temp, h), r)
+	if err != nil {
+		_ = temp.Close()
+		return "", 0, errs.Wrap(err)
+	}
+	if err := temp.Close(); err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	if err := os.Rename(temp.Name(), filename); err != nil {
+		return "", 0, errs.Wrap(err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// putObject stores the object, its md5 is kept as the ETag.
+func (l *Local) putObject(name string, r io.Reader) (*s3.ObjectInfo, error) {
+	if err := checkKey(name); err != nil {
+		return nil, err
+	}
+	etag, size, err := l.writeFile(l.dataPath(name), r)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.writeMeta(name, &objectMeta{ETag: etag}); err != nil {
+		return nil, err
+	}
+	return &s3.ObjectInfo{ETag: etag, Key: name, Size: size, LastModified: time.Now()}, nil
+}
+
+func (l *Local) writeMeta(name string, meta *objectMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	_, _, err = l.writeFile(l.metaPath(name), strings.NewReader(string(data)))
+	return err
+}
+
+func (l *Local) readMeta(name string) (*objectMeta, error) {
+	data, err := os.ReadFile(l.metaPath(name))
+	if err != nil {
+		return nil, err
+	}
+	var meta objectMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &meta, nil
+}
+
+func (l *Local) InitiateMultipartUpload(ctx context.Context, name string) (*s3.InitiateMultipartUploadResult, error) {
+	if err := checkKey(name); err != nil {
+		return nil, err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	uploadID := hex.EncodeToString(id)
+	uploadPath, err := l.uploadPath(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(uploadPath, 0o755); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if err := os.WriteFile(filepath.Join(uploadPath, uploadKeyFile), []byte(name), 0o644); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &s3.InitiateMultipartUploadResult{Key: name, UploadID: uploadID}, nil
+}
+
+// checkUpload returns the directory of the upload, the upload must be for name.
+func (l *Local) checkUpload(uploadID string, name string) (string, error) {
+	uploadPath, err := l.uploadPath(uploadID)
+	if err != nil {
+		return "", err
+	}
+	key, err := os.ReadFile(filepath.Join(uploadPath, uploadKeyFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", errs.ErrRecordNotFound.WrapMsg("upload not found", "uploadID", uploadID)
+		}
+		return "", errs.Wrap(err)
+	}
+	if string(key) != name {
+		return "", errs.ErrArgs.WrapMsg("upload is not for the object", "uploadID", uploadID, "name", name)
+	}
+	return uploadPath, nil
+}
+
+// putPart stores a part of a multipart upload, it returns the md5 of the part.
+func (l *Local) putPart(uploadID string, name string, partNumber int, r io.Reader) (string, error) {
+	if partNumber < 1 || int64(partNumber) > maxNumSize {
+		return "", errs.ErrArgs.WrapMsg("invalid part number", "partNumber", partNumber)
+	}
+	uploadPath, err := l.checkUpload(uploadID, name)
+	if err != nil {
+		return "", err
+	}
+	etag, _, err := l.writeFile(partPath(uploadPath, partNumber), io.LimitReader(r, maxPartSize))
+	return etag, err
+}
+
+// CompleteMultipartUpload assembles the parts in order, each part must match the md5 reported for it.
+func (l *Local) CompleteMultipartUpload(ctx context.Context, uploadID string, name string, parts []s3.Part) (*s3.CompleteMultipartUploadResult, error) {
+	uploadPath, err := l.checkUpload(uploadID, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) == 0 {
+		return nil, errs.ErrArgs.WrapMsg("no parts")
+	}
+	files := make([]*os.File, 0, len(parts))
+	defer func() {
+		for _, file := range files {
+			_ = file.Close()
+		}
+	}()
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		file, err := os.Open(partPath(uploadPath, part.PartNumber))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, errs.ErrArgs.WrapMsg("part not uploaded", "partNumber", part.PartNumber)
+			}
+			return nil, errs.Wrap(err)
+		}
+		files = append(files, file)
+		h := md5.New()
+		if _, err := io.Copy(h, file); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		if etag := hex.EncodeToString(h.Sum(nil)); etag != strings.ToLower(strings.Trim(part.ETag, `"`)) {
+			return nil, errs.ErrArgs.WrapMsg("part md5 mismatching", "partNumber", part.PartNumber, "etag", etag)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, errs.Wrap(err)
+		}
+		readers = append(readers, file)
+	}
+	info, err := l.putObject(name, io.MultiReader(readers...))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(uploadPath); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &s3.CompleteMultipartUploadResult{
+		Location: l.url + "/" + name,
+		Key:      name,
+		ETag:     info.ETag,
+	}, nil
+}
+
+func (l *Local) PartSize(ctx context.Context, size int64) (int64, error) {
+	if size <= 0 {
+		return 0, errors.New("size must be greater than 0")
+	}
+	if size > maxPartSize*maxNumSize {
+		return 0, fmt.Errorf("LOCAL size must be less than the maximum allowed limit")
+	}
+	if size <= minPartSize*maxNumSize {
+		return minPartSize, nil
+	}
+	partSize := size / maxNumSize
+	if size%maxNumSize != 0 {
+		partSize++
+	}
+	return partSize, nil
+}
+
+func (l *Local) AuthSign(ctx context.Context, uploadID string, name string, expire time.Duration, partNumbers []int) (*s3.AuthSignResult, error) {
+	if _, err := l.checkUpload(uploadID, name); err != nil {
+		return nil, err
+	}
+	result := s3.AuthSignResult{
+		Parts: make([]s3.SignPart, len(partNumbers)),
+	}
+	for i, partNumber := range partNumbers {
+		query := url.Values{
+			queryUploadID:   {uploadID},
+			queryPartNumber: {strconv.Itoa(partNumber)},
+		}
+		result.Parts[i] = s3.SignPart{
+			PartNumber: partNumber,
+			URL:        l.signURL(methodPut, name, query, expire),
+		}
+	}
+	return &result, nil
+}
+
+func (l *Local) PresignedPutObject(ctx context.Context, name string, expire time.Duration) (string, error) {
+	if err := checkKey(name); err != nil {
+		return "", err
+	}
+	return l.signURL(methodPut, name, url.Values{}, expire), nil
+}
+
+func (l *Local) DeleteObject(ctx context.Context, name string) error {
+	if err := checkKey(name); err != nil {
+		return err
+	}
+	if err := os.Remove(l.dataPath(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err)
+	}
+	if err := os.Remove(l.metaPath(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+func (l *Local) CopyObject(ctx context.Context, src string, dst string) (*s3.CopyObjectInfo, error) {
+	if err := checkKey(src); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(l.dataPath(src))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer file.Close()
+	info, err := l.putObject(dst, file)
+	if err != nil {
+		return nil, err
+	}
+	return &s3.CopyObjectInfo{Key: dst, ETag: info.ETag}, nil
+}
+
+func (l *Local) StatObject(ctx context.Context, name string) (*s3.ObjectInfo, error) {
+	if err := checkKey(name); err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(l.dataPath(name))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if stat.IsDir() {
+		return nil, errs.Wrap(fs.ErrNotExist)
+	}
+	meta, err := l.readMeta(name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		// The object was written without its meta, e.g. by a crash in between
+		meta, err = l.rebuildMeta(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &s3.ObjectInfo{
+		ETag:         meta.ETag,
+		Key:          name,
+		Size:         stat.Size(),
+		LastModified: stat.ModTime(),
+	}, nil
+}
+
+func (l *Local) rebuildMeta(name string) (*objectMeta, error) {
+	file, err := os.Open(l.dataPath(name))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer file.Close()
+	h := md5.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	meta := &objectMeta{ETag: hex.EncodeToString(h.Sum(nil))}
+	if err := l.writeMeta(name, meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+func (l *Local) IsNotFound(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errs.ErrRecordNotFound.Is(err)
+}
+
+func (l *Local) AbortMultipartUpload(ctx context.Context, uploadID string, name string) error {
+	uploadPath, err := l.checkUpload(uploadID, name)
+	if err != nil {
+		return err
+	}
+	return errs.Wrap(os.RemoveAll(uploadPath))
+}
+
+func (l *Local) ListUploadedParts(ctx context.Context, uploadID string, name string, partNumberMarker int, maxParts int) (*s3.ListUploadedPartsResult, error) {
+	uploadPath, err := l.checkUpload(uploadID, name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(uploadPath)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	partNumbers := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		partNumber, err := strconv.Atoi(entry.Name())
+		if err != nil || partNumber <= partNumberMarker {
+			continue
+		}
+		partNumbers = append(partNumbers, partNumber)
+	}
+	sort.Ints(partNumbers)
+	res := &s3.ListUploadedPartsResult{Key: name, UploadID: uploadID, MaxParts: maxParts}
+	for _, partNumber := range partNumbers {
+		if maxParts > 0 && len(res.UploadedParts) == maxParts {
+			break
+		}
+		part, err := l.uploadedPart(uploadPath, partNumber)
+		if err != nil {
+			return nil, err
+		}
+		res.UploadedParts = append(res.UploadedParts, *part)
+		res.NextPartNumberMarker = partNumber
+	}
+	return res, nil
+}
+
+func (l *Local) uploadedPart(uploadPath string, partNumber int) (*s3.UploadedPart, error) {
+	file, err := os.Open(partPath(uploadPath, partNumber))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	h := md5.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &s3.UploadedPart{
+		PartNumber:   partNumber,
+		LastModified: stat.ModTime(),
+		ETag:         hex.EncodeToString(h.Sum(nil)),
+		Size:         stat.Size(),
+	}, nil
+}
+
+func (l *Local) AccessURL(ctx context.Context, name string, expire time.Duration, opt *s3.AccessURLOption) (string, error) {
+	info, err := l.StatObject(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	if opt != nil {
+		if opt.Image != nil {
+			key, contentType, err := l.thumbnail(info, opt.Image)
+			if err != nil {
+				return "", err
+			}
+			name = key
+			query.Set(queryContentType, contentType)
+		} else {
+			if opt.ContentType != "" {
+				query.Set(queryContentType, opt.ContentType)
+			}
+			if opt.Filename != "" {
+				query.Set(queryContentDisposition, `attachment; filename=`+strconv.Quote(opt.Filename))
+			}
+		}
+	}
+	return l.signURL(methodGet, name, query, expire), nil
+}
+
+// FormData signs a browser based upload, the form is posted to the FormDataPath of the /object route.
+func (l *Local) FormData(ctx context.Context, name string, size int64, contentType string, duration time.Duration) (*s3.FormData, error) {
+	if err := checkKey(name); err != nil {
+		return nil, err
+	}
+	expires := time.Now().Add(duration)
+	policy, err := json.Marshal(&uploadPolicy{
+		Key:         name,
+		Size:        size,
+		ContentType: contentType,
+		Expires:     expires.Unix(),
+	})
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	encodedPolicy := encodePolicy(policy)
+	return &s3.FormData{
+		URL:  l.url + "/" + FormDataPath,
+		File: formFile,
+		FormData: map[string]string{
+			formKey:       name,
+			formPolicy:    encodedPolicy,
+			formSignature: l.sign(methodPost, FormDataPath, encodedPolicy),
+		},
+		Expires:      expires,
+		SuccessCodes: []int{successCode},
+	}, nil
+}
@@ -0,0 +1,214 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KyleYe/open-im-tools/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLocal(t *testing.T) *Local {
+	l, err := NewLocal(Config{Directory: t.TempDir(), URL: "http://127.0.0.1:10002/object", Secret: "openIM123"})
+	assert.NoError(t, err)
+	return l
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// serve sends the request for the signed url to the handler as the api does.
+func serve(l *Local, method string, rawURL string, body []byte) *httptest.ResponseRecorder {
+	u, _ := url.Parse(rawURL)
+	r := httptest.NewRequest(method, u.RequestURI(), bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	l.ServeObject(w, r, strings.TrimPrefix(u.Path, "/object/"))
+	return w
+}
+
+func TestCheckKey(t *testing.T) {
+	assert.NoError(t, checkKey("openim/data/hash/abc"))
+	for _, name := range []string{"", "/abs", "../escape", "a/../../b", "a//b", `a\b`, "a/./b"} {
+		assert.Error(t, checkKey(name), name)
+	}
+}
+
+func TestSignedURL(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocal(t)
+	putURL, err := l.PresignedPutObject(ctx, "a/b.txt", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, serve(l, http.MethodPut, putURL, []byte("hello")).Code)
+
+	info, err := l.StatObject(ctx, "a/b.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, md5Hex([]byte("hello")), info.ETag)
+
+	// the put signature does not allow downloading
+	assert.Equal(t, http.StatusForbidden, serve(l, http.MethodGet, putURL, nil).Code)
+	// nor uploading another object
+	assert.Equal(t, http.StatusForbidden, serve(l, http.MethodPut, strings.Replace(putURL, "b.txt", "c.txt", 1), nil).Code)
+
+	getURL, err := l.AccessURL(ctx, "a/b.txt", time.Minute, &s3.AccessURLOption{Filename: "b.txt"})
+	assert.NoError(t, err)
+	w := serve(l, http.MethodGet, getURL, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", w.Body.String())
+	assert.Equal(t, `attachment; filename="b.txt"`, w.Header().Get("Content-Disposition"))
+
+	expiredURL, err := l.AccessURL(ctx, "a/b.txt", -time.Minute, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, serve(l, http.MethodGet, expiredURL, nil).Code)
+
+	assert.NoError(t, l.DeleteObject(ctx, "a/b.txt"))
+	_, err = l.StatObject(ctx, "a/b.txt")
+	assert.True(t, l.IsNotFound(err))
+}
+
+func TestMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocal(t)
+	upload, err := l.InitiateMultipartUpload(ctx, "big.bin")
+	assert.NoError(t, err)
+	parts := [][]byte{[]byte("part one,"), []byte("part two")}
+	sign, err := l.AuthSign(ctx, upload.UploadID, upload.Key, time.Minute, []int{1, 2})
+	assert.NoError(t, err)
+	for i, part := range sign.Parts {
+		w := serve(l, http.MethodPut, part.URL, parts[i])
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"`+md5Hex(parts[i])+`"`, w.Header().Get("ETag"))
+	}
+	uploaded, err := l.ListUploadedParts(ctx, upload.UploadID, upload.Key, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, uploaded.UploadedParts, 2)
+
+	_, err = l.CompleteMultipartUpload(ctx, upload.UploadID, upload.Key, []s3.Part{
+		{PartNumber: 1, ETag: md5Hex(parts[1])},
+		{PartNumber: 2, ETag: md5Hex(parts[1])},
+	})
+	assert.Error(t, err)
+
+	res, err := l.CompleteMultipartUpload(ctx, upload.UploadID, upload.Key, []s3.Part{
+		{PartNumber: 1, ETag: md5Hex(parts[0])},
+		{PartNumber: 2, ETag: md5Hex(parts[1])},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, md5Hex([]byte("part one,part two")), res.ETag)
+
+	_, err = l.ListUploadedParts(ctx, upload.UploadID, upload.Key, 0, 10)
+	assert.True(t, l.IsNotFound(err))
+}
+
+// postForm posts the fields in order and the file last, unless fileFirst is set.
+func postForm(l *Local, fields [][2]string, file []byte, fileFirst bool) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	writeFile := func() {
+		fw, _ := mw.CreateFormFile(formFile, "file")
+		_, _ = fw.Write(file)
+	}
+	if fileFirst {
+		writeFile()
+	}
+	for _, field := range fields {
+		_ = mw.WriteField(field[0], field[1])
+	}
+	if !fileFirst {
+		writeFile()
+	}
+	_ = mw.Close()
+	r := httptest.NewRequest(http.MethodPost, "/object/"+FormDataPath, &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	l.ServeFormData(w, r)
+	return w
+}
+
+func TestFormData(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocal(t)
+	content := []byte("form content")
+	form, err := l.FormData(ctx, "form.txt", int64(len(content)), "text/plain", time.Minute)
+	assert.NoError(t, err)
+	fields := [][2]string{
+		{formKey, form.FormData[formKey]},
+		{formPolicy, form.FormData[formPolicy]},
+		{formSignature, form.FormData[formSignature]},
+	}
+
+	// the file is refused before the policy is verified
+	assert.Equal(t, http.StatusForbidden, postForm(l, fields, content, true).Code)
+	// a tampered signature is refused
+	tampered := append([][2]string{}, fields...)
+	tampered[2] = [2]string{formSignature, strings.Repeat("0", 64)}
+	assert.Equal(t, http.StatusForbidden, postForm(l, tampered, content, false).Code)
+	// another key than the signed one is refused
+	renamed := append([][2]string{}, fields...)
+	renamed[0] = [2]string{formKey, "other.txt"}
+	assert.Equal(t, http.StatusBadRequest, postForm(l, renamed, content, false).Code)
+	// oversized fields are refused
+	huge := append([][2]string{{"pad", strings.Repeat("x", int(maxFormFieldsSize))}}, fields...)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postForm(l, huge, content, false).Code)
+	// a file of another size than the signed one is not stored
+	assert.Equal(t, http.StatusBadRequest, postForm(l, fields, append(content, '!'), false).Code)
+	assert.Equal(t, http.StatusBadRequest, postForm(l, fields, content[1:], false).Code)
+	_, err = l.StatObject(ctx, "form.txt")
+	assert.True(t, l.IsNotFound(err))
+
+	w := postForm(l, fields, content, false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"`+md5Hex(content)+`"`, w.Header().Get("ETag"))
+	info, err := l.StatObject(ctx, "form.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(content)), info.Size)
+}
+
+func TestThumbnail(t *testing.T) {
+	ctx := context.Background()
+	l := newTestLocal(t)
+	buf := bytes.NewBuffer(nil)
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 200, 100))))
+	_, err := l.putObject("img.png", buf)
+	assert.NoError(t, err)
+
+	thumbnailURL, err := l.AccessURL(ctx, "img.png", time.Minute, &s3.AccessURLOption{Image: &s3.Image{Width: 50, Height: 50}})
+	assert.NoError(t, err)
+	w := serve(l, http.MethodGet, thumbnailURL, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	img, _, err := image.Decode(w.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(50, 25), img.Bounds().Size())
+
+	_, err = l.putObject("text.txt", strings.NewReader("not image"))
+	assert.NoError(t, err)
+	_, err = l.AccessURL(ctx, "text.txt", time.Minute, &s3.AccessURLOption{Image: &s3.Image{Width: 50}})
+	assert.Error(t, err)
+}
@@ -66,7 +66,11 @@ func CheckMinIO(ctx context.Context, config *config.Minio) error {
 }
 
 func CheckKafka(ctx context.Context, conf *config.Kafka) error {
-	return kafka.Check(ctx, conf.Build(), []string{conf.ToMongoTopic, conf.ToRedisTopic, conf.ToPushTopic})
+	topics := []string{conf.ToMongoTopic, conf.ToRedisTopic, conf.ToPushTopic}
+	if conf.ToDeadLetterTopic != "" {
+		topics = append(topics, conf.ToDeadLetterTopic)
+	}
+	return kafka.Check(ctx, conf.Build(), topics)
 }
 
 func initConfig(configDir string) (*config.Mongo, *config.Redis, *config.Kafka, *config.Minio, *config.Discovery, error) {
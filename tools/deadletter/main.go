@@ -0,0 +1,222 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command deadletter lists the records msg-transfer sent to the dead-letter topic and replays them.
+// Progress is committed under a consumer group, so every record is replayed at most once per group.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/IBM/sarama"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/internal/msgtransfer"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/cmd"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/mq/kafka"
+	"google.golang.org/protobuf/proto"
+)
+
+type deadLetter struct {
+	conf     *config.Kafka
+	replay   bool
+	limit    int
+	count    int
+	client   sarama.Client
+	consumer sarama.Consumer
+	offsets  sarama.OffsetManager
+	producer sarama.SyncProducer
+}
+
+func main() {
+	var (
+		configDir string
+		groupID   string
+		replay    bool
+		limit     int
+	)
+	flag.StringVar(&configDir, "c", "", "config directory")
+	flag.StringVar(&groupID, "g", "deadLetterReplay", "consumer group recording the replay progress")
+	flag.BoolVar(&replay, "replay", false, "replay the records instead of listing them")
+	flag.IntVar(&limit, "n", 0, "maximum number of records to handle, 0 means all")
+	flag.Parse()
+	if err := run(configDir, groupID, replay, limit); err != nil {
+		fmt.Println("dead letter", err)
+		os.Exit(1)
+	}
+}
+
+func run(configDir string, groupID string, replay bool, limit int) error {
+	var kafkaConfig config.Kafka
+	err := config.LoadConfig(filepath.Join(configDir, cmd.KafkaConfigFileName), cmd.ConfigEnvPrefixMap[cmd.KafkaConfigFileName], &kafkaConfig)
+	if err != nil {
+		return err
+	}
+	if kafkaConfig.ToDeadLetterTopic == "" {
+		return errors.New("toDeadLetterTopic is not configured")
+	}
+	conf, err := kafka.BuildConsumerGroupConfig(kafkaConfig.Build(), sarama.OffsetOldest, false)
+	if err != nil {
+		return err
+	}
+	d := &deadLetter{conf: &kafkaConfig, replay: replay, limit: limit}
+	d.client, err = sarama.NewClient(kafkaConfig.Address, conf)
+	if err != nil {
+		return err
+	}
+	defer d.client.Close()
+	d.consumer, err = sarama.NewConsumerFromClient(d.client)
+	if err != nil {
+		return err
+	}
+	defer d.consumer.Close()
+	d.offsets, err = sarama.NewOffsetManagerFromClient(groupID, d.client)
+	if err != nil {
+		return err
+	}
+	defer d.offsets.Close()
+	if replay {
+		producerConf, err := kafka.BuildProducerConfig(*kafkaConfig.Build())
+		if err != nil {
+			return err
+		}
+		d.producer, err = kafka.NewProducer(producerConf, kafkaConfig.Address)
+		if err != nil {
+			return err
+		}
+		defer d.producer.Close()
+	}
+	partitions, err := d.client.Partitions(kafkaConfig.ToDeadLetterTopic)
+	if err != nil {
+		return err
+	}
+	for _, partition := range partitions {
+		if d.limit > 0 && d.count >= d.limit {
+			break
+		}
+		if err := d.partition(partition); err != nil {
+			return err
+		}
+	}
+	if replay {
+		fmt.Printf("replayed %d records\n", d.count)
+	} else {
+		fmt.Printf("%d records pending\n", d.count)
+	}
+	return nil
+}
+
+// partition handles the records of one partition that the group has not replayed yet.
+func (d *deadLetter) partition(partition int32) error {
+	topic := d.conf.ToDeadLetterTopic
+	pom, err := d.offsets.ManagePartition(topic, partition)
+	if err != nil {
+		return err
+	}
+	defer pom.Close()
+	oldest, err := d.client.GetOffset(topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return err
+	}
+	newest, err := d.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return err
+	}
+	offset, _ := pom.NextOffset()
+	if offset < oldest {
+		offset = oldest
+	}
+	if offset >= newest {
+		return nil
+	}
+	pc, err := d.consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return err
+	}
+	defer pc.Close()
+	for msg := range pc.Messages() {
+		if d.replay {
+			if err := d.republish(msg); err != nil {
+				return err
+			}
+			pom.MarkOffset(msg.Offset+1, "")
+			d.offsets.Commit()
+		} else {
+			printRecord(msg)
+		}
+		d.count++
+		if msg.Offset+1 >= newest || (d.limit > 0 && d.count >= d.limit) {
+			break
+		}
+	}
+	return nil
+}
+
+// republish sends the record back to the topic of the stage it failed at.
+func (d *deadLetter) republish(msg *sarama.ConsumerMessage) error {
+	out := &sarama.ProducerMessage{
+		Key:   sarama.ByteEncoder(msg.Key),
+		Value: sarama.ByteEncoder(msg.Value),
+	}
+	for _, header := range msgtransfer.ContextHeaders(msg.Headers) {
+		out.Headers = append(out.Headers, *header)
+	}
+	switch stage := msgtransfer.DeadLetterHeader(msg.Headers, msgtransfer.DeadLetterStageHeader); stage {
+	case msgtransfer.DeadLetterStageMongo:
+		// The messages are cached with their seq already, going through the cache again would allocate new ones.
+		out.Topic = d.conf.ToMongoTopic
+	case msgtransfer.DeadLetterStageRedis, "":
+		out.Topic = d.conf.ToRedisTopic
+		// Carried along so that a message failing again is dead-lettered with the next attempt.
+		out.Headers = append(out.Headers, sarama.RecordHeader{
+			Key:   []byte(msgtransfer.DeadLetterAttemptHeader),
+			Value: []byte(msgtransfer.DeadLetterHeader(msg.Headers, msgtransfer.DeadLetterAttemptHeader)),
+		})
+	default:
+		return fmt.Errorf("unknown stage %s at partition %d offset %d", stage, msg.Partition, msg.Offset)
+	}
+	if _, _, err := d.producer.SendMessage(out); err != nil {
+		return err
+	}
+	fmt.Printf("partition %d offset %d replayed to %s\n", msg.Partition, msg.Offset, out.Topic)
+	return nil
+}
+
+func printRecord(msg *sarama.ConsumerMessage) {
+	var (
+		stage   = msgtransfer.DeadLetterHeader(msg.Headers, msgtransfer.DeadLetterStageHeader)
+		summary string
+	)
+	if stage == msgtransfer.DeadLetterStageMongo {
+		var data pbmsg.MsgDataToMongoByMQ
+		if err := proto.Unmarshal(msg.Value, &data); err == nil {
+			summary = fmt.Sprintf("msgs %d lastSeq %d", len(data.MsgData), data.LastSeq)
+		}
+	} else {
+		var data sdkws.MsgData
+		if err := proto.Unmarshal(msg.Value, &data); err == nil {
+			summary = fmt.Sprintf("clientMsgID %s sendID %s", data.ClientMsgID, data.SendID)
+		}
+	}
+	fmt.Printf("partition %d offset %d time %s stage %s conversationID %s attempt %d %s error %s\n",
+		msg.Partition, msg.Offset, msg.Timestamp.Format("2006-01-02 15:04:05"), stage,
+		msgtransfer.DeadLetterHeader(msg.Headers, msgtransfer.DeadLetterConversationIDHeader),
+		msgtransfer.DeadLetterAttempt(msg.Headers), summary,
+		msgtransfer.DeadLetterHeader(msg.Headers, msgtransfer.DeadLetterErrorHeader))
+}
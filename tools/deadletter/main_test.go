@@ -0,0 +1,95 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/KyleYe/open-im-server/v3/internal/msgtransfer"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func deadLetterRecord(stage string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Key:   []byte("key"),
+		Value: []byte("value"),
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("operationID"), Value: []byte("op")},
+			{Key: []byte(msgtransfer.DeadLetterErrorHeader), Value: []byte("redis down")},
+			{Key: []byte(msgtransfer.DeadLetterConversationIDHeader), Value: []byte("si_u1_u2")},
+			{Key: []byte(msgtransfer.DeadLetterAttemptHeader), Value: []byte("2")},
+			{Key: []byte(msgtransfer.DeadLetterStageHeader), Value: []byte(stage)},
+		},
+	}
+}
+
+func replayed(t *testing.T, stage string) (*sarama.ProducerMessage, error) {
+	var out *sarama.ProducerMessage
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		out = msg
+		return nil
+	})
+	d := &deadLetter{
+		conf:     &config.Kafka{ToRedisTopic: "toRedis", ToMongoTopic: "toMongo", ToDeadLetterTopic: "deadLetter"},
+		replay:   true,
+		producer: producer,
+	}
+	err := d.republish(deadLetterRecord(stage))
+	if err == nil {
+		assert.NoError(t, producer.Close())
+	}
+	return out, err
+}
+
+func headerMap(headers []sarama.RecordHeader) map[string]string {
+	res := make(map[string]string, len(headers))
+	for _, header := range headers {
+		res[string(header.Key)] = string(header.Value)
+	}
+	return res
+}
+
+func TestRepublishRedisStage(t *testing.T) {
+	for _, stage := range []string{msgtransfer.DeadLetterStageRedis, ""} {
+		out, err := replayed(t, stage)
+		assert.NoError(t, err)
+		assert.Equal(t, "toRedis", out.Topic)
+		value, _ := out.Value.Encode()
+		assert.Equal(t, "value", string(value))
+		key, _ := out.Key.Encode()
+		assert.Equal(t, "key", string(key))
+		// The attempt survives the replay, the other dead-letter headers are dropped.
+		assert.Equal(t, map[string]string{
+			"operationID":                       "op",
+			msgtransfer.DeadLetterAttemptHeader: "2",
+		}, headerMap(out.Headers))
+	}
+}
+
+func TestRepublishMongoStage(t *testing.T) {
+	out, err := replayed(t, msgtransfer.DeadLetterStageMongo)
+	assert.NoError(t, err)
+	assert.Equal(t, "toMongo", out.Topic)
+	assert.Equal(t, map[string]string{"operationID": "op"}, headerMap(out.Headers))
+}
+
+func TestRepublishUnknownStage(t *testing.T) {
+	d := &deadLetter{conf: &config.Kafka{}, replay: true, producer: mocks.NewSyncProducer(t, nil)}
+	assert.Error(t, d.republish(deadLetterRecord("push")))
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	pbgroup "github.com/KyleYe/open-im-protocol/group"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/spf13/cobra"
+)
+
+const groupMemberPageSize = 500
+
+func (c *ctl) groupCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "group", Short: "inspect groups"}
+	cmd.AddCommand(c.groupMembersCmd())
+	return cmd
+}
+
+func (c *ctl) groupMembersCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "members <groupID>",
+		Short: "dump the members of a group",
+		Args:  cobra.ExactArgs(1),
+		RunE: c.run(func(ctx context.Context, args []string) error {
+			conn, err := c.conn(ctx, c.share.RpcRegisterName.Group)
+			if err != nil {
+				return err
+			}
+			client := pbgroup.NewGroupClient(conn)
+			var members []*sdkws.GroupMemberFullInfo
+			for page := int32(1); ; page++ {
+				resp, err := client.GetGroupMemberList(ctx, &pbgroup.GetGroupMemberListReq{
+					GroupID:    args[0],
+					Pagination: &sdkws.RequestPagination{PageNumber: page, ShowNumber: groupMemberPageSize},
+				})
+				if err != nil {
+					return err
+				}
+				members = append(members, resp.Members...)
+				if len(resp.Members) < groupMemberPageSize || uint32(len(members)) >= resp.Total {
+					break
+				}
+			}
+			rows := make([][]string, 0, len(members))
+			now := time.Now().UnixMilli()
+			for _, member := range members {
+				mutedUntil := "-"
+				if member.MuteEndTime > now {
+					mutedUntil = formatMilli(member.MuteEndTime)
+				}
+				rows = append(rows, []string{
+					member.UserID,
+					member.Nickname,
+					strconv.Itoa(int(member.RoleLevel)),
+					formatMilli(member.JoinTime),
+					mutedUntil,
+					member.InviterUserID,
+				})
+			}
+			return c.out.Print(members, []string{"USER ID", "NICKNAME", "ROLE LEVEL", "JOINED", "MUTED UNTIL", "INVITER"}, rows)
+		}),
+	}
+}
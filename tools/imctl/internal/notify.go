@@ -0,0 +1,90 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/utils/idutil"
+	"github.com/KyleYe/open-im-tools/utils/jsonutil"
+	"github.com/KyleYe/open-im-tools/utils/timeutil"
+	"github.com/spf13/cobra"
+)
+
+// notifyCmd sends business notifications the same way as /msg/send_business_notification.
+func (c *ctl) notifyCmd() *cobra.Command {
+	var (
+		sendID string
+		key    string
+		data   string
+	)
+	cmd := &cobra.Command{
+		Use:   "notify <recvUserID>...",
+		Short: "send a business notification to users",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.Flags().StringVar(&sendID, "send-id", "", "sender of the notification, the first app manager by default")
+	cmd.Flags().StringVar(&key, "key", "", "key of the notification")
+	cmd.Flags().StringVar(&data, "data", "", "data of the notification")
+	_ = cmd.MarkFlagRequired("key")
+	cmd.RunE = c.run(func(ctx context.Context, args []string) error {
+		if sendID == "" {
+			sendID = c.share.IMAdminUserID[0]
+		}
+		client, err := c.msgClient(ctx)
+		if err != nil {
+			return err
+		}
+		content := jsonutil.StructToJsonString(&sdkws.NotificationElem{
+			Detail: jsonutil.StructToJsonString(&struct {
+				Key  string `json:"key"`
+				Data string `json:"data"`
+			}{Key: key, Data: data}),
+		})
+		results := make([]*pbmsg.SendMsgResp, 0, len(args))
+		rows := make([][]string, 0, len(args))
+		for _, recvID := range args {
+			resp, err := client.SendMsg(ctx, &pbmsg.SendMsgReq{
+				MsgData: &sdkws.MsgData{
+					SendID:      sendID,
+					RecvID:      recvID,
+					Content:     []byte(content),
+					MsgFrom:     constant.SysMsgType,
+					ContentType: constant.BusinessNotification,
+					SessionType: constant.SingleChatType,
+					CreateTime:  timeutil.GetCurrentTimestampByMill(),
+					ClientMsgID: idutil.GetMsgIDByMD5(sendID),
+					Options: config.GetOptionsByNotification(config.NotificationConfig{
+						IsSendMsg:        false,
+						ReliabilityLevel: 1,
+						UnreadCount:      false,
+					}),
+				},
+			})
+			if err != nil {
+				return errs.WrapMsg(err, "send notification failed", "recvID", recvID)
+			}
+			results = append(results, resp)
+			rows = append(rows, []string{recvID, resp.ClientMsgID, resp.ServerMsgID})
+		}
+		return c.out.Print(results, []string{"RECV ID", "CLIENT MSG ID", "SERVER MSG ID"}, rows)
+	})
+	return cmd
+}
@@ -0,0 +1,101 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/KyleYe/open-im-protocol/msggateway"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	pbuser "github.com/KyleYe/open-im-protocol/user"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/spf13/cobra"
+)
+
+const onlineBatchSize = 500
+
+// gatewayConn is one connection held by a gateway.
+type gatewayConn struct {
+	Gateway      string `json:"gateway"`
+	UserID       string `json:"userID"`
+	Platform     string `json:"platform"`
+	ConnID       string `json:"connID"`
+	IsBackground bool   `json:"isBackground"`
+}
+
+func (c *ctl) onlineCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "online [userID]...",
+		Short: "show the connections of users on every gateway, of all users when none is given",
+		RunE: c.run(func(ctx context.Context, args []string) error {
+			userIDs := args
+			if len(userIDs) == 0 {
+				var err error
+				if userIDs, err = c.allUserIDs(ctx); err != nil {
+					return err
+				}
+			}
+			conns, err := c.client.GetConns(ctx, c.share.RpcRegisterName.MessageGateway)
+			if err != nil {
+				return errs.WrapMsg(err, "get gateway conns failed")
+			}
+			var result []*gatewayConn
+			for _, conn := range conns {
+				client := msggateway.NewMsgGatewayClient(conn)
+				for i := 0; i < len(userIDs); i += onlineBatchSize {
+					resp, err := client.GetUsersOnlineStatus(ctx, &msggateway.GetUsersOnlineStatusReq{UserIDs: userIDs[i:min(i+onlineBatchSize, len(userIDs))]})
+					if err != nil {
+						return errs.WrapMsg(err, "get users online status failed", "gateway", conn.Target())
+					}
+					for _, user := range resp.SuccessResult {
+						for _, detail := range user.DetailPlatformStatus {
+							result = append(result, &gatewayConn{
+								Gateway:      conn.Target(),
+								UserID:       user.UserID,
+								Platform:     detail.Platform,
+								ConnID:       detail.ConnID,
+								IsBackground: detail.IsBackground,
+							})
+						}
+					}
+				}
+			}
+			rows := make([][]string, 0, len(result))
+			for _, conn := range result {
+				rows = append(rows, []string{conn.Gateway, conn.UserID, conn.Platform, conn.ConnID, strconv.FormatBool(conn.IsBackground)})
+			}
+			return c.out.Print(result, []string{"GATEWAY", "USER ID", "PLATFORM", "CONN ID", "BACKGROUND"}, rows)
+		}),
+	}
+}
+
+func (c *ctl) allUserIDs(ctx context.Context) ([]string, error) {
+	client, err := c.userClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var userIDs []string
+	for page := int32(1); ; page++ {
+		resp, err := client.GetAllUserID(ctx, &pbuser.GetAllUserIDReq{Pagination: &sdkws.RequestPagination{PageNumber: page, ShowNumber: onlineBatchSize}})
+		if err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, resp.UserIDs...)
+		if len(resp.UserIDs) < onlineBatchSize || len(userIDs) >= int(resp.Total) {
+			return userIDs, nil
+		}
+	}
+}
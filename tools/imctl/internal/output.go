@@ -0,0 +1,77 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KyleYe/open-im-tools/errs"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// output prints the result of a command as a table for people or as JSON for scripts.
+type output struct {
+	format string
+	w      io.Writer
+}
+
+func newOutput(format string, w io.Writer) (*output, error) {
+	switch format {
+	case formatTable, formatJSON:
+		return &output{format: format, w: w}, nil
+	default:
+		return nil, errs.ErrArgs.WrapMsg("unknown output format", "format", format)
+	}
+}
+
+// Print writes v as JSON, or header and rows as a table.
+func (o *output) Print(v any, header []string, rows [][]string) error {
+	if o.format == formatJSON {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return errs.Wrap(enc.Encode(v))
+	}
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return errs.Wrap(tw.Flush())
+}
+
+// Done reports an action without a result.
+func (o *output) Done(action string, keyvals ...any) error {
+	if o.format == formatJSON {
+		v := map[string]any{"action": action}
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			v[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+		}
+		return o.Print(v, nil, nil)
+	}
+	line := action
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		line += fmt.Sprintf(" %v=%v", keyvals[i], keyvals[i+1])
+	}
+	_, err := fmt.Fprintln(o.w, line)
+	return errs.Wrap(err)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	out, err := newOutput(formatTable, buf)
+	assert.NoError(t, err)
+	seqs := []*conversationSeq{{ConversationID: "si_a_b", MaxSeq: 10, UserMaxSeq: 10, HasReadSeq: 12}}
+	assert.NoError(t, out.Print(seqs, []string{"CONVERSATION ID", "HAS READ SEQ"}, [][]string{{"si_a_b", "12"}}))
+	assert.Equal(t, "CONVERSATION ID  HAS READ SEQ\nsi_a_b           12\n", buf.String())
+
+	buf.Reset()
+	out, err = newOutput(formatJSON, buf)
+	assert.NoError(t, err)
+	assert.NoError(t, out.Print(seqs, nil, nil))
+	assert.JSONEq(t, `[{"conversationID":"si_a_b","maxSeq":10,"userMaxSeq":10,"hasReadSeq":12}]`, buf.String())
+
+	_, err = newOutput("yaml", buf)
+	assert.Error(t, err)
+}
+
+func TestParsePlatform(t *testing.T) {
+	id, err := parsePlatform("1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(constant.IOSPlatformID), id)
+	id, err = parsePlatform(constant.PlatformIDToName(constant.WebPlatformID))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(constant.WebPlatformID), id)
+	_, err = parsePlatform("100")
+	assert.Error(t, err)
+	_, err = parsePlatform("Unknown")
+	assert.Error(t, err)
+}
+
+func TestConversationSeqBroken(t *testing.T) {
+	assert.False(t, (&conversationSeq{UserMaxSeq: 10, HasReadSeq: 10}).broken())
+	assert.True(t, (&conversationSeq{UserMaxSeq: 10, HasReadSeq: 11}).broken())
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/KyleYe/open-im-server/v3/pkg/common/cmd"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/config"
+	kdisc "github.com/KyleYe/open-im-server/v3/pkg/common/discoveryregister"
+	"github.com/KyleYe/open-im-server/v3/pkg/common/rpctls"
+	"github.com/KyleYe/open-im-tools/discovery"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/KyleYe/open-im-tools/mcontext"
+	"github.com/KyleYe/open-im-tools/mw"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// ctl holds what every command needs: the configuration, the discovery client and the output.
+type ctl struct {
+	configDir string
+	format    string
+	timeout   time.Duration
+
+	share     config.Share
+	discovery config.Discovery
+	client    discovery.SvcDiscoveryRegistry
+	out       *output
+}
+
+// NewRootCmd returns the imctl command, its sub commands call the rpc services as the first app manager.
+func NewRootCmd() *cobra.Command {
+	c := &ctl{}
+	root := &cobra.Command{
+		Use:           "imctl",
+		Short:         "imctl controls an OpenIM server through its rpc services",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return c.init(cmd)
+		},
+	}
+	root.PersistentFlags().StringVarP(&c.configDir, cmd.FlagConf, "c", "config", "path of config directory")
+	root.PersistentFlags().StringVarP(&c.format, "output", "o", formatTable, "output format, table or json")
+	root.PersistentFlags().DurationVar(&c.timeout, "timeout", time.Second*30, "timeout of the command")
+	root.AddCommand(
+		c.userCmd(),
+		c.tokenCmd(),
+		c.seqCmd(),
+		c.groupCmd(),
+		c.notifyCmd(),
+		c.onlineCmd(),
+	)
+	return root
+}
+
+func (c *ctl) init(command *cobra.Command) error {
+	out, err := newOutput(c.format, command.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	c.out = out
+	configs := map[string]any{
+		cmd.ShareFileName:           &c.share,
+		cmd.DiscoveryConfigFilename: &c.discovery,
+	}
+	for name, conf := range configs {
+		if err := config.LoadConfig(filepath.Join(c.configDir, name), cmd.ConfigEnvPrefixMap[name], conf); err != nil {
+			return err
+		}
+	}
+	if len(c.share.IMAdminUserID) == 0 {
+		return errs.New("imAdminUserID is not configured").Wrap()
+	}
+	c.client, err = kdisc.NewDiscoveryRegister(&c.discovery, &c.share)
+	if err != nil {
+		return errs.WrapMsg(err, "failed to create discovery client")
+	}
+	creds, err := rpctls.New(&c.share.TLS)
+	if err != nil {
+		return err
+	}
+	c.client.AddOption(mw.GrpcClient(), creds.DialOption())
+	return nil
+}
+
+// context returns the context of one command, the calls are made as the first app manager.
+func (c *ctl) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	ctx = mcontext.SetOperationID(ctx, fmt.Sprintf("imctl_%d_%d", os.Getpid(), time.Now().UnixMilli()))
+	ctx = mcontext.SetOpUserID(ctx, c.share.IMAdminUserID[0])
+	return ctx, cancel
+}
+
+func (c *ctl) conn(ctx context.Context, serviceName string) (grpc.ClientConnInterface, error) {
+	conn, err := c.client.GetConn(ctx, serviceName)
+	if err != nil {
+		return nil, errs.WrapMsg(err, "get rpc conn failed", "service", serviceName)
+	}
+	return conn, nil
+}
+
+// run adapts a command to the context of imctl.
+func (c *ctl) run(fn func(ctx context.Context, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := c.context()
+		defer cancel()
+		return fn(ctx, args)
+	}
+}
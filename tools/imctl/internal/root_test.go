@@ -0,0 +1,65 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootCmd(t *testing.T) {
+	root := NewRootCmd()
+	for _, path := range [][]string{
+		{"user", "register"},
+		{"user", "get"},
+		{"user", "kick"},
+		{"token", "issue"},
+		{"token", "revoke"},
+		{"seq", "get"},
+		{"seq", "repair"},
+		{"group", "members"},
+		{"notify"},
+		{"online"},
+	} {
+		cmd, rest, err := root.Find(path)
+		assert.NoError(t, err, path)
+		assert.Empty(t, rest, path)
+		assert.Equal(t, path[len(path)-1], cmd.Name(), path)
+	}
+}
+
+func TestOutputDone(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	out, err := newOutput(formatTable, buf)
+	assert.NoError(t, err)
+	assert.NoError(t, out.Done("kicked", "userID", "u1", "platforms", 3))
+	assert.Equal(t, "kicked userID=u1 platforms=3\n", buf.String())
+
+	buf.Reset()
+	out, err = newOutput(formatJSON, buf)
+	assert.NoError(t, err)
+	assert.NoError(t, out.Done("kicked", "userID", "u1"))
+	assert.JSONEq(t, `{"action":"kicked","userID":"u1"}`, buf.String())
+}
+
+func TestFormatMilli(t *testing.T) {
+	assert.Equal(t, "-", formatMilli(0))
+	assert.NotEqual(t, "-", formatMilli(1700000000000))
+	ids := platformIDs()
+	assert.NotEmpty(t, ids)
+	assert.IsIncreasing(t, ids)
+}
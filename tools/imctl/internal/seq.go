@@ -0,0 +1,158 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	pbmsg "github.com/KyleYe/open-im-protocol/msg"
+	"github.com/spf13/cobra"
+)
+
+func (c *ctl) seqCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "seq", Short: "inspect and repair the conversation seqs of a user"}
+	cmd.AddCommand(c.seqGetCmd(), c.seqRepairCmd())
+	return cmd
+}
+
+func (c *ctl) msgClient(ctx context.Context) (pbmsg.MsgClient, error) {
+	conn, err := c.conn(ctx, c.share.RpcRegisterName.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return pbmsg.NewMsgClient(conn), nil
+}
+
+// conversationSeq is the state of one conversation of a user.
+type conversationSeq struct {
+	ConversationID string `json:"conversationID"`
+	// MaxSeq of the conversation
+	MaxSeq int64 `json:"maxSeq"`
+	// UserMaxSeq is the last seq the user can see, it stops at MaxSeq when the user left the group
+	UserMaxSeq int64 `json:"userMaxSeq"`
+	HasReadSeq int64 `json:"hasReadSeq"`
+}
+
+// broken reports whether the user has read beyond what they can see, the unread count of the conversation is negative then.
+func (s *conversationSeq) broken() bool {
+	return s.HasReadSeq > s.UserMaxSeq
+}
+
+// conversationSeqs returns the seqs of the conversations of the user, all of them when conversationIDs is empty.
+func (c *ctl) conversationSeqs(ctx context.Context, client pbmsg.MsgClient, userID string, conversationIDs []string) ([]*conversationSeq, error) {
+	resp, err := client.GetConversationsHasReadAndMaxSeq(ctx, &pbmsg.GetConversationsHasReadAndMaxSeqReq{UserID: userID, ConversationIDs: conversationIDs})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(resp.Seqs))
+	for conversationID := range resp.Seqs {
+		ids = append(ids, conversationID)
+	}
+	sort.Strings(ids)
+	maxSeqs, err := client.GetMaxSeqs(ctx, &pbmsg.GetMaxSeqsReq{ConversationIDs: ids})
+	if err != nil {
+		return nil, err
+	}
+	seqs := make([]*conversationSeq, 0, len(ids))
+	for _, conversationID := range ids {
+		seqs = append(seqs, &conversationSeq{
+			ConversationID: conversationID,
+			MaxSeq:         maxSeqs.MaxSeqs[conversationID],
+			UserMaxSeq:     resp.Seqs[conversationID].MaxSeq,
+			HasReadSeq:     resp.Seqs[conversationID].HasReadSeq,
+		})
+	}
+	return seqs, nil
+}
+
+func (c *ctl) printSeqs(seqs []*conversationSeq) error {
+	rows := make([][]string, 0, len(seqs))
+	for _, seq := range seqs {
+		state := "ok"
+		if seq.broken() {
+			state = "broken"
+		}
+		rows = append(rows, []string{
+			seq.ConversationID,
+			strconv.FormatInt(seq.MaxSeq, 10),
+			strconv.FormatInt(seq.UserMaxSeq, 10),
+			strconv.FormatInt(seq.HasReadSeq, 10),
+			strconv.FormatInt(max(seq.UserMaxSeq-seq.HasReadSeq, 0), 10),
+			state,
+		})
+	}
+	return c.out.Print(seqs, []string{"CONVERSATION ID", "MAX SEQ", "USER MAX SEQ", "HAS READ SEQ", "UNREAD", "STATE"}, rows)
+}
+
+func (c *ctl) seqGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <userID> [conversationID]...",
+		Short: "show the seqs of the conversations of a user",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: c.run(func(ctx context.Context, args []string) error {
+			client, err := c.msgClient(ctx)
+			if err != nil {
+				return err
+			}
+			seqs, err := c.conversationSeqs(ctx, client, args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			return c.printSeqs(seqs)
+		}),
+	}
+}
+
+func (c *ctl) seqRepairCmd() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:   "repair <userID> [conversationID]...",
+		Short: "move the has read seqs of a user back to the last seq they can see",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show the conversations that would be repaired")
+	cmd.RunE = c.run(func(ctx context.Context, args []string) error {
+		client, err := c.msgClient(ctx)
+		if err != nil {
+			return err
+		}
+		seqs, err := c.conversationSeqs(ctx, client, args[0], args[1:])
+		if err != nil {
+			return err
+		}
+		var broken []*conversationSeq
+		for _, seq := range seqs {
+			if !seq.broken() {
+				continue
+			}
+			if !dryRun {
+				_, err := client.SetConversationHasReadSeq(ctx, &pbmsg.SetConversationHasReadSeqReq{
+					ConversationID: seq.ConversationID,
+					UserID:         args[0],
+					HasReadSeq:     seq.UserMaxSeq,
+				})
+				if err != nil {
+					return err
+				}
+				seq.HasReadSeq = seq.UserMaxSeq
+			}
+			broken = append(broken, seq)
+		}
+		return c.printSeqs(broken)
+	})
+	return cmd
+}
@@ -0,0 +1,108 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"strconv"
+
+	pbauth "github.com/KyleYe/open-im-protocol/auth"
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/spf13/cobra"
+)
+
+func (c *ctl) tokenCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "token", Short: "issue and revoke user tokens"}
+	cmd.AddCommand(c.tokenIssueCmd(), c.tokenRevokeCmd())
+	return cmd
+}
+
+func (c *ctl) authClient(ctx context.Context) (pbauth.AuthClient, error) {
+	conn, err := c.conn(ctx, c.share.RpcRegisterName.Auth)
+	if err != nil {
+		return nil, err
+	}
+	return pbauth.NewAuthClient(conn), nil
+}
+
+// parsePlatform accepts a platform id or name, e.g. 1 or IOS.
+func parsePlatform(platform string) (int32, error) {
+	if id, err := strconv.Atoi(platform); err == nil {
+		if _, ok := constant.PlatformID2Name[id]; ok {
+			return int32(id), nil
+		}
+	} else if id := constant.PlatformNameToID(platform); id != 0 {
+		return int32(id), nil
+	}
+	return 0, errs.ErrArgs.WrapMsg("unknown platform", "platform", platform)
+}
+
+func (c *ctl) tokenIssueCmd() *cobra.Command {
+	var platform string
+	cmd := &cobra.Command{
+		Use:   "issue <userID>",
+		Short: "issue a token to a user",
+		Args:  cobra.ExactArgs(1),
+	}
+	cmd.Flags().StringVarP(&platform, "platform", "p", constant.PlatformIDToName(constant.WebPlatformID), "platform id or name")
+	cmd.RunE = c.run(func(ctx context.Context, args []string) error {
+		platformID, err := parsePlatform(platform)
+		if err != nil {
+			return err
+		}
+		client, err := c.authClient(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := client.UserToken(ctx, &pbauth.UserTokenReq{Secret: c.share.Secret, UserID: args[0], PlatformID: platformID})
+		if err != nil {
+			return err
+		}
+		return c.out.Print(resp, []string{"USER ID", "PLATFORM", "EXPIRES IN", "TOKEN"}, [][]string{{
+			args[0],
+			constant.PlatformIDToName(int(platformID)),
+			strconv.FormatInt(resp.ExpireTimeSeconds, 10) + "s",
+			resp.Token,
+		}})
+	})
+	return cmd
+}
+
+func (c *ctl) tokenRevokeCmd() *cobra.Command {
+	var platform string
+	cmd := &cobra.Command{
+		Use:   "revoke <userID>",
+		Short: "kick a user off a platform and revoke the tokens of the platform",
+		Args:  cobra.ExactArgs(1),
+	}
+	cmd.Flags().StringVarP(&platform, "platform", "p", "", "platform id or name")
+	_ = cmd.MarkFlagRequired("platform")
+	cmd.RunE = c.run(func(ctx context.Context, args []string) error {
+		platformID, err := parsePlatform(platform)
+		if err != nil {
+			return err
+		}
+		client, err := c.authClient(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := client.ForceLogout(ctx, &pbauth.ForceLogoutReq{UserID: args[0], PlatformID: platformID}); err != nil {
+			return err
+		}
+		return c.out.Done("revoked", "userID", args[0], "platform", constant.PlatformIDToName(int(platformID)))
+	})
+	return cmd
+}
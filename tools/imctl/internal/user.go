@@ -0,0 +1,144 @@
+// Copyright © 2024 OpenIM. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	pbauth "github.com/KyleYe/open-im-protocol/auth"
+	"github.com/KyleYe/open-im-protocol/constant"
+	"github.com/KyleYe/open-im-protocol/sdkws"
+	pbuser "github.com/KyleYe/open-im-protocol/user"
+	"github.com/KyleYe/open-im-tools/errs"
+	"github.com/spf13/cobra"
+)
+
+func (c *ctl) userCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "user", Short: "register, look up and kick users"}
+	cmd.AddCommand(c.userRegisterCmd(), c.userGetCmd(), c.userKickCmd())
+	return cmd
+}
+
+func (c *ctl) userClient(ctx context.Context) (pbuser.UserClient, error) {
+	conn, err := c.conn(ctx, c.share.RpcRegisterName.User)
+	if err != nil {
+		return nil, err
+	}
+	return pbuser.NewUserClient(conn), nil
+}
+
+func (c *ctl) userRegisterCmd() *cobra.Command {
+	var user sdkws.UserInfo
+	cmd := &cobra.Command{
+		Use:   "register <userID>",
+		Short: "register a user",
+		Args:  cobra.ExactArgs(1),
+	}
+	cmd.Flags().StringVar(&user.Nickname, "nickname", "", "nickname of the user")
+	cmd.Flags().StringVar(&user.FaceURL, "face-url", "", "avatar of the user")
+	cmd.Flags().StringVar(&user.Ex, "ex", "", "extra data of the user")
+	cmd.RunE = c.run(func(ctx context.Context, args []string) error {
+		user.UserID = args[0]
+		if user.Nickname == "" {
+			user.Nickname = user.UserID
+		}
+		client, err := c.userClient(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := client.UserRegister(ctx, &pbuser.UserRegisterReq{Users: []*sdkws.UserInfo{&user}}); err != nil {
+			return err
+		}
+		return c.out.Done("registered", "userID", user.UserID)
+	})
+	return cmd
+}
+
+func (c *ctl) userGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <userID>...",
+		Short: "show users",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: c.run(func(ctx context.Context, args []string) error {
+			client, err := c.userClient(ctx)
+			if err != nil {
+				return err
+			}
+			resp, err := client.GetDesignateUsers(ctx, &pbuser.GetDesignateUsersReq{UserIDs: args})
+			if err != nil {
+				return err
+			}
+			rows := make([][]string, 0, len(resp.UsersInfo))
+			for _, user := range resp.UsersInfo {
+				rows = append(rows, []string{
+					user.UserID,
+					user.Nickname,
+					strconv.Itoa(int(user.AppMangerLevel)),
+					formatMilli(user.CreateTime),
+					user.FaceURL,
+				})
+			}
+			return c.out.Print(resp.UsersInfo, []string{"USER ID", "NICKNAME", "APP MANAGER LEVEL", "CREATED", "FACE URL"}, rows)
+		}),
+	}
+}
+
+// userKickCmd kicks the users off every platform and revokes their tokens, new tokens are still issued by UserToken.
+func (c *ctl) userKickCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "kick <userID>...",
+		Short: "kick users off every platform and revoke all of their tokens",
+		Long: "kick kicks the users off every platform and revokes all of their tokens. " +
+			"It does not stop the business server from issuing new tokens to them.",
+		Args: cobra.MinimumNArgs(1),
+		RunE: c.run(func(ctx context.Context, args []string) error {
+			client, err := c.authClient(ctx)
+			if err != nil {
+				return err
+			}
+			for _, userID := range args {
+				for _, platformID := range platformIDs() {
+					if _, err := client.ForceLogout(ctx, &pbauth.ForceLogoutReq{UserID: userID, PlatformID: int32(platformID)}); err != nil {
+						return errs.WrapMsg(err, "force logout failed", "userID", userID, "platformID", platformID)
+					}
+				}
+				if err := c.out.Done("kicked", "userID", userID); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	}
+}
+
+// platformIDs returns the ids of every platform in order.
+func platformIDs() []int {
+	ids := make([]int, 0, len(constant.PlatformID2Name))
+	for id := range constant.PlatformID2Name {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func formatMilli(milli int64) string {
+	if milli == 0 {
+		return "-"
+	}
+	return time.UnixMilli(milli).Format(time.DateTime)
+}
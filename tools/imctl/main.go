@@ -12,11 +12,19 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Command imctl controls an OpenIM server through its rpc services, see README.md.
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"os"
 
-func main() {
+	"github.com/KyleYe/open-im-server/v3/tools/imctl/internal"
+)
 
-	fmt.Println("imctl")
+func main() {
+	if err := internal.NewRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "imctl", err)
+		os.Exit(1)
+	}
 }